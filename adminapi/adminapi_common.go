@@ -22,10 +22,16 @@ import (
 	"time"
 
 	"github.com/rdkcentral/xconfwebconfig/dataapi"
+	"github.com/rdkcentral/xconfwebconfig/db"
 
 	queries "github.com/rdkcentral/xconfadmin/adminapi/queries"
 	common "github.com/rdkcentral/xconfadmin/common"
 	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xapitoken "github.com/rdkcentral/xconfadmin/shared/apitoken"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+	xrole "github.com/rdkcentral/xconfadmin/shared/role"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -50,6 +56,14 @@ func WebServerInjection(ws *xhttp.WebconfigServer, xc *dataapi.XconfConfigs) {
 		common.AuthProvider = "acl"
 		common.ApplicationTypes = []string{"stb"}
 		common.WakeupPoolTagName = "t_canary_wakeup"
+		common.ChangeApprovalBlockSelfApproval = true
+		common.ChangeApprovalRequiredApprovers = 1
+		common.ChangeApprovalRequiredApproversByType = map[string]int{}
+		common.ScheduledChangeIntervalInSecs = 60
+		common.ScheduledChangeFailedRetentionInDays = 30
+		common.AuditMaxRangeInDays = 7
+		common.ChangedKeysRetentionInDays = 30
+		common.ApiTokenMaxTtlInDays = 365
 	} else {
 		common.AuthProvider = ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.authprovider")
 		applicationTypeString := ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.application_types")
@@ -66,6 +80,24 @@ func WebServerInjection(ws *xhttp.WebconfigServer, xc *dataapi.XconfConfigs) {
 		common.CanaryCreationEnabled = ws.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.enable_canary_creation")
 		common.VideoCanaryCreationEnabled = ws.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.enable_video_canary_creation")
 		common.LockDuration = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xcrp.lock_duration_in_secs", common.DefaultLockDuration)
+		common.ChangeApprovalBlockSelfApproval = ws.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.change_approval_block_self_approval", true)
+		if !common.ChangeApprovalBlockSelfApproval {
+			log.Warn("Self-approval of changes is allowed, the authors of changes may approve them")
+		}
+		common.ChangeApprovalRequiredApprovers = int(ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.change_approval_required_approvers", 1))
+		common.ChangeApprovalRequiredApproversByType = common.ParseRequiredApprovers(ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.change_approval_required_approvers_by_type"))
+		changeWorkflowEntityTypes := ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.change_workflow_entity_types")
+		for _, entityType := range strings.Split(changeWorkflowEntityTypes, ",") {
+			if entityType = strings.ToUpper(strings.TrimSpace(entityType)); entityType != "" {
+				common.ChangeWorkflowEntityTypes.Add(entityType)
+			}
+		}
+		common.ScheduledChangeIntervalInSecs = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.scheduled_change_interval_in_secs", 60)
+		common.ScheduledChangeFailedRetentionInDays = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.scheduled_change_failed_retention_in_days", 30)
+		common.AuditMaxRangeInDays = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.audit_max_range_in_days", 7)
+		common.ChangedKeysRetentionInDays = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.changed_keys_retention_in_days", 30)
+		common.ApiTokenMaxTtlInDays = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.api_token_max_ttl_in_days", 365)
+		common.LockdownOverrideMaxDurationInMins = ws.XW_XconfServer.ServerConfig.GetInt32("xconfwebconfig.xconf.lockdown_override_max_duration_in_mins", 240)
 		if common.CanaryCreationEnabled {
 			timezoneStr := ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.canary_time_zone")
 			timezone, err := time.LoadLocation(timezoneStr)
@@ -112,6 +144,25 @@ func WebServerInjection(ws *xhttp.WebconfigServer, xc *dataapi.XconfConfigs) {
 	Xc = xc
 }
 
+// registerTables registers the tables owned by the admin service
+func registerTables() {
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_CHANGE_APPROVAL, xchange.NewChangeApprovalInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_ENTITY_CHANGE, xchange.NewEntityChangeInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_APPROVED_ENTITY_CHANGE, xchange.NewApprovedEntityChangeInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_SCHEDULED_CHANGE, xchange.NewScheduledChangeInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_CHANGE_COMMENT, xchange.NewChangeCommentInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_CHANGE_REVIEW, xchange.NewChangeReviewInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_API_TOKEN, xapitoken.NewApiTokenInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_API_TOKEN_USE, xapitoken.NewApiTokenUseInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_ROLE, xrole.NewRoleInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_ROLE_BINDING, xrole.NewRoleBindingInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_LOCKDOWN_WINDOW, xlockdown.NewLockdownWindowInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_LOCKDOWN_OVERRIDE, xlockdown.NewLockdownOverrideInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_TAG_NAMESPACE, xtagging.NewTagNamespaceInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_TAG_METADATA, xtagging.NewTagMetadataInf)
+	db.RegisterTableConfigSimple(common.TABLE_XCONF_TAG_JOB, xtagging.NewTagJobInf)
+}
+
 func initDB() {
 	queries.CreateFirmwareRuleTemplates() // Initialize FirmwareRule templates
 	initAppSettings()                     // Initialize Application settings
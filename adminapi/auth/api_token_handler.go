@@ -0,0 +1,95 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+const SERVICE_ACCOUNT = "serviceAccount"
+
+func CreateApiTokenHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanWrite(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	// r.Body is already drained in the middleware
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, "responsewriter cast error"))
+		return
+	}
+	request := ApiTokenRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "Unable to extract api token from json file:"+err.Error())
+		return
+	}
+
+	created, err := CreateApiToken(r, &request)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(created, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusCreated, xhttp.ContextTypeHeader(r))
+}
+
+func GetApiTokensHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanRead(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	tokens := GetApiTokens(r.URL.Query().Get(SERVICE_ACCOUNT))
+	res, err := xhttp.ReturnJsonResponse(tokens, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func RevokeApiTokenHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanWrite(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	revoked, err := RevokeApiToken(r, mux.Vars(r)[common.ID])
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(revoked, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
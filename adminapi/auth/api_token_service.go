@@ -0,0 +1,175 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+)
+
+type ApiTokenRequest struct {
+	ServiceAccount string   `json:"serviceAccount"`
+	Description    string   `json:"description"`
+	Permissions    []string `json:"permissions"`
+	ExpiresAt      int64    `json:"expiresAt"`
+}
+
+// CreatedApiToken is the only response which carries the token, it can not be read again
+type CreatedApiToken struct {
+	*apitoken.ApiToken
+	Token string `json:"token"`
+}
+
+// isKnownPermission accepts the permissions checked by CanRead, CanWrite and the tag checks
+func isKnownPermission(permission string) bool {
+	switch permission {
+	case READ_COMMON, WRITE_COMMON, VIEW_TOOLS, WRITE_TOOLS, READ_TAGS, WRITE_TAGS, DELETE_TAGS, MANAGE_TAG_NAMESPACES:
+		return true
+	}
+	for _, entityPermission := range applicationEntityPermissions {
+		if permission == entityPermission.ReadAll || permission == entityPermission.WriteAll {
+			return true
+		}
+		for _, applicationType := range common.ApplicationTypes {
+			if permission == entityPermission.Read+applicationType || permission == entityPermission.Write+applicationType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canGrantPermission is true when the user holds the permission, directly or with the * permission of the entity,
+// so a token never has more rights than the user who created it. The login permissions are checked even with SAT off.
+func canGrantPermission(r *http.Request, permission string) bool {
+	if capabilities := xhttp.GetCapabilitiesFromContext(r); len(capabilities) > 0 {
+		return util.Contains(capabilities, XCONF_ALL)
+	}
+	permissions := GetPermissionsFunc(r)
+	if util.Contains(permissions, permission) {
+		return true
+	}
+	for _, entityPermission := range applicationEntityPermissions {
+		if strings.HasPrefix(permission, entityPermission.Read) && util.Contains(permissions, entityPermission.ReadAll) {
+			return true
+		}
+		if strings.HasPrefix(permission, entityPermission.Write) && util.Contains(permissions, entityPermission.WriteAll) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateApiTokenRequest(r *http.Request, request *ApiTokenRequest, now int64) error {
+	if xhttp.GetApiTokenFromContext(r) != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusForbidden, "Api tokens can not be created with an api token")
+	}
+	if util.IsBlank(request.ServiceAccount) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "serviceAccount is required")
+	}
+	if len(request.Permissions) == 0 {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "permissions are required")
+	}
+	for _, permission := range request.Permissions {
+		if !isKnownPermission(permission) {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("permission %s is not known", permission))
+		}
+		if !canGrantPermission(r, permission) {
+			return xwcommon.NewRemoteErrorAS(http.StatusForbidden, fmt.Sprintf("permission %s can not be granted without holding it", permission))
+		}
+	}
+	maxExpiresAt := now + int64(common.ApiTokenMaxTtlInDays)*24*time.Hour.Milliseconds()
+	if request.ExpiresAt == 0 {
+		request.ExpiresAt = maxExpiresAt
+	}
+	if request.ExpiresAt <= now {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "expiresAt must be in the future")
+	}
+	if request.ExpiresAt > maxExpiresAt {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("expiresAt must be within %d days", common.ApiTokenMaxTtlInDays))
+	}
+	return nil
+}
+
+// CreateApiToken issues a token for the service account, only its hash is stored
+func CreateApiToken(r *http.Request, request *ApiTokenRequest) (*CreatedApiToken, error) {
+	now := xwutil.GetTimestamp(time.Now().UTC())
+	if err := validateApiTokenRequest(r, request, now); err != nil {
+		return nil, err
+	}
+	id, secret, token, err := apitoken.GenerateToken()
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	apiToken := &apitoken.ApiToken{
+		ID:             id,
+		ServiceAccount: strings.TrimSpace(request.ServiceAccount),
+		Description:    request.Description,
+		Permissions:    util.StringCopySlice(request.Permissions),
+		TokenHash:      apitoken.HashSecret(secret),
+		CreatedBy:      GetUserNameOrUnknown(r),
+		Created:        now,
+		ExpiresAt:      request.ExpiresAt,
+	}
+	if err := apitoken.SetOneApiToken(apiToken); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return &CreatedApiToken{ApiToken: apiToken.WithoutHash(), Token: token}, nil
+}
+
+// GetApiTokens lists the tokens of all service accounts, or of the given one
+func GetApiTokens(serviceAccount string) []*apitoken.ApiToken {
+	tokens := []*apitoken.ApiToken{}
+	lastUsed := apitoken.GetApiTokenLastUsed()
+	for _, apiToken := range apitoken.GetApiTokenList() {
+		if serviceAccount == "" || apiToken.ServiceAccount == serviceAccount {
+			token := apiToken.WithoutHash()
+			token.LastUsed = lastUsed[token.ID]
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
+
+// RevokeApiToken keeps the revoked token, so the use of the service account can still be audited
+func RevokeApiToken(r *http.Request, id string) (*apitoken.ApiToken, error) {
+	apiToken := apitoken.GetOneApiToken(id)
+	if apiToken == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Api token %s is not found", id))
+	}
+	if apiToken.IsRevoked() {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Api token %s is already revoked", id))
+	}
+	revoked := *apiToken
+	revoked.RevokedBy = GetUserNameOrUnknown(r)
+	revoked.Revoked = xwutil.GetTimestamp(time.Now().UTC())
+	if err := apitoken.SetOneApiToken(&revoked); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return revoked.WithoutHash(), nil
+}
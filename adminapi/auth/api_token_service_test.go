@@ -0,0 +1,97 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func withApiTokenSettings(t *testing.T, permissions []string) {
+	satOn, applicationTypes, maxTtl, getPermissions := common.SatOn, common.ApplicationTypes, common.ApiTokenMaxTtlInDays, GetPermissionsFunc
+	common.SatOn = true
+	common.ApplicationTypes = []string{"stb", "xhome"}
+	common.ApiTokenMaxTtlInDays = 30
+	GetPermissionsFunc = func(r *http.Request) []string {
+		return permissions
+	}
+	t.Cleanup(func() {
+		common.SatOn, common.ApplicationTypes, common.ApiTokenMaxTtlInDays, GetPermissionsFunc = satOn, applicationTypes, maxTtl, getPermissions
+	})
+}
+
+func TestIsKnownPermission(t *testing.T) {
+	withApiTokenSettings(t, nil)
+	for _, permission := range []string{READ_COMMON, WRITE_TOOLS, "read-firmware-stb", "write-dcm-*", "read-telemetry-xhome", "write-changes-stb"} {
+		assert.True(t, isKnownPermission(permission), permission)
+	}
+	for _, permission := range []string{"", XCONF_ALL, "read-firmware-unknown", "delete-dcm-*"} {
+		assert.False(t, isKnownPermission(permission), permission)
+	}
+}
+
+func TestValidateApiTokenRequest(t *testing.T) {
+	withApiTokenSettings(t, []string{READ_FIRMWARE_ALL, "write-dcm-stb"})
+	r := httptest.NewRequest(http.MethodPost, "/xconfAdminService/auth/apiTokens", nil)
+	now := time.Now().UnixMilli()
+	day := 24 * time.Hour.Milliseconds()
+
+	request := &ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{"read-firmware-stb", "write-dcm-stb"}}
+	assert.Nil(t, validateApiTokenRequest(r, request, now))
+	assert.Equal(t, now+30*day, request.ExpiresAt)
+
+	cases := []struct {
+		request *ApiTokenRequest
+		status  int
+	}{
+		{&ApiTokenRequest{Permissions: []string{READ_FIRMWARE_ALL}}, http.StatusBadRequest},
+		{&ApiTokenRequest{ServiceAccount: "ci"}, http.StatusBadRequest},
+		{&ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{XCONF_ALL}}, http.StatusBadRequest},
+		{&ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{"write-dcm-xhome"}}, http.StatusForbidden},
+		{&ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{WRITE_DCM_ALL}}, http.StatusForbidden},
+		{&ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{READ_FIRMWARE_ALL}, ExpiresAt: now - 1}, http.StatusBadRequest},
+		{&ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{READ_FIRMWARE_ALL}, ExpiresAt: now + 31*day}, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		err := validateApiTokenRequest(r, c.request, now)
+		assert.NotNil(t, err)
+		assert.Equal(t, c.status, xwcommon.GetXconfErrorStatusCode(err))
+	}
+
+	// the login permissions are checked with SAT off too
+	common.SatOn = false
+	err := validateApiTokenRequest(r, &ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{WRITE_DCM_ALL}}, now)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+
+	// a service account can not issue more tokens
+	ctx := context.WithValue(r.Context(), xhttp.CTX_KEY_API_TOKEN, &apitoken.ApiToken{ID: "1"})
+	err = validateApiTokenRequest(r.WithContext(ctx), &ApiTokenRequest{ServiceAccount: "ci", Permissions: []string{READ_FIRMWARE_ALL}}, now)
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+}
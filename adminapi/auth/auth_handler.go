@@ -84,8 +84,10 @@ func BasicAuthHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	//Login and password are hardcoded now for testing purposes only.
 	if authRequest.Username == "admin" && authRequest.Password == "admin" {
-		claims := jwt.NewWithClaims(jwt.SigningMethodHS256,
+		now := time.Now()
+		token, err := xhttp.SignLoginToken(
 			jwt.MapClaims{
+				"sub":         authRequest.Username,
 				"username":    authRequest.Username,
 				"lastName":    "",
 				"displayName": authRequest.Username,
@@ -112,13 +114,14 @@ func BasicAuthHandler(w http.ResponseWriter, r *http.Request) {
 							},
 						},
 					},
-					"exp": time.Now().Add(time.Hour * 24).Unix(),
-				}})
-		token, err := claims.SignedString([]byte("xconf"))
-
+				},
+				"iat": now.Unix(),
+				"exp": now.Add(time.Hour * 24).Unix(),
+			})
 		if err != nil {
 			log.Error("Authentication Error : ", err)
 			http.Error(w, "Authentication Error", http.StatusUnauthorized)
+			return
 		}
 		// Add the cookie to the response
 		w.Header()[xhttp.AUTH_TOKEN] = []string{token}
@@ -0,0 +1,238 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	core "github.com/rdkcentral/xconfadmin/shared"
+	xrole "github.com/rdkcentral/xconfadmin/shared/role"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+)
+
+// where a grant comes from
+const (
+	GrantSourceSatOff        = "SAT_OFF"
+	GrantSourceSatCapability = "SAT_CAPABILITY"
+	GrantSourceLoginToken    = "LOGIN_TOKEN"
+	GrantSourceApiToken      = "API_TOKEN"
+	GrantSourceDevProfile    = "DEV_PROFILE"
+	GrantSourceRole          = "ROLE"
+)
+
+const BlockedByLockdown = "LOCKDOWN"
+
+// marks a request built by NewIdentityRequest
+const CTX_KEY_IDENTITY xhttp.AuthCtxKey = "Identity"
+
+type PermissionGrant struct {
+	Permission string `json:"permission,omitempty"`
+	Source     string `json:"source"`
+	Role       string `json:"role,omitempty"`
+}
+
+// ModuleAccess is the access to a module for one application type, the application type is empty
+// for the common and tools modules which are not split by application type
+type ModuleAccess struct {
+	Module          string           `json:"module"`
+	ApplicationType string           `json:"applicationType,omitempty"`
+	CanRead         bool             `json:"canRead"`
+	CanWrite        bool             `json:"canWrite"`
+	ReadGrantedBy   *PermissionGrant `json:"readGrantedBy,omitempty"`
+	WriteGrantedBy  *PermissionGrant `json:"writeGrantedBy,omitempty"`
+	WriteBlockedBy  string           `json:"writeBlockedBy,omitempty"`
+}
+
+// EffectivePermissions are partial when they are resolved for another identity: its login token and SAT
+// grants are not known, only its roles and the dev profile are
+type EffectivePermissions struct {
+	Subject      string             `json:"subject"`
+	Groups       []string           `json:"groups"`
+	Roles        []string           `json:"roles"`
+	SatOn        bool               `json:"satOn"`
+	DevProfile   bool               `json:"devProfile"`
+	ReadonlyMode bool               `json:"readonlyMode"`
+	Partial      bool               `json:"partial"`
+	Grants       []*PermissionGrant `json:"grants"`
+	Modules      []*ModuleAccess    `json:"modules"`
+}
+
+type moduleEntity struct {
+	module     string
+	entityType string
+	path       string
+}
+
+// the modules in the order of the api, rfc entities are checked as dcm entities on the rfc paths
+var moduleEntities = []moduleEntity{
+	{COMMON_MODULE, COMMON_ENTITY, ""},
+	{TOOL_MODULE, TOOL_ENTITY, ""},
+	{CHANGE_MODULE, CHANGE_ENTITY, ""},
+	{DCM_MODULE, DCM_ENTITY, ""},
+	{FIRMWARE_MODULE, FIRMWARE_ENTITY, ""},
+	{RFC_MODULE, DCM_ENTITY, "/rfc"},
+	{TELEMETRY_MODULE, TELEMETRY_ENTITY, ""},
+}
+
+// NewIdentityRequest is a request of another identity, which has no token: only its roles and the dev profile apply
+func NewIdentityRequest(r *http.Request, subject string, groups []string) *http.Request {
+	identity := r.Clone(context.Background())
+	identity.Header = http.Header{}
+	identity.Header.Set(xhttp.AUTH_SUBJECT, subject)
+	loginToken := &xhttp.LoginToken{Subject: subject}
+	for _, group := range groups {
+		loginToken.Application = append(loginToken.Application, xhttp.Application{Role: group})
+	}
+	ctx := context.WithValue(context.Background(), xhttp.CTX_KEY_TOKEN, loginToken)
+	return identity.WithContext(context.WithValue(ctx, CTX_KEY_IDENTITY, true))
+}
+
+func isIdentityRequest(r *http.Request) bool {
+	identity, _ := r.Context().Value(CTX_KEY_IDENTITY).(bool)
+	return identity
+}
+
+// GetEffectivePermissions resolves what the identity of the request may read and write with CanRead and CanWrite,
+// and explains every grant with the permission and where it comes from
+func GetEffectivePermissions(r *http.Request) *EffectivePermissions {
+	groups := getRoleGroups(r)
+	effective := &EffectivePermissions{
+		Subject:      r.Header.Get(xhttp.AUTH_SUBJECT),
+		Groups:       groups,
+		Roles:        []string{},
+		SatOn:        common.SatOn,
+		DevProfile:   IsDevProfile(),
+		ReadonlyMode: isReadonlyMode(),
+		Partial:      isIdentityRequest(r),
+		Grants:       getGrants(r),
+		Modules:      []*ModuleAccess{},
+	}
+	if xhttp.GetApiTokenFromContext(r) == nil {
+		effective.Roles = GetRoleNames(effective.Subject, groups)
+	}
+
+	for _, entity := range moduleEntities {
+		applicationTypes := common.ApplicationTypes
+		if entity.entityType == COMMON_ENTITY || entity.entityType == TOOL_ENTITY {
+			applicationTypes = []string{""}
+		}
+		for _, applicationType := range applicationTypes {
+			effective.Modules = append(effective.Modules, getModuleAccess(r, entity, applicationType, effective.Grants))
+		}
+	}
+	return effective
+}
+
+func getModuleAccess(r *http.Request, entity moduleEntity, applicationType string, grants []*PermissionGrant) *ModuleAccess {
+	moduleRequest := r.Clone(r.Context())
+	moduleRequest.URL = &url.URL{Path: entity.path}
+	if applicationType != "" {
+		moduleRequest.URL.RawQuery = url.Values{core.APPLICATION_TYPE: []string{applicationType}}.Encode()
+	}
+	access := &ModuleAccess{
+		Module:          entity.module,
+		ApplicationType: applicationType,
+	}
+	if _, err := CanRead(moduleRequest, entity.entityType); err == nil {
+		access.CanRead = true
+		access.ReadGrantedBy = findGrant(grants, entity.entityType, applicationType, false)
+	}
+	_, err := CanWrite(moduleRequest, entity.entityType)
+	if err == nil {
+		access.CanWrite = true
+	} else if xwcommon.GetXconfErrorStatusCode(err) == http.StatusLocked {
+		access.WriteBlockedBy = BlockedByLockdown
+	}
+	if access.CanWrite || access.WriteBlockedBy != "" {
+		access.WriteGrantedBy = findGrant(grants, entity.entityType, applicationType, true)
+	}
+	return access
+}
+
+// getGrants lists everything granted to the identity, in the order CanRead and CanWrite check them
+func getGrants(r *http.Request) []*PermissionGrant {
+	grants := []*PermissionGrant{}
+	if !common.SatOn {
+		grants = append(grants, &PermissionGrant{Source: GrantSourceSatOff})
+	}
+	capabilities := xhttp.GetCapabilitiesFromContext(r)
+	for _, capability := range capabilities {
+		grants = append(grants, &PermissionGrant{Permission: capability, Source: GrantSourceSatCapability})
+	}
+	if len(capabilities) == 0 {
+		if IsDevProfile() {
+			for _, permission := range devProfilePermissions {
+				grants = append(grants, &PermissionGrant{Permission: permission, Source: GrantSourceDevProfile})
+			}
+		} else {
+			source := GrantSourceLoginToken
+			if xhttp.GetApiTokenFromContext(r) != nil {
+				source = GrantSourceApiToken
+			}
+			for _, permission := range xhttp.GetPermissionsFromContext(r) {
+				grants = append(grants, &PermissionGrant{Permission: permission, Source: source})
+			}
+		}
+	}
+	if xhttp.GetApiTokenFromContext(r) == nil && !(len(capabilities) == 0 && IsDevProfile()) {
+		for _, roleName := range GetRoleNames(r.Header.Get(xhttp.AUTH_SUBJECT), getRoleGroups(r)) {
+			if role := xrole.GetOneRole(roleName); role != nil {
+				for _, permission := range ExpandRole(role) {
+					grants = append(grants, &PermissionGrant{Permission: permission, Source: GrantSourceRole, Role: roleName})
+				}
+			}
+		}
+	}
+	return grants
+}
+
+// findGrant returns the first grant which gives the access
+func findGrant(grants []*PermissionGrant, entityType string, applicationType string, write bool) *PermissionGrant {
+	capabilities := []string{XCONF_ALL, XCONF_READ}
+	if write {
+		capabilities = []string{XCONF_ALL, XCONF_WRITE}
+	}
+	if entityType == COMMON_ENTITY {
+		if write {
+			capabilities = append(capabilities, XCONF_WRITE_MACLIST)
+		} else {
+			capabilities = append(capabilities, XCONF_READ_MACLIST)
+		}
+	}
+	for _, grant := range grants {
+		switch grant.Source {
+		case GrantSourceSatOff:
+			return grant
+		case GrantSourceSatCapability:
+			if util.Contains(capabilities, grant.Permission) {
+				return grant
+			}
+		default:
+			if grantingPermission([]string{grant.Permission}, entityType, applicationType, write) != "" {
+				return grant
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindGrant(t *testing.T) {
+	withApiTokenSettings(t, nil)
+	grants := []*PermissionGrant{
+		{Permission: XCONF_READ_MACLIST, Source: GrantSourceSatCapability},
+		{Permission: "read-firmware-stb", Source: GrantSourceLoginToken},
+		{Permission: WRITE_FIRMWARE_ALL, Source: GrantSourceRole, Role: "firmware-operator"},
+	}
+	assert.Equal(t, grants[0], findGrant(grants, COMMON_ENTITY, "", false))
+	assert.Nil(t, findGrant(grants, COMMON_ENTITY, "", true))
+	assert.Equal(t, grants[1], findGrant(grants, FIRMWARE_ENTITY, "stb", false))
+	assert.Nil(t, findGrant(grants, FIRMWARE_ENTITY, "xhome", false))
+	assert.Equal(t, grants[2], findGrant(grants, FIRMWARE_ENTITY, "xhome", true))
+	assert.Nil(t, findGrant(grants, DCM_ENTITY, "stb", false))
+
+	satOff := []*PermissionGrant{{Source: GrantSourceSatOff}}
+	assert.Equal(t, satOff[0], findGrant(satOff, TELEMETRY_ENTITY, "stb", true))
+}
+
+func TestGetGrantsOfApiToken(t *testing.T) {
+	withApiTokenSettings(t, nil)
+	activeProfiles := common.ActiveAuthProfiles
+	common.ActiveAuthProfiles = "prod"
+	defer func() { common.ActiveAuthProfiles = activeProfiles }()
+
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/auth/permissions", nil)
+	ctx := context.WithValue(r.Context(), xhttp.CTX_KEY_API_TOKEN, &apitoken.ApiToken{ID: "1", ServiceAccount: "ci"})
+	ctx = context.WithValue(ctx, xhttp.CTX_KEY_PERMISSIONS, []string{"read-dcm-stb"})
+	grants := getGrants(r.WithContext(ctx))
+	assert.Equal(t, []*PermissionGrant{{Permission: "read-dcm-stb", Source: GrantSourceApiToken}}, grants)
+
+	common.SatOn = false
+	grants = getGrants(r.WithContext(ctx))
+	assert.Equal(t, GrantSourceSatOff, grants[0].Source)
+}
+
+func TestNewIdentityRequest(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/auth/permissions?subject=alice", nil)
+	r.Header.Set(xhttp.AUTH_SUBJECT, "admin")
+	identity := NewIdentityRequest(r, "alice", []string{"ops", "dev"})
+	assert.Equal(t, "alice", identity.Header.Get(xhttp.AUTH_SUBJECT))
+	assert.Equal(t, []string{"ops", "dev"}, getRoleGroups(identity))
+	assert.Empty(t, xhttp.GetPermissionsFromContext(identity))
+	assert.True(t, isIdentityRequest(identity))
+	assert.False(t, isIdentityRequest(r))
+}
@@ -80,7 +80,24 @@ func CodeHandler(w http.ResponseWriter, r *http.Request) {
 	}
 	code := codeList[0]
 	log.Debugf("getting login token for code=%s", code)
-	token := Ws.IdpServiceConnector.GetToken(code)
+	var token string
+	if loginFlow, ok := Ws.IdpServiceConnector.(xhttp.IdpLoginFlow); ok {
+		http.SetCookie(w, xhttp.NewErasedIdpLoginCookie())
+		login, err := xhttp.GetIdpLoginFromCookie(r)
+		if err != nil {
+			log.Errorf("%s: %s", xhttp.IdpLoginCookieName, err.Error())
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte("login is not started"))
+			return
+		}
+		token, err = loginFlow.FinishLogin(code, r.URL.Query().Get("state"), login)
+		if err != nil {
+			log.Error(err.Error())
+			xhttp.WriteXconfResponse(w, http.StatusUnauthorized, []byte("error finishing login"))
+			return
+		}
+	} else {
+		token = Ws.IdpServiceConnector.GetToken(code)
+	}
 	if token == "" {
 		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte("Idp service error"))
 		return
@@ -103,7 +120,24 @@ func CodeHandler(w http.ResponseWriter, r *http.Request) {
 func LoginUrlHandler(w http.ResponseWriter, r *http.Request) {
 	idpAuthServer := Ws.XW_XconfServer.ServerConfig.GetString("xconfwebconfig.xconf.idp_service_name")
 	continueUrl := GetAdminUIUrlFromCookies(r) + Ws.XW_XconfServer.ServerConfig.GetString(fmt.Sprintf("xconfwebconfig.%v.idp_code_path", idpAuthServer), "/"+getAuthProvider()+"/code")
-	loginUrl := Ws.IdpServiceConnector.GetFullLoginUrl(continueUrl)
+	var loginUrl string
+	if loginFlow, ok := Ws.IdpServiceConnector.(xhttp.IdpLoginFlow); ok {
+		authorizationUrl, login, err := loginFlow.StartLogin(continueUrl)
+		if err == nil {
+			var cookie *http.Cookie
+			if cookie, err = xhttp.NewIdpLoginCookie(login); err == nil {
+				http.SetCookie(w, cookie)
+				loginUrl = authorizationUrl
+			}
+		}
+		if err != nil {
+			log.Errorf("error starting login: %s", err.Error())
+			xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte("Idp service error"))
+			return
+		}
+	} else {
+		loginUrl = Ws.IdpServiceConnector.GetFullLoginUrl(continueUrl)
+	}
 	responseMap := map[string]string{
 		"url": loginUrl,
 	}
@@ -226,3 +226,59 @@ func TestCodeHandler_Branches(t *testing.T) {
 	// valid-ish token: craft minimal signed JWT using helper? Simplify by bypassing validation: we cannot easily sign acceptable JWT without secret knowledge; skip success branch if library enforces signature.
 	// Instead, simulate success by stubbing ValidateAndGetLoginToken via a simple replacement if available. If not, this branch is omitted.
 }
+
+// fake idp which keeps the login state like oidc
+type fakeLoginFlowIdp struct {
+	fakeIdp
+}
+
+func (f *fakeLoginFlowIdp) StartLogin(continueUrl string) (string, *xhttp.IdpLogin, error) {
+	f.lastLoginUrl = continueUrl
+	return "http://idp/authorize?state=s1", &xhttp.IdpLogin{State: "s1", Nonce: "n1", CodeVerifier: "v1", RedirectUri: continueUrl}, nil
+}
+
+func (f *fakeLoginFlowIdp) FinishLogin(code string, state string, login *xhttp.IdpLogin) (string, error) {
+	if state != login.State {
+		return "", fmt.Errorf("login state does not match")
+	}
+	return f.tokenReturn, nil
+}
+
+func TestLoginFlowHandlers_State(t *testing.T) {
+	setupTestEnv()
+	defer cleanupTestEnv()
+
+	fidp := &fakeLoginFlowIdp{fakeIdp{tokenReturn: "tok"}}
+	ws := makeWs(&fidp.fakeIdp)
+	ws.IdpServiceConnector = fidp
+	WebServerInjection(ws)
+
+	r := httptest.NewRequest("GET", "/loginurl", nil)
+	r.AddCookie(&http.Cookie{Name: adminUrlCookieName, Value: url.QueryEscape("http://admin.local")})
+	rr := runHandler(LoginUrlHandler, r)
+	if rr.Code != http.StatusOK || !strings.Contains(rr.Body.String(), "http://idp/authorize") {
+		t.Fatalf("status %d body=%s", rr.Code, rr.Body.String())
+	}
+	var loginCookie *http.Cookie
+	for _, cookie := range rr.Result().Cookies() {
+		if cookie.Name == xhttp.IdpLoginCookieName {
+			loginCookie = cookie
+		}
+	}
+	if loginCookie == nil || !loginCookie.HttpOnly {
+		t.Fatalf("login cookie not set")
+	}
+
+	// the code of a login which was not started in this browser
+	r = httptest.NewRequest("GET", "/code?code=c1&state=s1", nil)
+	if rr = runHandler(CodeHandler, r); rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 got %d", rr.Code)
+	}
+
+	// the state of another login
+	r = httptest.NewRequest("GET", "/code?code=c1&state=s2", nil)
+	r.AddCookie(loginCookie)
+	if rr = runHandler(CodeHandler, r); rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 got %d", rr.Code)
+	}
+}
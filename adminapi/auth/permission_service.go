@@ -29,6 +29,7 @@ import (
 	owcommon "github.com/rdkcentral/xconfadmin/common"
 	xhttp "github.com/rdkcentral/xconfadmin/http"
 	core "github.com/rdkcentral/xconfadmin/shared"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
 	"github.com/rdkcentral/xconfadmin/util"
 	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
 	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
@@ -50,6 +51,9 @@ const (
 	VIEW_TOOLS  string = "view-tools"
 	WRITE_TOOLS string = "write-tools"
 
+	LOCKDOWN_BREAK_GLASS string = "lockdown-break-glass"
+	MANAGE_ROLES         string = "manage-roles"
+
 	READ_DCM     string = "read-dcm-"
 	READ_DCM_ALL string = "read-dcm-*"
 
@@ -135,6 +139,9 @@ var TelemetryPermissions = EntityPermission{
 	Write:    WRITE_TELEMETRY,
 }
 
+// permissions granted per application type
+var applicationEntityPermissions = []EntityPermission{FirmwarePermissions, ChangePermissions, DcmPermissions, TelemetryPermissions}
+
 func getEntityPermission(entityType string) *EntityPermission {
 	if entityType == COMMON_ENTITY {
 		return &CommonPermissions
@@ -197,6 +204,7 @@ func HasReadPermissionForTool(r *http.Request) bool {
 		if util.Contains(capabilities, XCONF_ALL) || util.Contains(capabilities, XCONF_READ) {
 			return true
 		}
+		return util.Contains(getRolePermissions(r), getEntityPermission(TOOL_ENTITY).ReadAll)
 	} else {
 		// checked permissions from Login token
 		permissions := GetPermissionsFunc(r)
@@ -217,6 +225,7 @@ func HasWritePermissionForTool(r *http.Request) bool {
 		if util.Contains(capabilities, XCONF_ALL) || util.Contains(capabilities, XCONF_WRITE) {
 			return true
 		}
+		return util.Contains(getRolePermissions(r), getEntityPermission(TOOL_ENTITY).WriteAll)
 	} else {
 		// checked permissions from Login token
 		permissions := GetPermissionsFunc(r)
@@ -227,16 +236,39 @@ func HasWritePermissionForTool(r *http.Request) bool {
 	return false
 }
 
+// HasBreakGlassPermission is true when the user may request a lockdown override, an api token never may
+func HasBreakGlassPermission(r *http.Request) bool {
+	if !(owcommon.SatOn) {
+		return true
+	}
+	if xhttp.GetApiTokenFromContext(r) != nil {
+		return false
+	}
+	if capabilities := xhttp.GetCapabilitiesFromContext(r); len(capabilities) > 0 {
+		return util.Contains(getRolePermissions(r), LOCKDOWN_BREAK_GLASS)
+	}
+	return util.Contains(GetPermissionsFunc(r), LOCKDOWN_BREAK_GLASS)
+}
+
+// HasManageRolesPermission is true when the user may change the roles and their bindings, an api token never may
+func HasManageRolesPermission(r *http.Request) bool {
+	if !(owcommon.SatOn) {
+		return true
+	}
+	if xhttp.GetApiTokenFromContext(r) != nil {
+		return false
+	}
+	if capabilities := xhttp.GetCapabilitiesFromContext(r); len(capabilities) > 0 {
+		return util.Contains(capabilities, XCONF_ALL) || util.Contains(getRolePermissions(r), MANAGE_ROLES)
+	}
+	return util.Contains(GetPermissionsFunc(r), MANAGE_ROLES)
+}
+
 // CanWrite returns the applicationType the user has write permission for non-common entityType,
 // otherwise returns error if applicationType is not specified in query parameter or cookie
 func CanWrite(r *http.Request, entityType string, vargs ...string) (applicationType string, err error) {
-	if isLockdownMode() {
-		lockdownModules := strings.Split(common.GetStringAppSetting(common.PROP_LOCKDOWN_MODULES), ",")
-		if len(lockdownModules) != 0 {
-			if util.CaseInsensitiveContains(lockdownModules, getCurrentModule(r, entityType)) || strings.ToUpper(lockdownModules[0]) == common.DefaultLockdownModules {
-				return "", xwcommon.NewRemoteErrorAS(http.StatusLocked, "Modification not allowed in Lockdown mode")
-			}
-		}
+	if module := getCurrentModule(r, entityType); IsModuleLocked(module) && !isLockdownOverridden(r, module) {
+		return "", xwcommon.NewRemoteErrorAS(http.StatusLocked, "Modification not allowed in Lockdown mode")
 	}
 
 	if entityType != COMMON_ENTITY && entityType != TOOL_ENTITY {
@@ -271,16 +303,16 @@ func CanWrite(r *http.Request, entityType string, vargs ...string) (applicationT
 			return applicationType, nil
 		}
 		if !(util.Contains(capabilities, XCONF_ALL) || util.Contains(capabilities, XCONF_WRITE)) {
+			// the roles bound to the subject of the SAT token may still grant the permission
+			if hasWritePermission(getRolePermissions(r), entityType, applicationType) {
+				return applicationType, nil
+			}
 			return "", xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No write capabilities")
 		}
 		return applicationType, nil
 	} else {
-		// checked permissions from Login token
-		permissions := GetPermissionsFunc(r)
-		if util.Contains(permissions, getEntityPermission(entityType).WriteAll) {
-			return applicationType, nil
-		}
-		if util.Contains(common.ApplicationTypes, applicationType) && util.Contains(permissions, getEntityPermission(entityType).Write+applicationType) {
+		// checked permissions from Login token and roles
+		if hasWritePermission(GetPermissionsFunc(r), entityType, applicationType) {
 			return applicationType, nil
 		}
 	}
@@ -326,17 +358,16 @@ func CanRead(r *http.Request, entityType string, vargs ...string) (applicationTy
 			return applicationType, nil
 		}
 		if !(util.Contains(capabilities, XCONF_ALL) || util.Contains(capabilities, XCONF_READ)) {
+			// the roles bound to the subject of the SAT token may still grant the permission
+			if hasReadPermission(getRolePermissions(r), entityType, applicationType) {
+				return applicationType, nil
+			}
 			return "", xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No read capabilities")
 		}
 		return applicationType, nil
 	} else {
-		// checked permissions from Login token
-		permissions := GetPermissionsFunc(r)
-		if util.Contains(permissions, getEntityPermission(entityType).ReadAll) {
-			return applicationType, nil
-		}
-
-		if util.Contains(common.ApplicationTypes, applicationType) && util.Contains(permissions, getEntityPermission(entityType).Read+applicationType) {
+		// checked permissions from Login token and roles
+		if hasReadPermission(GetPermissionsFunc(r), entityType, applicationType) {
 			return applicationType, nil
 		}
 	}
@@ -350,20 +381,47 @@ func CanRead(r *http.Request, entityType string, vargs ...string) (applicationTy
 
 var GetPermissionsFunc = getPermissions
 
+// permissions granted to everyone with the dev profile
+var devProfilePermissions = []string{
+	WRITE_COMMON, READ_COMMON,
+	WRITE_FIRMWARE_ALL, READ_FIRMWARE_ALL,
+	WRITE_DCM_ALL, READ_DCM_ALL,
+	WRITE_TELEMETRY_ALL, READ_TELEMETRY_ALL,
+	READ_CHANGES_ALL, WRITE_CHANGES_ALL,
+	READ_TAGS, WRITE_TAGS, DELETE_TAGS, MANAGE_TAG_NAMESPACES}
+
 func getPermissions(r *http.Request) (permissions []string) {
 	if IsDevProfile() {
-		permissions = []string{
-			WRITE_COMMON, READ_COMMON,
-			WRITE_FIRMWARE_ALL, READ_FIRMWARE_ALL,
-			WRITE_DCM_ALL, READ_DCM_ALL,
-			WRITE_TELEMETRY_ALL, READ_TELEMETRY_ALL,
-			READ_CHANGES_ALL, WRITE_CHANGES_ALL}
+		permissions = util.StringCopySlice(devProfilePermissions)
 	} else {
-		permissions = xhttp.GetPermissionsFromContext(r)
+		permissions = append(xhttp.GetPermissionsFromContext(r), getRolePermissions(r)...)
 	}
 	return permissions
 }
 
+func hasReadPermission(permissions []string, entityType string, applicationType string) bool {
+	return grantingPermission(permissions, entityType, applicationType, false) != ""
+}
+
+func hasWritePermission(permissions []string, entityType string, applicationType string) bool {
+	return grantingPermission(permissions, entityType, applicationType, true) != ""
+}
+
+// grantingPermission returns the permission which grants the access, or an empty string
+func grantingPermission(permissions []string, entityType string, applicationType string, write bool) string {
+	all, perApplication := getEntityPermission(entityType).ReadAll, getEntityPermission(entityType).Read
+	if write {
+		all, perApplication = getEntityPermission(entityType).WriteAll, getEntityPermission(entityType).Write
+	}
+	if util.Contains(permissions, all) {
+		return all
+	}
+	if util.Contains(common.ApplicationTypes, applicationType) && util.Contains(permissions, perApplication+applicationType) {
+		return perApplication + applicationType
+	}
+	return ""
+}
+
 func IsDevProfile() bool {
 	activeProfiles := strings.Split(strings.TrimSpace(owcommon.ActiveAuthProfiles), ",")
 	if len(activeProfiles) > 0 {
@@ -403,48 +461,32 @@ func ValidateWrite(r *http.Request, entityApplicationType string, entityType str
 	return nil
 }
 
-func isLockdownMode() bool {
-	if owcommon.GetBooleanAppSetting(owcommon.PROP_LOCKDOWN_ENABLED, false) {
-		startTime := owcommon.GetStringAppSetting(owcommon.PROP_LOCKDOWN_STARTTIME)
-		endTime := owcommon.GetStringAppSetting(owcommon.PROP_LOCKDOWN_ENDTIME)
-
-		timezone, err := time.LoadLocation(owcommon.DefaultLockdownTimezone)
-		if err != nil {
-			log.Errorf("Error loading timezone: %s", owcommon.DefaultLockdownTimezone)
-			return false
-		}
-
-		t := time.Now().In(timezone).Format(owcommon.DefaultTimeDateFormatLayout)
-		CurrentDate := time.Now().In(timezone).Format(owcommon.DefaultDateFormatLayout)
-
-		Currenttime, err := time.Parse(owcommon.DefaultTimeDateFormatLayout, t)
-
-		if err != nil {
-			log.Errorf("Unable to Parse currenttime: %s", Currenttime)
-			return false
-		}
-		LockdownStartTime, err := time.Parse(owcommon.DefaultTimeDateFormatLayout, CurrentDate+" "+startTime)
-		if err != nil {
-			log.Errorf("Unable to Parse LockdownStartTime: %s", LockdownStartTime)
-			return false
-		}
-		LockdownEndTime, err := time.Parse(owcommon.DefaultTimeDateFormatLayout, CurrentDate+" "+endTime)
-		if err != nil {
-			log.Errorf("Unable to Parse LockdownEndTime: %s", LockdownEndTime)
-			return false
-		}
-
-		if LockdownStartTime.After(LockdownEndTime) || LockdownStartTime.Equal(LockdownEndTime) {
-			LockdownStartTime = LockdownStartTime.AddDate(0, 0, -1)
-		}
+// IsModuleLocked returns true when a lockdown window is active now for the given module
+func IsModuleLocked(module string) bool {
+	return xlockdown.IsModuleLocked(module, time.Now())
+}
 
-		if (Currenttime.Equal(LockdownStartTime) || Currenttime.After(LockdownStartTime)) && Currenttime.Before(LockdownEndTime) {
-			log.Infof("Lockdown Mode is Scheduled Now. Current time=%s, Lockdown StartTime=%s, Lockdown EndTime=%s", t, startTime, endTime)
-			return true
-		}
+// isLockdownOverridden is true when the user holds an active break-glass override of the locked module.
+// The write is recorded with the override once the request has succeeded, so it shows in the audit trail.
+func isLockdownOverridden(r *http.Request, module string) bool {
+	userName := r.Header.Get(xhttp.AUTH_SUBJECT)
+	if userName == "" || !HasBreakGlassPermission(r) {
 		return false
 	}
-	return false
+	now := time.Now()
+	override := xlockdown.GetActiveLockdownOverride(userName, module, now)
+	if override == nil {
+		return false
+	}
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		xhttp.AddOverriddenWrite(r, override.ID, &xlockdown.LockdownOverrideWrite{
+			Timestamp: now.UnixMilli(),
+			Module:    module,
+			Method:    r.Method,
+			Path:      r.URL.Path,
+		})
+	}
+	return true
 }
 
 func GetUserNameOrUnknown(r *http.Request) string {
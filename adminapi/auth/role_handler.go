@@ -0,0 +1,176 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xrole "github.com/rdkcentral/xconfadmin/shared/role"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	SUBJECT = "subject"
+	GROUPS  = "groups"
+)
+
+func GetRolesHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanRead(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeRoleResponse(w, r, xrole.GetRoleList(), http.StatusOK)
+}
+
+func GetRoleHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanRead(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	role, err := GetRole(mux.Vars(r)[common.ID])
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeRoleResponse(w, r, role, http.StatusOK)
+}
+
+func CreateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	saveRoleHandler(w, r, CreateRole, http.StatusCreated)
+}
+
+func UpdateRoleHandler(w http.ResponseWriter, r *http.Request) {
+	saveRoleHandler(w, r, UpdateRole, http.StatusOK)
+}
+
+func saveRoleHandler(w http.ResponseWriter, r *http.Request, save func(r *http.Request, role *xrole.Role) (*xrole.Role, error), status int) {
+	if err := CanManageRoles(r); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	role := xrole.Role{}
+	if !extractRoleBody(w, &role) {
+		return
+	}
+	saved, err := save(r, &role)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeRoleResponse(w, r, saved, status)
+}
+
+func DeleteRoleHandler(w http.ResponseWriter, r *http.Request) {
+	if err := CanManageRoles(r); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	if err := DeleteRole(mux.Vars(r)[common.ID]); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, http.StatusNoContent, nil)
+}
+
+func GetRoleBindingsHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := CanRead(r, TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeRoleResponse(w, r, xrole.GetRoleBindingList(), http.StatusOK)
+}
+
+func SetRoleBindingHandler(w http.ResponseWriter, r *http.Request) {
+	if err := CanManageRoles(r); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	binding := xrole.RoleBinding{}
+	if !extractRoleBody(w, &binding) {
+		return
+	}
+	saved, err := SetRoleBinding(r, &binding)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeRoleResponse(w, r, saved, http.StatusOK)
+}
+
+func DeleteRoleBindingHandler(w http.ResponseWriter, r *http.Request) {
+	if err := CanManageRoles(r); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	if err := DeleteRoleBinding(mux.Vars(r)[common.ID]); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, http.StatusNoContent, nil)
+}
+
+// extractRoleBody writes the error response when the body is not valid
+func extractRoleBody(w http.ResponseWriter, obj interface{}) bool {
+	// r.Body is already drained in the middleware
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, "responsewriter cast error"))
+		return false
+	}
+	if err := json.Unmarshal([]byte(xw.Body()), obj); err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "Unable to extract entity from json file:"+err.Error())
+		return false
+	}
+	return true
+}
+
+func writeRoleResponse(w http.ResponseWriter, r *http.Request, obj interface{}, status int) {
+	res, err := xhttp.ReturnJsonResponse(obj, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, status, xhttp.ContextTypeHeader(r))
+}
+
+// GetEffectivePermissionsHandler explains the permissions of the caller, or of the subject and groups
+// given as parameters, which requires write permission for tools. The permissions of another identity are partial,
+// they leave out its login token and SAT grants.
+func GetEffectivePermissionsHandler(w http.ResponseWriter, r *http.Request) {
+	identity := r
+	if subject := r.URL.Query().Get(SUBJECT); subject != "" {
+		if !HasWritePermissionForTool(r) {
+			xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No permission to read the permissions of another identity"))
+			return
+		}
+		groups := []string{}
+		if value := r.URL.Query().Get(GROUPS); value != "" {
+			groups = strings.Split(value, ",")
+		}
+		identity = NewIdentityRequest(r, subject, groups)
+	}
+	writeRoleResponse(w, r, GetEffectivePermissions(identity), http.StatusOK)
+}
@@ -0,0 +1,279 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xrole "github.com/rdkcentral/xconfadmin/shared/role"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+)
+
+var roleIdPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// isRoleApplicationPermission is true for the permissions a role grants per application type, e.g. read-firmware
+func isRoleApplicationPermission(permission string) bool {
+	for _, entityPermission := range applicationEntityPermissions {
+		if permission+"-" == entityPermission.Read || permission+"-" == entityPermission.Write {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandRole returns the permissions checked by CanRead and CanWrite which are granted by the role
+func ExpandRole(role *xrole.Role) []string {
+	applicationTypes := role.ApplicationTypes
+	if len(applicationTypes) == 0 || util.Contains(applicationTypes, xrole.AllApplicationTypes) {
+		applicationTypes = []string{xrole.AllApplicationTypes}
+	}
+	permissions := []string{}
+	for _, permission := range role.Permissions {
+		if !isRoleApplicationPermission(permission) {
+			permissions = append(permissions, permission)
+			continue
+		}
+		for _, applicationType := range applicationTypes {
+			permissions = append(permissions, permission+"-"+applicationType)
+		}
+	}
+	return permissions
+}
+
+// GetRoleNames returns the roles bound to the user and to any of the groups
+func GetRoleNames(subject string, groups []string) []string {
+	bindingIds := []string{}
+	if subject != "" {
+		bindingIds = append(bindingIds, xrole.RoleBindingId(xrole.UserSubject, subject))
+	}
+	for _, group := range groups {
+		bindingIds = append(bindingIds, xrole.RoleBindingId(xrole.GroupSubject, group))
+	}
+	roleNames := []string{}
+	for _, bindingId := range bindingIds {
+		if binding := xrole.GetOneRoleBinding(bindingId); binding != nil {
+			for _, roleName := range binding.Roles {
+				if !util.Contains(roleNames, roleName) {
+					roleNames = append(roleNames, roleName)
+				}
+			}
+		}
+	}
+	sort.Strings(roleNames)
+	return roleNames
+}
+
+// GetRolePermissions expands the roles bound to the user and to any of the groups
+func GetRolePermissions(subject string, groups []string) []string {
+	permissions := []string{}
+	for _, roleName := range GetRoleNames(subject, groups) {
+		role := xrole.GetOneRole(roleName)
+		if role == nil {
+			continue
+		}
+		for _, permission := range ExpandRole(role) {
+			if !util.Contains(permissions, permission) {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+// getRoleGroups returns the groups of the login token, the roles it has been issued with
+func getRoleGroups(r *http.Request) []string {
+	groups := []string{}
+	if loginToken := xhttp.GetLoginTokenFromContext(r); loginToken != nil {
+		for _, application := range loginToken.Application {
+			if application.Role != "" && !util.Contains(groups, application.Role) {
+				groups = append(groups, application.Role)
+			}
+		}
+	}
+	return groups
+}
+
+func getRolePermissions(r *http.Request) []string {
+	// an api token is limited to the permissions it has been issued with
+	if xhttp.GetApiTokenFromContext(r) != nil {
+		return []string{}
+	}
+	return GetRolePermissions(r.Header.Get(xhttp.AUTH_SUBJECT), getRoleGroups(r))
+}
+
+func validateRole(role *xrole.Role) error {
+	if !roleIdPattern.MatchString(role.ID) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Role id is required and may only contain letters, digits, '_', '.' and '-'")
+	}
+	if len(role.Permissions) == 0 {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Role permissions are required")
+	}
+	for _, permission := range role.Permissions {
+		// break-glass and the role management are granted by roles only, never to an api token
+		if !isRoleApplicationPermission(permission) && !isKnownPermission(permission) && permission != LOCKDOWN_BREAK_GLASS && permission != MANAGE_ROLES {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("Permission %s is not known", permission))
+		}
+	}
+	for _, applicationType := range role.ApplicationTypes {
+		if applicationType != xrole.AllApplicationTypes && !util.Contains(common.ApplicationTypes, applicationType) {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("ApplicationType %s is not valid", applicationType))
+		}
+	}
+	return nil
+}
+
+// CanManageRoles returns an error when the user may not change the roles and their bindings
+func CanManageRoles(r *http.Request) error {
+	if !HasManageRolesPermission(r) {
+		return xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No permission to manage roles")
+	}
+	return nil
+}
+
+// validateRoleGrant refuses a role granting a permission the user does not hold, as for the api tokens
+func validateRoleGrant(r *http.Request, role *xrole.Role) error {
+	for _, permission := range ExpandRole(role) {
+		if !canGrantPermission(r, permission) {
+			return xwcommon.NewRemoteErrorAS(http.StatusForbidden, fmt.Sprintf("Permission %s of role %s can not be granted without holding it", permission, role.ID))
+		}
+	}
+	return nil
+}
+
+// isCallerSubject is true when the binding would grant the roles to the user, directly or with one of the groups
+func isCallerSubject(r *http.Request, binding *xrole.RoleBinding) bool {
+	if binding.Kind == xrole.UserSubject {
+		return binding.Subject == r.Header.Get(xhttp.AUTH_SUBJECT)
+	}
+	return util.Contains(getRoleGroups(r), binding.Subject)
+}
+
+func GetRole(id string) (*xrole.Role, error) {
+	role := xrole.GetOneRole(id)
+	if role == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Role %s is not found", id))
+	}
+	return role, nil
+}
+
+func CreateRole(r *http.Request, role *xrole.Role) (*xrole.Role, error) {
+	if err := validateRole(role); err != nil {
+		return nil, err
+	}
+	if err := validateRoleGrant(r, role); err != nil {
+		return nil, err
+	}
+	if xrole.GetOneRole(role.ID) != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Role %s already exists", role.ID))
+	}
+	return saveRole(r, role)
+}
+
+func UpdateRole(r *http.Request, role *xrole.Role) (*xrole.Role, error) {
+	if err := validateRole(role); err != nil {
+		return nil, err
+	}
+	if err := validateRoleGrant(r, role); err != nil {
+		return nil, err
+	}
+	if xrole.GetOneRole(role.ID) == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Role %s is not found", role.ID))
+	}
+	return saveRole(r, role)
+}
+
+func saveRole(r *http.Request, role *xrole.Role) (*xrole.Role, error) {
+	role.UpdatedBy = GetUserNameOrUnknown(r)
+	role.Updated = xwutil.GetTimestamp(time.Now().UTC())
+	if err := xrole.SetOneRole(role); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return role, nil
+}
+
+// DeleteRole refuses to delete a role which is still bound
+func DeleteRole(id string) error {
+	if xrole.GetOneRole(id) == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Role %s is not found", id))
+	}
+	boundTo := []string{}
+	for _, binding := range xrole.GetRoleBindingList() {
+		if util.Contains(binding.Roles, id) {
+			boundTo = append(boundTo, binding.ID)
+		}
+	}
+	if len(boundTo) > 0 {
+		return xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Role %s is bound to %s", id, strings.Join(boundTo, ", ")))
+	}
+	if err := xrole.DeleteOneRole(id); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// SetRoleBinding replaces the roles of the user or group, the user can not bind roles to themselves
+func SetRoleBinding(r *http.Request, binding *xrole.RoleBinding) (*xrole.RoleBinding, error) {
+	if binding.Kind != xrole.UserSubject && binding.Kind != xrole.GroupSubject {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("Kind must be %s or %s", xrole.UserSubject, xrole.GroupSubject))
+	}
+	if util.IsBlank(binding.Subject) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Subject is required")
+	}
+	if len(binding.Roles) == 0 {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Roles are required, delete the binding to remove all roles")
+	}
+	if isCallerSubject(r, binding) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusForbidden, "Roles can not be bound to yourself or to your groups")
+	}
+	for _, roleName := range binding.Roles {
+		role := xrole.GetOneRole(roleName)
+		if role == nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("Role %s is not found", roleName))
+		}
+		if err := validateRoleGrant(r, role); err != nil {
+			return nil, err
+		}
+	}
+	binding.ID = xrole.RoleBindingId(binding.Kind, binding.Subject)
+	binding.UpdatedBy = GetUserNameOrUnknown(r)
+	binding.Updated = xwutil.GetTimestamp(time.Now().UTC())
+	if err := xrole.SetOneRoleBinding(binding); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return binding, nil
+}
+
+func DeleteRoleBinding(id string) error {
+	if xrole.GetOneRoleBinding(id) == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Role binding %s is not found", id))
+	}
+	if err := xrole.DeleteOneRoleBinding(id); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
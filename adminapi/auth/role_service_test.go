@@ -0,0 +1,140 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+	xrole "github.com/rdkcentral/xconfadmin/shared/role"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandRole(t *testing.T) {
+	role := &xrole.Role{
+		ID:               "firmware-operator",
+		Permissions:      []string{"read-firmware", "write-firmware", READ_COMMON, "read-dcm-stb"},
+		ApplicationTypes: []string{"stb", "xhome"},
+	}
+	assert.Equal(t, []string{"read-firmware-stb", "read-firmware-xhome", "write-firmware-stb", "write-firmware-xhome", READ_COMMON, "read-dcm-stb"}, ExpandRole(role))
+
+	role.ApplicationTypes = nil
+	assert.Equal(t, []string{READ_FIRMWARE_ALL, WRITE_FIRMWARE_ALL, READ_COMMON, "read-dcm-stb"}, ExpandRole(role))
+
+	role.ApplicationTypes = []string{"stb", xrole.AllApplicationTypes}
+	assert.Equal(t, []string{READ_FIRMWARE_ALL, WRITE_FIRMWARE_ALL, READ_COMMON, "read-dcm-stb"}, ExpandRole(role))
+}
+
+func TestValidateRole(t *testing.T) {
+	withApiTokenSettings(t, nil)
+	valid := &xrole.Role{ID: "telemetry-approver", Permissions: []string{"read-telemetry", "write-changes", VIEW_TOOLS}, ApplicationTypes: []string{"xhome"}}
+	assert.Nil(t, validateRole(valid))
+
+	invalid := []*xrole.Role{
+		{ID: "", Permissions: []string{"read-firmware"}},
+		{ID: "has space", Permissions: []string{"read-firmware"}},
+		{ID: "r1"},
+		{ID: "r1", Permissions: []string{"read-everything"}},
+		{ID: "r1", Permissions: []string{"read-firmware"}, ApplicationTypes: []string{"unknown"}},
+	}
+	for _, role := range invalid {
+		err := validateRole(role)
+		assert.NotNil(t, err, role.ID)
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+	}
+}
+
+func TestHasPermissionWithExpandedRole(t *testing.T) {
+	applicationTypes := common.ApplicationTypes
+	common.ApplicationTypes = []string{"stb", "xhome"}
+	defer func() { common.ApplicationTypes = applicationTypes }()
+
+	permissions := ExpandRole(&xrole.Role{ID: "dcm-operator", Permissions: []string{"read-dcm", "write-dcm"}, ApplicationTypes: []string{"xhome"}})
+	assert.True(t, hasReadPermission(permissions, DCM_ENTITY, "xhome"))
+	assert.True(t, hasWritePermission(permissions, DCM_ENTITY, "xhome"))
+	assert.False(t, hasWritePermission(permissions, DCM_ENTITY, "stb"))
+	assert.False(t, hasReadPermission(permissions, FIRMWARE_ENTITY, "xhome"))
+}
+
+func TestApiTokenIsNotExpandedWithRoles(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/roles", nil)
+	r.Header.Set(xhttp.AUTH_SUBJECT, "ci")
+	ctx := context.WithValue(r.Context(), xhttp.CTX_KEY_API_TOKEN, &apitoken.ApiToken{ID: "1", ServiceAccount: "ci"})
+	assert.Empty(t, getRolePermissions(r.WithContext(ctx)))
+}
+
+func TestHasBreakGlassPermission(t *testing.T) {
+	withApiTokenSettings(t, []string{WRITE_TOOLS, LOCKDOWN_BREAK_GLASS})
+	assert.Nil(t, validateRole(&xrole.Role{ID: "incident-responder", Permissions: []string{LOCKDOWN_BREAK_GLASS}}))
+
+	r := httptest.NewRequest(http.MethodPost, "/xconfAdminService/lockdownOverrides", nil)
+	r.Header.Set(xhttp.AUTH_SUBJECT, "alice")
+	assert.True(t, HasBreakGlassPermission(r))
+
+	// an api token never breaks the glass, even with the permission
+	ctx := context.WithValue(r.Context(), xhttp.CTX_KEY_API_TOKEN, &apitoken.ApiToken{ID: "1", ServiceAccount: "ci"})
+	assert.False(t, HasBreakGlassPermission(r.WithContext(ctx)))
+
+	withApiTokenSettings(t, []string{WRITE_TOOLS})
+	assert.False(t, HasBreakGlassPermission(r))
+}
+
+func TestHasTagPermission(t *testing.T) {
+	withApiTokenSettings(t, []string{WRITE_TAGS})
+	r := httptest.NewRequest(http.MethodGet, "/taggingService/tags", nil)
+	// write-tags also reads the tags, deleting a tag needs its own permission
+	assert.True(t, hasTagPermission(r, nil, READ_TAGS))
+	assert.True(t, hasTagPermission(r, nil, WRITE_TAGS))
+	assert.False(t, hasTagPermission(r, nil, DELETE_TAGS))
+	assert.Nil(t, CanReadTags(r))
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(CanDeleteTag(r, "tag1")))
+
+	assert.True(t, hasTagPermission(r, []string{XCONF_WRITE}, DELETE_TAGS))
+	assert.True(t, isKnownPermission(DELETE_TAGS))
+}
+
+func TestRoleGrantIsLimitedToTheCaller(t *testing.T) {
+	withApiTokenSettings(t, []string{READ_FIRMWARE_ALL, "write-dcm-stb"})
+	r := httptest.NewRequest(http.MethodPost, "/xconfAdminService/roles", nil)
+	r.Header.Set(xhttp.AUTH_SUBJECT, "alice")
+
+	assert.Nil(t, validateRoleGrant(r, &xrole.Role{ID: "r1", Permissions: []string{"read-firmware", "write-dcm"}, ApplicationTypes: []string{"stb"}}))
+	for _, role := range []*xrole.Role{
+		{ID: "r1", Permissions: []string{"write-dcm"}},
+		{ID: "r1", Permissions: []string{"write-dcm"}, ApplicationTypes: []string{"xhome"}},
+		{ID: "r1", Permissions: []string{MANAGE_ROLES}},
+	} {
+		err := validateRoleGrant(r, role)
+		assert.NotNil(t, err)
+		assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+	}
+
+	_, err := SetRoleBinding(r, &xrole.RoleBinding{Kind: xrole.UserSubject, Subject: "alice", Roles: []string{"r1"}})
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(CanManageRoles(r)))
+	withApiTokenSettings(t, []string{MANAGE_ROLES})
+	assert.Nil(t, CanManageRoles(r))
+}
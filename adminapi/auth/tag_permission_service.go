@@ -0,0 +1,134 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package auth
+
+import (
+	"fmt"
+	"net/http"
+
+	owcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+)
+
+const (
+	READ_TAGS   string = "read-tags"
+	WRITE_TAGS  string = "write-tags"
+	DELETE_TAGS string = "delete-tags"
+	// MANAGE_TAG_NAMESPACES lets a tag admin hand the namespaces to the teams
+	MANAGE_TAG_NAMESPACES string = "manage-tag-namespaces"
+)
+
+// CanReadTags is checked when the tags of all namespaces are listed
+func CanReadTags(r *http.Request) error {
+	return canAccessTag(r, "", READ_TAGS)
+}
+
+func CanReadTag(r *http.Request, tagId string) error {
+	return canAccessTag(r, tagId, READ_TAGS)
+}
+
+// CanWriteTag is checked for every change of the members of the tag
+func CanWriteTag(r *http.Request, tagId string) error {
+	return canAccessTag(r, tagId, WRITE_TAGS)
+}
+
+func CanDeleteTag(r *http.Request, tagId string) error {
+	return canAccessTag(r, tagId, DELETE_TAGS)
+}
+
+// CanManageTagNamespaces is checked when a namespace is created, changed or deleted
+func CanManageTagNamespaces(r *http.Request) error {
+	if !(owcommon.SatOn) {
+		return nil
+	}
+	capabilities := xhttp.GetCapabilitiesFromContext(r)
+	if util.Contains(capabilities, XCONF_ALL) {
+		return nil
+	}
+	permissions := GetPermissionsFunc(r)
+	if len(capabilities) > 0 {
+		permissions = getRolePermissions(r)
+	}
+	if !util.Contains(permissions, MANAGE_TAG_NAMESPACES) {
+		return xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No permission: "+MANAGE_TAG_NAMESPACES)
+	}
+	return nil
+}
+
+// canAccessTag checks the tag permission, and for a change the ownership of the namespace of the tag
+func canAccessTag(r *http.Request, tagId string, permission string) error {
+	if !(owcommon.SatOn) {
+		return nil
+	}
+	capabilities := xhttp.GetCapabilitiesFromContext(r)
+	if util.Contains(capabilities, XCONF_ALL) {
+		return nil
+	}
+	if !hasTagPermission(r, capabilities, permission) {
+		return xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No permission: "+permission)
+	}
+	if permission == READ_TAGS || tagId == "" {
+		return nil
+	}
+	return checkTagNamespaceOwner(r, tagId)
+}
+
+// CanReadTagJob is checked for the jobs writing a tag, unlike the tag itself the jobs writing a tag of
+// a namespace are only shown to the owners of the namespace
+func CanReadTagJob(r *http.Request, tagId string) error {
+	if err := CanReadTag(r, tagId); err != nil {
+		return err
+	}
+	if !(owcommon.SatOn) || tagId == "" || util.Contains(xhttp.GetCapabilitiesFromContext(r), XCONF_ALL) {
+		return nil
+	}
+	return checkTagNamespaceOwner(r, tagId)
+}
+
+func checkTagNamespaceOwner(r *http.Request, tagId string) error {
+	if namespace := xtagging.GetTagNamespaceOf(tagId); namespace != nil {
+		if !namespace.IsOwner(r.Header.Get(xhttp.AUTH_SUBJECT), getRoleGroups(r)) {
+			return xwcommon.NewRemoteErrorAS(http.StatusForbidden, fmt.Sprintf("Tag %s belongs to the namespace %s of another team", tagId, namespace.Prefix))
+		}
+	}
+	return nil
+}
+
+// hasTagPermission accepts the write capability of a SAT token for changes, and write-tags for reads
+func hasTagPermission(r *http.Request, capabilities []string, permission string) bool {
+	var permissions []string
+	if len(capabilities) > 0 {
+		if permission == READ_TAGS && util.Contains(capabilities, XCONF_READ) {
+			return true
+		}
+		if permission != READ_TAGS && util.Contains(capabilities, XCONF_WRITE) {
+			return true
+		}
+		permissions = getRolePermissions(r)
+	} else {
+		permissions = GetPermissionsFunc(r)
+	}
+	if util.Contains(permissions, permission) {
+		return true
+	}
+	return permission == READ_TAGS && util.Contains(permissions, WRITE_TAGS)
+}
@@ -0,0 +1,96 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+
+	"github.com/gorilla/mux"
+)
+
+// writeAwaitingApprovalsResponse answers 202 with the collected approvals when the change still needs more approvers
+func writeAwaitingApprovalsResponse(w http.ResponseWriter, r *http.Request, err error) bool {
+	var awaitingErr *AwaitingApprovalsError
+	if !errors.As(err, &awaitingErr) {
+		return false
+	}
+	res, err := xhttp.ReturnJsonResponse(awaitingErr.Approval, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return true
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusAccepted, xhttp.ContextTypeHeader(r))
+	return true
+}
+
+// writeChangeConflictResponse answers 409 with the conflicting fields when the change can not be applied on top of the current entity
+func writeChangeConflictResponse(w http.ResponseWriter, r *http.Request, err error) bool {
+	var conflictErr *ChangeConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+	res, err := xhttp.ReturnJsonResponse(conflictErr, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return true
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusConflict, xhttp.ContextTypeHeader(r))
+	return true
+}
+
+func GetChangeApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanRead(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+
+	approval, err := GetChangeApproval(changeId)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	if !xshared.ApplicationTypeEquals(applicationType, approval.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Approvals of change "+changeId+" do not exist"))
+		return
+	}
+
+	res, err := xhttp.ReturnJsonResponse(approval, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
@@ -0,0 +1,140 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwchange "github.com/rdkcentral/xconfwebconfig/shared/change"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AwaitingApprovalsError is returned when an approval has been recorded
+// but the change still needs more distinct approvers before it is applied
+type AwaitingApprovalsError struct {
+	Approval *xchange.ChangeApproval
+}
+
+func (e *AwaitingApprovalsError) Error() string {
+	return fmt.Sprintf("Change %s is awaiting more approvals: %d of %d", e.Approval.ID, len(e.Approval.Approvals), e.Approval.Required)
+}
+
+// GetRequiredApprovers returns the number of distinct approvers needed for a change,
+// the strictest override wins when both the application type and the entity type are configured
+func GetRequiredApprovers(applicationType string, entityType string) int {
+	required := xcommon.ChangeApprovalRequiredApprovers
+	overridden := false
+	for _, key := range []string{applicationType, entityType} {
+		if count, ok := xcommon.ChangeApprovalRequiredApproversByType[key]; ok && key != "" {
+			if !overridden || count > required {
+				required = count
+			}
+			overridden = true
+		}
+	}
+	if required < 1 {
+		required = 1
+	}
+	return required
+}
+
+func newChangeApprovalForChange(change *xwchange.Change) *xchange.ChangeApproval {
+	approval := xchange.NewChangeApprovalInf().(*xchange.ChangeApproval)
+	approval.ID = change.ID
+	approval.EntityID = change.EntityID
+	approval.EntityType = string(change.EntityType)
+	approval.ApplicationType = change.ApplicationType
+	approval.Author = change.Author
+	return approval
+}
+
+func newChangeApprovalForTelemetryTwoChange(change *xwchange.TelemetryTwoChange) *xchange.ChangeApproval {
+	approval := xchange.NewChangeApprovalInf().(*xchange.ChangeApproval)
+	approval.ID = change.ID
+	approval.EntityID = change.EntityID
+	approval.EntityType = string(change.EntityType)
+	approval.ApplicationType = change.ApplicationType
+	approval.Author = change.Author
+	return approval
+}
+
+// addApproval applies the approval policy for the given approver on top of the approvals collected so far
+func addApproval(existing *xchange.ChangeApproval, candidate *xchange.ChangeApproval, approver string) (*xchange.ChangeApproval, error) {
+	if xcommon.ChangeApprovalBlockSelfApproval && strings.EqualFold(approver, candidate.Author) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusForbidden, fmt.Sprintf("Change %s cannot be approved by its author %s", candidate.ID, approver))
+	}
+	approval := candidate
+	if existing != nil {
+		approval = existing
+	}
+	approval.Required = GetRequiredApprovers(candidate.ApplicationType, candidate.EntityType)
+	if approval.HasApproved(approver) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Change %s has already been approved by %s", approval.ID, approver))
+	}
+	approval.Approvals = append(approval.Approvals, xchange.Approval{
+		User:      approver,
+		Timestamp: xwutil.GetTimestamp(time.Now().UTC()),
+	})
+	return approval, nil
+}
+
+// registerApproval records the approval of the current user. When the change still needs
+// more approvers the approval is saved and an AwaitingApprovalsError is returned,
+// otherwise the caller applies the change and then saves the approval with saveChangeApproval
+func registerApproval(r *http.Request, candidate *xchange.ChangeApproval) (*xchange.ChangeApproval, error) {
+	approver := auth.GetUserNameOrUnknown(r)
+	approval, err := addApproval(xchange.GetOneChangeApproval(candidate.ID), candidate, approver)
+	if err != nil {
+		return nil, err
+	}
+	if !approval.IsSatisfied() {
+		if err := xchange.SetOneChangeApproval(approval); err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		log.Infof("Change %s approved by %s, awaiting more approvals: %d of %d", approval.ID, approver, len(approval.Approvals), approval.Required)
+		return nil, &AwaitingApprovalsError{Approval: approval}
+	}
+	return approval, nil
+}
+
+func saveChangeApproval(approval *xchange.ChangeApproval) {
+	if err := xchange.SetOneChangeApproval(approval); err != nil {
+		log.Errorf("Failed to save approvals of change %s: %v", approval.ID, err)
+	}
+}
+
+func GetChangeApproval(changeId string) (*xchange.ChangeApproval, error) {
+	if changeId == "" {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Id is blank")
+	}
+	approval := xchange.GetOneChangeApproval(changeId)
+	if approval == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Approvals of change "+changeId+" do not exist")
+	}
+	return approval, nil
+}
@@ -0,0 +1,103 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func setApprovalPolicy(t *testing.T, blockSelfApproval bool, required int, byType map[string]int) {
+	oldBlock := xcommon.ChangeApprovalBlockSelfApproval
+	oldRequired := xcommon.ChangeApprovalRequiredApprovers
+	oldByType := xcommon.ChangeApprovalRequiredApproversByType
+	xcommon.ChangeApprovalBlockSelfApproval = blockSelfApproval
+	xcommon.ChangeApprovalRequiredApprovers = required
+	xcommon.ChangeApprovalRequiredApproversByType = byType
+	t.Cleanup(func() {
+		xcommon.ChangeApprovalBlockSelfApproval = oldBlock
+		xcommon.ChangeApprovalRequiredApprovers = oldRequired
+		xcommon.ChangeApprovalRequiredApproversByType = oldByType
+	})
+}
+
+func newTestChangeApproval() *xchange.ChangeApproval {
+	approval := xchange.NewChangeApprovalInf().(*xchange.ChangeApproval)
+	approval.ID = "change-1"
+	approval.EntityType = xchange.TelemetryTwoProfile
+	approval.ApplicationType = "stb"
+	approval.Author = "author"
+	return approval
+}
+
+func TestGetRequiredApprovers(t *testing.T) {
+	setApprovalPolicy(t, false, 0, nil)
+	assert.Equal(t, 1, GetRequiredApprovers("stb", xchange.TelemetryTwoProfile))
+
+	setApprovalPolicy(t, false, 2, map[string]int{"stb": 1, xchange.TelemetryTwoProfile: 3})
+	assert.Equal(t, 3, GetRequiredApprovers("stb", xchange.TelemetryTwoProfile))
+	assert.Equal(t, 1, GetRequiredApprovers("stb", "TELEMETRY_PROFILE"))
+	assert.Equal(t, 2, GetRequiredApprovers("rdkcloud", "TELEMETRY_PROFILE"))
+}
+
+func TestAddApprovalBlocksSelfApproval(t *testing.T) {
+	setApprovalPolicy(t, true, 1, nil)
+	_, err := addApproval(nil, newTestChangeApproval(), "Author")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+
+	setApprovalPolicy(t, false, 1, nil)
+	approval, err := addApproval(nil, newTestChangeApproval(), "author")
+	assert.Nil(t, err)
+	assert.True(t, approval.IsSatisfied())
+}
+
+func TestAddApprovalRequiresDistinctApprovers(t *testing.T) {
+	setApprovalPolicy(t, true, 2, nil)
+	approval, err := addApproval(nil, newTestChangeApproval(), "approver1")
+	assert.Nil(t, err)
+	assert.False(t, approval.IsSatisfied())
+	assert.Equal(t, 2, approval.Required)
+
+	_, err = addApproval(approval, newTestChangeApproval(), "APPROVER1")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusConflict, xwcommon.GetXconfErrorStatusCode(err))
+
+	approval, err = addApproval(approval, newTestChangeApproval(), "approver2")
+	assert.Nil(t, err)
+	assert.True(t, approval.IsSatisfied())
+	assert.Equal(t, []string{"approver1", "approver2"}, approval.ApprovedUsers())
+}
+
+func TestAwaitingApprovalsError(t *testing.T) {
+	approval := newTestChangeApproval()
+	approval.Required = 2
+	approval.Approvals = append(approval.Approvals, xchange.Approval{User: "approver1"})
+	var err error = &AwaitingApprovalsError{Approval: approval}
+
+	var awaitingErr *AwaitingApprovalsError
+	assert.True(t, errors.As(err, &awaitingErr))
+	assert.Equal(t, "Change change-1 is awaiting more approvals: 1 of 2", err.Error())
+}
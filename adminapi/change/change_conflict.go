@@ -0,0 +1,351 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwchange "github.com/rdkcentral/xconfwebconfig/shared/change"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// FieldConflict is a field modified both by the pending change and by someone else since the change was created
+type FieldConflict struct {
+	Field    string      `json:"field"`
+	Base     interface{} `json:"base"`
+	Current  interface{} `json:"current"`
+	Proposed interface{} `json:"proposed"`
+}
+
+// ChangeConflictError is returned when a pending change can not be applied on top of the current entity,
+// the reviewer has to resolve the listed fields, e.g. by requesting changes from the author
+type ChangeConflictError struct {
+	ChangeID       string          `json:"changeId"`
+	EntityID       string          `json:"entityId"`
+	BaseVersion    string          `json:"baseVersion"`
+	CurrentVersion string          `json:"currentVersion"`
+	Status         int             `json:"status"`
+	Message        string          `json:"message"`
+	Conflicts      []FieldConflict `json:"conflicts"`
+}
+
+func (e *ChangeConflictError) Error() string {
+	return e.Message
+}
+
+// StatusCode answers the conflict with 409 and the conflicting fields, see xhttp.AdminError
+func (e *ChangeConflictError) StatusCode() int {
+	return e.Status
+}
+
+// missingValue marks a field which is absent on one side of the merge
+type missingValue struct{}
+
+// entityBytes marshals an entity, nil entities give nil
+func entityBytes(entity interface{}) json.RawMessage {
+	if isNilEntity(entity) {
+		return nil
+	}
+	if raw, ok := entity.(json.RawMessage); ok {
+		if len(raw) == 0 || string(raw) == "null" {
+			return nil
+		}
+		return raw
+	}
+	bytes, err := json.Marshal(entity)
+	if err != nil {
+		return nil
+	}
+	return bytes
+}
+
+// resolveChange checks a pending change against the current entity. base is the entity the change was
+// created from and proposed the entity the change writes. An update which does not overlap with the
+// modifications made since base is merged into the current entity and the merged entity is returned.
+func resolveChange(changeId string, entityId string, operation xwchange.ChangeOperation, base json.RawMessage, current json.RawMessage, proposed json.RawMessage) (json.RawMessage, error) {
+	conflictErr := &ChangeConflictError{
+		ChangeID:       changeId,
+		EntityID:       entityId,
+		BaseVersion:    xchange.EntityVersion(base),
+		CurrentVersion: xchange.EntityVersion(current),
+		Status:         http.StatusConflict,
+		Conflicts:      []FieldConflict{},
+	}
+	switch operation {
+	case xchange.Create:
+		if current != nil {
+			conflictErr.Message = fmt.Sprintf("Change %s conflicts with the current entity: %s has been created since the change was made", changeId, entityId)
+			return nil, conflictErr
+		}
+		return proposed, nil
+	case xchange.Delete:
+		if current == nil {
+			conflictErr.Message = fmt.Sprintf("Change %s conflicts with the current entity: %s does not exist anymore", changeId, entityId)
+			return nil, conflictErr
+		}
+		if conflictErr.BaseVersion != conflictErr.CurrentVersion {
+			// deleting would silently drop what has been modified since the change was made
+			conflictErr.Conflicts = DiffEntities(base, current)
+			conflictErr.Message = fmt.Sprintf("Change %s conflicts with the current entity: %s has been modified since the change was made", changeId, entityId)
+			return nil, conflictErr
+		}
+		return nil, nil
+	}
+
+	if current == nil {
+		conflictErr.Message = fmt.Sprintf("Change %s conflicts with the current entity: %s does not exist anymore", changeId, entityId)
+		return nil, conflictErr
+	}
+	if conflictErr.BaseVersion == conflictErr.CurrentVersion {
+		return proposed, nil
+	}
+	merged, conflicts, err := mergeEntities(base, current, proposed)
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	if len(conflicts) > 0 {
+		conflictErr.Conflicts = conflicts
+		conflictErr.Message = fmt.Sprintf("Change %s conflicts with the current entity: %d field(s) of %s have been modified since the change was made", changeId, len(conflicts), entityId)
+		return nil, conflictErr
+	}
+	return merged, nil
+}
+
+// mergeEntities is a three-way merge of the modifications base -> proposed into current
+func mergeEntities(base json.RawMessage, current json.RawMessage, proposed json.RawMessage) (json.RawMessage, []FieldConflict, error) {
+	var baseValue, currentValue, proposedValue interface{}
+	for _, item := range []struct {
+		bytes json.RawMessage
+		value *interface{}
+	}{{base, &baseValue}, {current, &currentValue}, {proposed, &proposedValue}} {
+		if err := json.Unmarshal(item.bytes, item.value); err != nil {
+			return nil, nil, err
+		}
+	}
+	if currentFields, ok := currentValue.(map[string]interface{}); ok {
+		for _, value := range []interface{}{baseValue, proposedValue} {
+			if fields, ok := value.(map[string]interface{}); ok {
+				if updated, ok := currentFields[xchange.UpdatedField]; ok {
+					fields[xchange.UpdatedField] = updated
+				} else {
+					delete(fields, xchange.UpdatedField)
+				}
+			}
+		}
+	}
+
+	conflicts := []FieldConflict{}
+	merged := mergeValue("", baseValue, currentValue, proposedValue, &conflicts)
+	if len(conflicts) > 0 {
+		return nil, conflicts, nil
+	}
+	mergedBytes, err := json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mergedBytes, nil, nil
+}
+
+func mergeValue(path string, base interface{}, current interface{}, proposed interface{}, conflicts *[]FieldConflict) interface{} {
+	switch {
+	case reflect.DeepEqual(proposed, base):
+		return current
+	case reflect.DeepEqual(current, base), reflect.DeepEqual(current, proposed):
+		return proposed
+	}
+
+	baseFields, baseOk := base.(map[string]interface{})
+	currentFields, currentOk := current.(map[string]interface{})
+	proposedFields, proposedOk := proposed.(map[string]interface{})
+	if baseOk && currentOk && proposedOk {
+		return mergeFields(path, baseFields, currentFields, proposedFields, conflicts)
+	}
+
+	baseList, baseOk := elementsById(base)
+	currentList, currentOk := elementsById(current)
+	proposedList, proposedOk := elementsById(proposed)
+	if baseOk && currentOk && proposedOk {
+		return mergeElements(path, baseList, currentList, proposedList, conflicts)
+	}
+
+	*conflicts = append(*conflicts, FieldConflict{
+		Field:    path,
+		Base:     presentValue(base),
+		Current:  presentValue(current),
+		Proposed: presentValue(proposed),
+	})
+	return current
+}
+
+func mergeFields(path string, base map[string]interface{}, current map[string]interface{}, proposed map[string]interface{}, conflicts *[]FieldConflict) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for _, key := range unionKeys(base, current, proposed) {
+		value := mergeValue(joinPath(path, key), fieldValue(base, key), fieldValue(current, key), fieldValue(proposed, key), conflicts)
+		if _, missing := value.(missingValue); !missing {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// idList is a list of objects identified by their id, the elements are merged one by one
+type idList struct {
+	ids      []string
+	elements map[string]interface{}
+}
+
+func elementsById(value interface{}) (*idList, bool) {
+	if _, missing := value.(missingValue); missing || value == nil {
+		return &idList{elements: map[string]interface{}{}}, true
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	list := &idList{elements: make(map[string]interface{})}
+	for _, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		id, ok := fields["id"].(string)
+		if !ok || id == "" {
+			return nil, false
+		}
+		if _, duplicated := list.elements[id]; duplicated {
+			return nil, false
+		}
+		list.ids = append(list.ids, id)
+		list.elements[id] = item
+	}
+	return list, true
+}
+
+func mergeElements(path string, base *idList, current *idList, proposed *idList, conflicts *[]FieldConflict) []interface{} {
+	// current order first, elements added by the change are appended in their proposed order
+	ids := append([]string{}, current.ids...)
+	for _, id := range append(append([]string{}, base.ids...), proposed.ids...) {
+		if _, ok := current.elements[id]; !ok && !containsString(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	merged := []interface{}{}
+	for _, id := range ids {
+		value := mergeValue(fmt.Sprintf("%s[id=%s]", path, id), listValue(base, id), listValue(current, id), listValue(proposed, id), conflicts)
+		if _, missing := value.(missingValue); !missing {
+			merged = append(merged, value)
+		}
+	}
+	return merged
+}
+
+// DiffEntities lists the fields which differ between two versions of an entity, a nil version differs in the whole entity
+func DiffEntities(base json.RawMessage, current json.RawMessage) []FieldConflict {
+	var baseValue, currentValue interface{}
+	for _, item := range []struct {
+		bytes json.RawMessage
+		value *interface{}
+	}{{base, &baseValue}, {current, &currentValue}} {
+		if len(item.bytes) == 0 {
+			continue
+		}
+		if err := json.Unmarshal(item.bytes, item.value); err != nil {
+			log.Errorf("Unable to diff the entity %s: %v", string(item.bytes), err)
+		}
+	}
+	diffs := []FieldConflict{}
+	diffValue("", baseValue, currentValue, &diffs)
+	return diffs
+}
+
+func diffValue(path string, base interface{}, current interface{}, diffs *[]FieldConflict) {
+	if reflect.DeepEqual(base, current) || path == xchange.UpdatedField {
+		return
+	}
+	baseFields, baseOk := base.(map[string]interface{})
+	currentFields, currentOk := current.(map[string]interface{})
+	if baseOk && currentOk {
+		for _, key := range unionKeys(baseFields, currentFields) {
+			diffValue(joinPath(path, key), fieldValue(baseFields, key), fieldValue(currentFields, key), diffs)
+		}
+		return
+	}
+	*diffs = append(*diffs, FieldConflict{
+		Field:   path,
+		Base:    presentValue(base),
+		Current: presentValue(current),
+	})
+}
+
+func unionKeys(fields ...map[string]interface{}) []string {
+	keys := []string{}
+	for _, m := range fields {
+		for key := range m {
+			if !containsString(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func fieldValue(fields map[string]interface{}, key string) interface{} {
+	if value, ok := fields[key]; ok {
+		return value
+	}
+	return missingValue{}
+}
+
+func listValue(list *idList, id string) interface{} {
+	if value, ok := list.elements[id]; ok {
+		return value
+	}
+	return missingValue{}
+}
+
+func presentValue(value interface{}) interface{} {
+	if _, missing := value.(missingValue); missing {
+		return nil
+	}
+	return value
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,97 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEntityVersionIgnoresKeyOrderAndUpdated(t *testing.T) {
+	v1 := xchange.EntityVersion(json.RawMessage(`{"id":"p1","name":"a","updated":1}`))
+	v2 := xchange.EntityVersion(json.RawMessage(`{"name":"a","updated":2,"id":"p1"}`))
+	assert.NotEmpty(t, v1)
+	assert.Equal(t, v1, v2)
+	assert.NotEqual(t, v1, xchange.EntityVersion(json.RawMessage(`{"id":"p1","name":"b"}`)))
+	assert.Empty(t, xchange.EntityVersion(nil))
+}
+
+func TestResolveChangeMergesNonOverlappingFields(t *testing.T) {
+	base := json.RawMessage(`{"id":"p1","name":"a","schedule":"1 * * * *","updated":1}`)
+	current := json.RawMessage(`{"id":"p1","name":"a","schedule":"2 * * * *","updated":2}`)
+	proposed := json.RawMessage(`{"id":"p1","name":"b","schedule":"1 * * * *","updated":1}`)
+
+	merged, err := resolveChange("c1", "p1", xchange.Update, base, current, proposed)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id":"p1","name":"b","schedule":"2 * * * *","updated":2}`, string(merged))
+}
+
+func TestResolveChangeReturnsFieldConflicts(t *testing.T) {
+	base := json.RawMessage(`{"id":"p1","name":"a","schedule":"1 * * * *"}`)
+	current := json.RawMessage(`{"id":"p1","name":"c","schedule":"1 * * * *"}`)
+	proposed := json.RawMessage(`{"id":"p1","name":"b","schedule":"1 * * * *"}`)
+
+	_, err := resolveChange("c1", "p1", xchange.Update, base, current, proposed)
+	var conflictErr *ChangeConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+	assert.Equal(t, http.StatusConflict, xhttp.GetErrorStatusCode(err))
+	assert.Equal(t, []FieldConflict{{Field: "name", Base: "a", Current: "c", Proposed: "b"}}, conflictErr.Conflicts)
+	assert.NotEqual(t, conflictErr.BaseVersion, conflictErr.CurrentVersion)
+}
+
+func TestResolveChangeMergesListsById(t *testing.T) {
+	base := json.RawMessage(`{"id":"p1","entries":[{"id":"e1","value":"1"},{"id":"e2","value":"2"}]}`)
+	current := json.RawMessage(`{"id":"p1","entries":[{"id":"e1","value":"10"},{"id":"e2","value":"2"},{"id":"e3","value":"3"}]}`)
+	proposed := json.RawMessage(`{"id":"p1","entries":[{"id":"e1","value":"1"},{"id":"e4","value":"4"}]}`)
+
+	merged, err := resolveChange("c1", "p1", xchange.Update, base, current, proposed)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id":"p1","entries":[{"id":"e1","value":"10"},{"id":"e3","value":"3"},{"id":"e4","value":"4"}]}`, string(merged))
+
+	// removing an element modified by someone else is a conflict
+	proposed = json.RawMessage(`{"id":"p1","entries":[{"id":"e2","value":"2"}]}`)
+	_, err = resolveChange("c1", "p1", xchange.Update, base, current, proposed)
+	var conflictErr *ChangeConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+	assert.Equal(t, "entries[id=e1]", conflictErr.Conflicts[0].Field)
+}
+
+func TestResolveCreateAndDeleteChange(t *testing.T) {
+	entity := json.RawMessage(`{"id":"p1","name":"a"}`)
+
+	_, err := resolveChange("c1", "p1", xchange.Create, nil, entity, entity)
+	assert.Equal(t, http.StatusConflict, xhttp.GetErrorStatusCode(err))
+
+	_, err = resolveChange("c1", "p1", xchange.Delete, entity, json.RawMessage(`{"id":"p1","name":"a","updated":5}`), nil)
+	assert.Nil(t, err)
+
+	_, err = resolveChange("c1", "p1", xchange.Delete, entity, json.RawMessage(`{"id":"p1","name":"b"}`), nil)
+	var conflictErr *ChangeConflictError
+	assert.True(t, errors.As(err, &conflictErr))
+	assert.Equal(t, "name", conflictErr.Conflicts[0].Field)
+
+	_, err = resolveChange("c1", "p1", xchange.Delete, entity, nil, nil)
+	assert.Equal(t, http.StatusConflict, xhttp.GetErrorStatusCode(err))
+}
@@ -82,8 +82,31 @@ func ApproveChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, err = Approve(r, changeId)
+	if applyAt := r.URL.Query().Get(xcommon.APPLY_AT); applyAt != "" {
+		kind := xchange.TelemetryChangeKind
+		if IsEntityChange(changeId) {
+			kind = xchange.EntityChangeKind
+		}
+		scheduleChange(w, r, kind, changeId, applyAt)
+		return
+	}
+
+	if IsEntityChange(changeId) {
+		_, err = ApproveEntityChange(r, changeId)
+	} else {
+		_, err = Approve(r, changeId)
+	}
 	if err != nil {
+		if writeAwaitingApprovalsResponse(w, r, err) {
+			return
+		}
+		if writeChangeConflictResponse(w, r, err) {
+			return
+		}
+		if status := xwcommon.GetXconfErrorStatusCode(err); status == http.StatusForbidden || status == http.StatusConflict {
+			xhttp.AdminError(w, err)
+			return
+		}
 		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
@@ -117,11 +140,17 @@ func RevertChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = Revert(r, approveId)
+	target := findReviewTarget(approveId)
+	if IsApprovedEntityChange(approveId) {
+		err = RevertEntityChange(r, approveId)
+	} else {
+		err = Revert(r, approveId)
+	}
 	if err != nil {
 		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	recordChangeReviewOf(r, approveId, target, xchange.Reverted)
 	headerMap := createHeadersMap(applicationType)
 	xwhttp.WriteXconfResponseWithHeaders(w, headerMap, http.StatusOK, nil)
 }
@@ -139,11 +168,17 @@ func CancelChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = CancelChange(r, changeId)
+	target := findReviewTarget(changeId)
+	if IsEntityChange(changeId) {
+		err = CancelEntityChange(r, changeId)
+	} else {
+		err = CancelChange(r, changeId)
+	}
 	if err != nil {
 		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, err.Error())
 		return
 	}
+	recordChangeReviewOf(r, changeId, target, xchange.Canceled)
 	headerMap := createHeadersMap(applicationType)
 	xwhttp.WriteXconfResponseWithHeaders(w, headerMap, http.StatusOK, nil)
 }
@@ -327,11 +362,15 @@ func ApproveChangesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	changeIds, entityChangeIds := splitEntityChangeIds(changeIds, IsEntityChange)
 	errorMessages, err := ApproveChanges(r, &changeIds)
 	if err != nil {
 		xhttp.AdminError(w, err)
 		return
 	}
+	for id, errMsg := range ApproveEntityChanges(r, entityChangeIds) {
+		errorMessages[id] = errMsg
+	}
 	response, err := util.JSONMarshal(errorMessages)
 	if err != nil {
 		log.Error(fmt.Sprintf("json.Marshal ApprovedChangesMap error: %v", err))
@@ -358,11 +397,17 @@ func RevertChangesHandler(w http.ResponseWriter, r *http.Request) {
 		xwhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(response))
 		return
 	}
+	targets := findReviewTargets(changeIds)
+	changeIds, approvedEntityChangeIds := splitEntityChangeIds(changeIds, IsApprovedEntityChange)
 	errorMessages, err := RevertChanges(r, &changeIds)
 	if err != nil {
 		xhttp.AdminError(w, err)
 		return
 	}
+	for id, errMsg := range RevertEntityChanges(r, approvedEntityChangeIds) {
+		errorMessages[id] = errMsg
+	}
+	recordChangeReviewsOf(r, targets, errorMessages, xchange.Reverted)
 	response, err := util.JSONMarshal(errorMessages)
 	if err != nil {
 		log.Error(fmt.Sprintf("json.Marshal ApprovedChangesMap error: %v", err))
@@ -0,0 +1,187 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+type changeReviewRequest struct {
+	Reason string `json:"reason"`
+}
+
+func GetChangeCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanRead(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+
+	comments, err := GetChangeComments(applicationType, changeId)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(comments, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func PostChangeCommentHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanWrite(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+
+	// r.Body is already drained in the middleware
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, "responsewriter cast error"))
+		return
+	}
+	comment := xchange.ChangeComment{}
+	if err := json.Unmarshal([]byte(xw.Body()), &comment); err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "Unable to extract comment from json file:"+err.Error())
+		return
+	}
+
+	created, err := AddChangeComment(r, applicationType, changeId, &comment)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(created, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusCreated, xhttp.ContextTypeHeader(r))
+}
+
+func RejectChangeHandler(w http.ResponseWriter, r *http.Request) {
+	reviewChange(w, r, RejectChange)
+}
+
+func RequestChangesHandler(w http.ResponseWriter, r *http.Request) {
+	reviewChange(w, r, RequestChanges)
+}
+
+func reviewChange(w http.ResponseWriter, r *http.Request, review func(r *http.Request, applicationType string, changeId string, reason string) (*xchange.ChangeReview, error)) {
+	applicationType, err := auth.CanWrite(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+
+	// r.Body is already drained in the middleware
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, "responsewriter cast error"))
+		return
+	}
+	request := changeReviewRequest{}
+	if body := xw.Body(); body != "" {
+		if err := json.Unmarshal([]byte(body), &request); err != nil {
+			xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "Unable to extract reason from json file:"+err.Error())
+			return
+		}
+	}
+
+	changeReview, err := review(r, applicationType, changeId, request.Reason)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(changeReview, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func GetChangeReviewsHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanRead(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+
+	res, err := xhttp.ReturnJsonResponse(GetChangeReviews(applicationType, changeId), r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+// GetRejectedChangesHandler lists the rejected changes, other review actions can be requested with the action parameter
+func GetRejectedChangesHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanRead(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	action := r.URL.Query().Get("action")
+	if action == "" {
+		action = xchange.Rejected
+	}
+
+	res, err := xhttp.ReturnJsonResponse(GetChangeReviewsByAction(applicationType, action), r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
@@ -0,0 +1,236 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// reviewTarget describes the pending or approved change a comment or a review refers to
+type reviewTarget struct {
+	Kind            string
+	EntityID        string
+	EntityType      string
+	ApplicationType string
+	Author          string
+	Pending         bool
+	Change          interface{}
+}
+
+// findReviewTarget looks the change up among pending and approved changes of every kind
+func findReviewTarget(changeId string) *reviewTarget {
+	if change := xchange.GetOneChange(changeId); change != nil {
+		return &reviewTarget{xchange.TelemetryChangeKind, change.EntityID, string(change.EntityType), change.ApplicationType, change.Author, true, change}
+	}
+	if change := xchange.GetOneTelemetryTwoChange(changeId); change != nil {
+		return &reviewTarget{xchange.TelemetryTwoChangeKind, change.EntityID, string(change.EntityType), change.ApplicationType, change.Author, true, change}
+	}
+	if change := xchange.GetOneEntityChange(changeId); change != nil {
+		return &reviewTarget{xchange.EntityChangeKind, change.EntityID, change.EntityType, change.ApplicationType, change.Author, true, change}
+	}
+	if change := xchange.GetOneApprovedChange(changeId); change != nil {
+		return &reviewTarget{xchange.TelemetryChangeKind, change.EntityID, string(change.EntityType), change.ApplicationType, change.Author, false, change}
+	}
+	if change := xchange.GetOneApprovedTelemetryTwoChange(changeId); change != nil {
+		return &reviewTarget{xchange.TelemetryTwoChangeKind, change.EntityID, string(change.EntityType), change.ApplicationType, change.Author, false, change}
+	}
+	if change := xchange.GetOneApprovedEntityChange(changeId); change != nil {
+		return &reviewTarget{xchange.EntityChangeKind, change.EntityID, change.EntityType, change.ApplicationType, change.Author, false, change}
+	}
+	return nil
+}
+
+func getReviewTarget(applicationType string, changeId string) (*reviewTarget, error) {
+	target := findReviewTarget(changeId)
+	if target == nil || (!xshared.ApplicationTypeEquals(applicationType, target.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL)) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Change with "+changeId+" id does not exist")
+	}
+	return target, nil
+}
+
+func GetChangeComments(applicationType string, changeId string) ([]*xchange.ChangeComment, error) {
+	if _, err := getReviewTarget(applicationType, changeId); err != nil {
+		// comments of rejected changes stay readable
+		if len(GetChangeReviews(applicationType, changeId)) == 0 {
+			return nil, err
+		}
+	}
+	return xchange.GetChangeCommentsByChangeId(changeId), nil
+}
+
+func AddChangeComment(r *http.Request, applicationType string, changeId string, comment *xchange.ChangeComment) (*xchange.ChangeComment, error) {
+	if _, err := getReviewTarget(applicationType, changeId); err != nil {
+		return nil, err
+	}
+	comment.Text = strings.TrimSpace(comment.Text)
+	if comment.Text == "" {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Comment text is blank")
+	}
+	if comment.ParentID != "" {
+		parent := xchange.GetOneChangeComment(comment.ParentID)
+		if parent == nil || parent.ChangeID != changeId {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Comment "+comment.ParentID+" does not belong to change "+changeId)
+		}
+	}
+	comment.ID = uuid.New().String()
+	comment.ChangeID = changeId
+	comment.Author = auth.GetUserNameOrUnknown(r)
+	if err := xchange.CreateOneChangeComment(comment); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return comment, nil
+}
+
+// RejectChange removes the pending change and keeps the reason together with a snapshot of the change
+func RejectChange(r *http.Request, applicationType string, changeId string, reason string) (*xchange.ChangeReview, error) {
+	target, err := getPendingReviewTarget(applicationType, changeId, reason)
+	if err != nil {
+		return nil, err
+	}
+	switch target.Kind {
+	case xchange.TelemetryChangeKind:
+		err = xchange.DeleteOneChange(changeId)
+	case xchange.TelemetryTwoChangeKind:
+		err = xchange.DeleteOneTelemetryTwoChange(changeId)
+	case xchange.EntityChangeKind:
+		err = xchange.DeleteOneEntityChange(changeId)
+	}
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	xchange.DeleteOneChangeApproval(changeId)
+	xchange.DeleteOneScheduledChange(changeId)
+	return recordChangeReview(r, changeId, target, xchange.Rejected, reason), nil
+}
+
+// RequestChanges keeps the change pending but drops the approvals and the schedule collected so far
+func RequestChanges(r *http.Request, applicationType string, changeId string, reason string) (*xchange.ChangeReview, error) {
+	target, err := getPendingReviewTarget(applicationType, changeId, reason)
+	if err != nil {
+		return nil, err
+	}
+	xchange.DeleteOneChangeApproval(changeId)
+	xchange.DeleteOneScheduledChange(changeId)
+	return recordChangeReview(r, changeId, target, xchange.ChangesRequested, reason), nil
+}
+
+func getPendingReviewTarget(applicationType string, changeId string, reason string) (*reviewTarget, error) {
+	if strings.TrimSpace(reason) == "" {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Reason is required")
+	}
+	target, err := getReviewTarget(applicationType, changeId)
+	if err != nil {
+		return nil, err
+	}
+	if !target.Pending {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, "Change "+changeId+" is already approved")
+	}
+	return target, nil
+}
+
+// recordChangeReview saves the review, a failure is logged and does not undo the action it describes
+func recordChangeReview(r *http.Request, changeId string, target *reviewTarget, action string, reason string) *xchange.ChangeReview {
+	review := &xchange.ChangeReview{
+		ID:              uuid.New().String(),
+		ChangeID:        changeId,
+		Kind:            target.Kind,
+		EntityID:        target.EntityID,
+		EntityType:      target.EntityType,
+		ApplicationType: target.ApplicationType,
+		ChangeAuthor:    target.Author,
+		Reviewer:        auth.GetUserNameOrUnknown(r),
+		Action:          action,
+		Reason:          strings.TrimSpace(reason),
+	}
+	if changeBytes, err := json.Marshal(target.Change); err == nil {
+		review.Change = changeBytes
+	}
+	if err := xchange.CreateOneChangeReview(review); err != nil {
+		log.Errorf("Failed to save %s review of change %s: %v", action, changeId, err)
+	}
+	log.Infof("Change %s %s by %s: %s", changeId, action, review.Reviewer, review.Reason)
+	return review
+}
+
+// recordChangeReviewOf records a revert or a cancel, the target has to be looked up before the change is removed
+func recordChangeReviewOf(r *http.Request, changeId string, target *reviewTarget, action string) {
+	if target != nil {
+		recordChangeReview(r, changeId, target, action, r.URL.Query().Get(xcommon.REASON))
+	}
+}
+
+func findReviewTargets(changeIds []string) map[string]*reviewTarget {
+	targets := make(map[string]*reviewTarget)
+	for _, changeId := range changeIds {
+		if target := findReviewTarget(changeId); target != nil {
+			targets[changeId] = target
+		}
+	}
+	return targets
+}
+
+// recordChangeReviewsOf records a batch action for every change which did not fail
+func recordChangeReviewsOf(r *http.Request, targets map[string]*reviewTarget, errorMessages map[string]string, action string) {
+	for changeId, target := range targets {
+		if _, failed := errorMessages[changeId]; !failed {
+			recordChangeReviewOf(r, changeId, target, action)
+		}
+	}
+}
+
+func GetChangeReviews(applicationType string, changeId string) []*xchange.ChangeReview {
+	return filterChangeReviews(applicationType, func(review *xchange.ChangeReview) bool {
+		return review.ChangeID == changeId
+	})
+}
+
+func GetChangeReviewsByAction(applicationType string, action string) []*xchange.ChangeReview {
+	return filterChangeReviews(applicationType, func(review *xchange.ChangeReview) bool {
+		return action == "" || strings.EqualFold(review.Action, action)
+	})
+}
+
+func filterChangeReviews(applicationType string, include func(review *xchange.ChangeReview) bool) []*xchange.ChangeReview {
+	result := []*xchange.ChangeReview{}
+	for _, review := range xchange.GetChangeReviewList() {
+		if !xshared.ApplicationTypeEquals(applicationType, review.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+			continue
+		}
+		if include(review) {
+			result = append(result, review)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[j].Created < result[i].Created
+	})
+	return result
+}
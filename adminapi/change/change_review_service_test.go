@@ -0,0 +1,39 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectAndRequestChangesRequireReason(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/xconfAdminService/change/reject/change-1", nil)
+
+	_, err := RejectChange(r, "stb", "change-1", "  ")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+
+	_, err = RequestChanges(r, "stb", "change-1", "")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
@@ -18,6 +18,7 @@
 package change
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -242,6 +243,8 @@ func CancelChange(r *http.Request, changeId string) error {
 	if err != nil {
 		return err
 	}
+	xchange.DeleteOneChangeApproval(changeId)
+	xchange.DeleteOneScheduledChange(changeId)
 	userName := auth.GetUserNameOrUnknown(r)
 	log.Info("Change has been canceled by {}: {}", userName, canceledChange)
 	return nil
@@ -325,8 +328,23 @@ func Approve(r *http.Request, id string) (*xwchange.ApprovedChange, error) {
 		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Change with "+id+" id does not exist")
 	}
 
-	var err error
+	if err := checkNotScheduled(id); err != nil {
+		return nil, err
+	}
+	approval, err := registerApproval(r, newChangeApprovalForChange(change))
+	if err != nil {
+		return nil, err
+	}
+	return applyChange(r, change, approval)
+}
+
+// applyChange applies a change whose approvals are satisfied
+func applyChange(r *http.Request, change *xwchange.Change, approval *xchange.ChangeApproval) (*xwchange.ApprovedChange, error) {
 	var approvedChange *xwchange.ApprovedChange
+	err := resolveTelemetryProfileChange(change)
+	if err != nil {
+		return nil, err
+	}
 	switch {
 	case xwchange.Create == change.Operation:
 		_, err = CreatePermanentTelemetryProfile(r, change.NewEntity)
@@ -342,15 +360,43 @@ func Approve(r *http.Request, id string) (*xwchange.ApprovedChange, error) {
 		if err != nil {
 			return nil, err
 		}
+		saveChangeApproval(approval)
+		cancelChangesOfDeletedProfile(r, change)
 	}
+	return approvedChange, nil
+}
 
-	changesByProfileId := GetChangesByEntityId(change.EntityID)
-	err = CancelApprovedChangesByEntityId(r, getChangeIds(changesByProfileId), []string{})
-	if err != nil {
-		return nil, err
+// cancelChangesOfDeletedProfile cancels the pending changes of a profile the change has deleted, they can not be applied anymore
+func cancelChangesOfDeletedProfile(r *http.Request, change *xwchange.Change) {
+	if change.Operation != xwchange.Delete {
+		return
 	}
+	if err := CancelApprovedChangesByEntityId(r, []string{change.EntityID}, []string{}); err != nil {
+		log.Errorf("Failed to cancel the changes of the deleted profile %s: %v", change.EntityID, err)
+	}
+}
 
-	return approvedChange, nil
+// resolveTelemetryProfileChange checks the change against the current profile, other pending changes of
+// the profile stay pending and are checked the same way when they are approved. An update which does
+// not overlap with the modifications made since the change was created is merged into the current profile.
+func resolveTelemetryProfileChange(change *xwchange.Change) error {
+	current := logupload.GetOnePermanentTelemetryProfile(change.EntityID)
+	proposed := entityBytes(change.NewEntity)
+	merged, err := resolveChange(change.ID, change.EntityID, change.Operation, entityBytes(change.OldEntity), entityBytes(current), proposed)
+	if err != nil {
+		return err
+	}
+	if xwchange.Update != change.Operation || bytes.Equal(merged, proposed) {
+		return nil
+	}
+	mergedProfile := &logupload.PermanentTelemetryProfile{}
+	if err := json.Unmarshal(merged, mergedProfile); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	// the approved change reverts to the profile it has actually been applied to
+	change.OldEntity = current
+	change.NewEntity = mergedProfile
+	return nil
 }
 
 func getChangeIds(changes []*xwchange.Change) []string {
@@ -367,17 +413,26 @@ func ApproveChanges(r *http.Request, changeIds *[]string) (map[string]string, er
 		return nil, err
 	}
 	errorMessages := make(map[string]string)
-	mergedUpdateChangesByEntityId := make(map[string]*logupload.PermanentTelemetryProfile)
-	entityToByCancelChange := []string{}
 	for _, change := range changesToApprove {
-		var err error
+		if err := checkNotScheduled(change.ID); err != nil {
+			logAndCollectChangeException(change, err, errorMessages)
+			continue
+		}
+		approval, err := registerApproval(r, newChangeApprovalForChange(change))
+		if err != nil {
+			logAndCollectChangeException(change, err, errorMessages)
+			continue
+		}
+		// changes of the same profile approved in one batch are merged one after the other
+		if err = resolveTelemetryProfileChange(change); err != nil {
+			logAndCollectChangeException(change, err, errorMessages)
+			continue
+		}
 		switch {
 		case xwchange.Create == change.Operation:
 			_, err = CreatePermanentTelemetryProfile(r, change.NewEntity)
 		case xwchange.Update == change.Operation:
-			mergeResult := ApplyUpdateChange(mergedUpdateChangesByEntityId[change.EntityID], change)
-			mergedUpdateChangesByEntityId[mergeResult.ID] = mergeResult
-			_, err = UpdatePermanentTelemetryProfile(mergeResult)
+			_, err = UpdatePermanentTelemetryProfile(change.NewEntity)
 		case xwchange.Delete == change.Operation:
 			_, err = DeletePermanentTelemetryProfile(r, change.OldEntity.ID)
 		}
@@ -388,15 +443,11 @@ func ApproveChanges(r *http.Request, changeIds *[]string) (map[string]string, er
 			if err != nil {
 				logAndCollectChangeException(change, err, errorMessages)
 			} else {
-				entityToByCancelChange = append(entityToByCancelChange, change.EntityID)
+				saveChangeApproval(approval)
+				cancelChangesOfDeletedProfile(r, change)
 			}
 		}
 	}
-	keys := make([]string, 0, len(errorMessages))
-	for k := range errorMessages {
-		keys = append(keys, k)
-	}
-	CancelApprovedChangesByEntityId(r, entityToByCancelChange, keys)
 	return errorMessages, nil
 }
 
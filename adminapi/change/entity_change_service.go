@@ -0,0 +1,465 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+	xwchange "github.com/rdkcentral/xconfwebconfig/shared/change"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+)
+
+// EntityChangeWorkflow routes writes of an entity type through pending changes when the
+// entity type is listed in change_workflow_entity_types. Approved changes are applied by
+// the regular create/update/delete handlers, so they go through the same validation.
+type EntityChangeWorkflow struct {
+	entityType    string
+	authEntity    string
+	getEntity     func(id string) interface{}
+	createHandler http.HandlerFunc
+	updateHandler http.HandlerFunc
+	deleteHandler http.HandlerFunc
+}
+
+var (
+	entityChangeWorkflows      = make(map[string]*EntityChangeWorkflow)
+	entityChangeWorkflowsMutex sync.RWMutex
+)
+
+// NewEntityChangeWorkflow creates and registers the workflow of an entity type,
+// authEntity is the permission entity used by the wrapped handlers
+func NewEntityChangeWorkflow(entityType string, authEntity string, getEntity func(id string) interface{}, createHandler http.HandlerFunc, updateHandler http.HandlerFunc, deleteHandler http.HandlerFunc) *EntityChangeWorkflow {
+	workflow := &EntityChangeWorkflow{
+		entityType:    entityType,
+		authEntity:    authEntity,
+		getEntity:     getEntity,
+		createHandler: createHandler,
+		updateHandler: updateHandler,
+		deleteHandler: deleteHandler,
+	}
+	entityChangeWorkflowsMutex.Lock()
+	entityChangeWorkflows[entityType] = workflow
+	entityChangeWorkflowsMutex.Unlock()
+	return workflow
+}
+
+func getEntityChangeWorkflow(entityType string) *EntityChangeWorkflow {
+	entityChangeWorkflowsMutex.RLock()
+	defer entityChangeWorkflowsMutex.RUnlock()
+	return entityChangeWorkflows[entityType]
+}
+
+func IsEntityChangeWorkflowEnabled(entityType string) bool {
+	return xcommon.ChangeWorkflowEntityTypes.Contains(entityType)
+}
+
+func (wf *EntityChangeWorkflow) CreateHandler(w http.ResponseWriter, r *http.Request) {
+	wf.handle(w, r, xchange.Create, wf.createHandler)
+}
+
+func (wf *EntityChangeWorkflow) UpdateHandler(w http.ResponseWriter, r *http.Request) {
+	wf.handle(w, r, xchange.Update, wf.updateHandler)
+}
+
+func (wf *EntityChangeWorkflow) DeleteHandler(w http.ResponseWriter, r *http.Request) {
+	wf.handle(w, r, xchange.Delete, wf.deleteHandler)
+}
+
+// GuardHandler rejects the bulk and alternate writes of the entity type while its changes require approval,
+// they cannot be turned into a single pending change
+func (wf *EntityChangeWorkflow) GuardHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if IsEntityChangeWorkflowEnabled(wf.entityType) {
+			xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("%s changes require approval, submit them one entity at a time", wf.entityType)))
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (wf *EntityChangeWorkflow) handle(w http.ResponseWriter, r *http.Request, operation xwchange.ChangeOperation, next http.HandlerFunc) {
+	if !IsEntityChangeWorkflowEnabled(wf.entityType) {
+		next(w, r)
+		return
+	}
+
+	change, err := wf.buildEntityChange(w, r, operation)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	if err := CreateEntityChange(change); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+
+	res, err := xhttp.ReturnJsonResponse(change, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusAccepted, xhttp.ContextTypeHeader(r))
+}
+
+func (wf *EntityChangeWorkflow) buildEntityChange(w http.ResponseWriter, r *http.Request, operation xwchange.ChangeOperation) (*xchange.EntityChange, error) {
+	change := &xchange.EntityChange{
+		ID:         uuid.New().String(),
+		EntityType: wf.entityType,
+		Operation:  operation,
+		Author:     auth.GetUserNameOrUnknown(r),
+	}
+
+	var entityApplicationType string
+	if operation == xchange.Delete {
+		change.EntityID = mux.Vars(r)[xcommon.ID]
+	} else {
+		// r.Body is already drained in the middleware
+		xw, ok := w.(*xwhttp.XResponseWriter)
+		if !ok {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, "responsewriter cast error")
+		}
+		entity := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(xw.Body()), &entity); err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, err.Error())
+		}
+		change.EntityID, _ = entity[xcommon.ID].(string)
+		if operation == xchange.Create && change.EntityID == "" {
+			change.EntityID = uuid.New().String()
+			entity[xcommon.ID] = change.EntityID
+		}
+		entityApplicationType, _ = entity[xshared.APPLICATION_TYPE].(string)
+		newEntity, err := json.Marshal(entity)
+		if err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, err.Error())
+		}
+		change.NewEntity = newEntity
+	}
+	if change.EntityID == "" {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Entity id is empty")
+	}
+
+	applicationType, err := auth.CanWrite(r, wf.authEntity, entityApplicationType)
+	if err != nil {
+		return nil, err
+	}
+	change.ApplicationType = applicationType
+
+	oldEntity := wf.getEntity(change.EntityID)
+	if isNilEntity(oldEntity) {
+		if operation != xchange.Create {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Entity with id: %s does not exist", change.EntityID))
+		}
+	} else {
+		if operation == xchange.Create {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Entity with id: %s already exists", change.EntityID))
+		}
+		oldEntityBytes, err := json.Marshal(oldEntity)
+		if err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		change.OldEntity = oldEntityBytes
+		change.BaseVersion = xchange.EntityVersion(oldEntityBytes)
+	}
+	return change, nil
+}
+
+func isNilEntity(entity interface{}) bool {
+	if entity == nil {
+		return true
+	}
+	value := reflect.ValueOf(entity)
+	return value.Kind() == reflect.Ptr && value.IsNil()
+}
+
+func CreateEntityChange(change *xchange.EntityChange) error {
+	for _, existingChange := range GetEntityChangesByEntityId(change.EntityID) {
+		if existingChange.Operation == change.Operation && bytes.Equal(existingChange.NewEntity, change.NewEntity) {
+			return xwcommon.NewRemoteErrorAS(http.StatusConflict, "The same change already exists")
+		}
+	}
+	if err := xchange.CreateOneEntityChange(change); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	log.Infof("EntityChange created by %s: %s %s %s", change.Author, change.Operation, change.EntityType, change.EntityID)
+	return nil
+}
+
+func IsEntityChange(changeId string) bool {
+	return xchange.GetOneChange(changeId) == nil && xchange.GetOneEntityChange(changeId) != nil
+}
+
+func IsApprovedEntityChange(approveId string) bool {
+	return xchange.GetOneApprovedChange(approveId) == nil && xchange.GetOneApprovedEntityChange(approveId) != nil
+}
+
+func GetEntityChangesByEntityId(entityId string) []*xchange.EntityChange {
+	result := []*xchange.EntityChange{}
+	for _, change := range xchange.GetEntityChangeList() {
+		if change.EntityID == entityId {
+			result = append(result, change)
+		}
+	}
+	return result
+}
+
+func GetEntityChanges(applicationType string, entityType string) []*xchange.EntityChange {
+	result := []*xchange.EntityChange{}
+	for _, change := range xchange.GetEntityChangeList() {
+		if !xshared.ApplicationTypeEquals(applicationType, change.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+			continue
+		}
+		if entityType != "" && !strings.EqualFold(entityType, change.EntityType) {
+			continue
+		}
+		result = append(result, change)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[j].Updated < result[i].Updated
+	})
+	return result
+}
+
+func GetApprovedEntityChanges(applicationType string, entityType string) []*xchange.ApprovedEntityChange {
+	result := []*xchange.ApprovedEntityChange{}
+	for _, change := range xchange.GetApprovedEntityChangeList() {
+		if !xshared.ApplicationTypeEquals(applicationType, change.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+			continue
+		}
+		if entityType != "" && !strings.EqualFold(entityType, change.EntityType) {
+			continue
+		}
+		result = append(result, change)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[j].Updated < result[i].Updated
+	})
+	return result
+}
+
+func newChangeApprovalForEntityChange(change *xchange.EntityChange) *xchange.ChangeApproval {
+	approval := xchange.NewChangeApprovalInf().(*xchange.ChangeApproval)
+	approval.ID = change.ID
+	approval.EntityID = change.EntityID
+	approval.EntityType = change.EntityType
+	approval.ApplicationType = change.ApplicationType
+	approval.Author = change.Author
+	return approval
+}
+
+func ApproveEntityChange(r *http.Request, changeId string) (*xchange.ApprovedEntityChange, error) {
+	change := xchange.GetOneEntityChange(changeId)
+	if change == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Change with "+changeId+" id does not exist")
+	}
+	workflow := getEntityChangeWorkflow(change.EntityType)
+	if workflow == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Change workflow is not supported for "+change.EntityType)
+	}
+
+	if err := checkNotScheduled(changeId); err != nil {
+		return nil, err
+	}
+	approval, err := registerApproval(r, newChangeApprovalForEntityChange(change))
+	if err != nil {
+		return nil, err
+	}
+	return workflow.applyEntityChange(r, change, approval)
+}
+
+// applyEntityChange applies a change whose approvals are satisfied
+func (workflow *EntityChangeWorkflow) applyEntityChange(r *http.Request, change *xchange.EntityChange, approval *xchange.ChangeApproval) (*xchange.ApprovedEntityChange, error) {
+	err := workflow.resolveEntityChange(change)
+	if err != nil {
+		return nil, err
+	}
+	switch change.Operation {
+	case xchange.Create:
+		err = workflow.apply(r, workflow.createHandler, http.MethodPost, change.NewEntity, "", change.ApplicationType)
+	case xchange.Update:
+		err = workflow.apply(r, workflow.updateHandler, http.MethodPut, change.NewEntity, "", change.ApplicationType)
+	case xchange.Delete:
+		err = workflow.apply(r, workflow.deleteHandler, http.MethodDelete, nil, change.EntityID, change.ApplicationType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	change.ApprovedUser = auth.GetUserNameOrUnknown(r)
+	approvedChange := xchange.ApprovedEntityChange(*change)
+	if err := xchange.SetOneApprovedEntityChange(&approvedChange); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	if err := xchange.DeleteOneEntityChange(change.ID); err != nil {
+		log.Errorf("Failed to delete approved EntityChange %s: %v", change.ID, err)
+	}
+	saveChangeApproval(approval)
+	log.Infof("EntityChange approved by %s: %s %s %s", change.ApprovedUser, change.Operation, change.EntityType, change.EntityID)
+	return &approvedChange, nil
+}
+
+// resolveEntityChange checks the change against the current entity, other pending changes of the entity
+// stay pending and are checked the same way when they are approved
+func (workflow *EntityChangeWorkflow) resolveEntityChange(change *xchange.EntityChange) error {
+	current := entityBytes(workflow.getEntity(change.EntityID))
+	merged, err := resolveChange(change.ID, change.EntityID, change.Operation, change.OldEntity, current, change.NewEntity)
+	if err != nil {
+		return err
+	}
+	if change.Operation == xchange.Update && !bytes.Equal(merged, change.NewEntity) {
+		// the approved change reverts to the entity it has actually been applied to
+		change.OldEntity = current
+		change.NewEntity = merged
+	}
+	return nil
+}
+
+func RevertEntityChange(r *http.Request, approveId string) error {
+	approvedChange := xchange.GetOneApprovedEntityChange(approveId)
+	if approvedChange == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, "ApprovedChange with "+approveId+" id does not exist")
+	}
+	workflow := getEntityChangeWorkflow(approvedChange.EntityType)
+	if workflow == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Change workflow is not supported for "+approvedChange.EntityType)
+	}
+
+	var err error
+	switch approvedChange.Operation {
+	case xchange.Create:
+		err = workflow.apply(r, workflow.deleteHandler, http.MethodDelete, nil, approvedChange.EntityID, approvedChange.ApplicationType)
+	case xchange.Update:
+		err = workflow.apply(r, workflow.updateHandler, http.MethodPut, approvedChange.OldEntity, "", approvedChange.ApplicationType)
+	case xchange.Delete:
+		err = workflow.apply(r, workflow.createHandler, http.MethodPost, approvedChange.OldEntity, "", approvedChange.ApplicationType)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := xchange.DeleteOneApprovedEntityChange(approveId); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	log.Infof("EntityChange has been reverted by %s: %s", auth.GetUserNameOrUnknown(r), approveId)
+	return nil
+}
+
+func CancelEntityChange(r *http.Request, changeId string) error {
+	if xchange.GetOneEntityChange(changeId) == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, " Change with "+changeId+" id does not exist")
+	}
+	if err := xchange.DeleteOneEntityChange(changeId); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	xchange.DeleteOneChangeApproval(changeId)
+	xchange.DeleteOneScheduledChange(changeId)
+	log.Infof("EntityChange has been canceled by %s: %s", auth.GetUserNameOrUnknown(r), changeId)
+	return nil
+}
+
+// splitEntityChangeIds separates the ids of entity changes from the ids of telemetry profile changes
+func splitEntityChangeIds(ids []string, isEntityChange func(id string) bool) ([]string, []string) {
+	changeIds := []string{}
+	entityChangeIds := []string{}
+	for _, id := range ids {
+		if id != "" && isEntityChange(id) {
+			entityChangeIds = append(entityChangeIds, id)
+		} else {
+			changeIds = append(changeIds, id)
+		}
+	}
+	return changeIds, entityChangeIds
+}
+
+func ApproveEntityChanges(r *http.Request, changeIds []string) map[string]string {
+	changes := []*xchange.EntityChange{}
+	for _, changeId := range changeIds {
+		if change := xchange.GetOneEntityChange(changeId); change != nil {
+			changes = append(changes, change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Updated < changes[j].Updated
+	})
+	errorMessages := make(map[string]string)
+	for _, change := range changes {
+		// the change may have been rejected or canceled in the meantime
+		if xchange.GetOneEntityChange(change.ID) == nil {
+			continue
+		}
+		if _, err := ApproveEntityChange(r, change.ID); err != nil {
+			errMsg := fmt.Sprintf("ApprovingException:  %v", err)
+			log.Error(errMsg)
+			errorMessages[change.ID] = errMsg
+		}
+	}
+	return errorMessages
+}
+
+func RevertEntityChanges(r *http.Request, approveIds []string) map[string]string {
+	approvedChanges := []*xchange.ApprovedEntityChange{}
+	for _, approveId := range approveIds {
+		if approvedChange := xchange.GetOneApprovedEntityChange(approveId); approvedChange != nil {
+			approvedChanges = append(approvedChanges, approvedChange)
+		}
+	}
+	sort.Slice(approvedChanges, func(i, j int) bool {
+		return approvedChanges[j].Updated < approvedChanges[i].Updated
+	})
+	errorMessages := make(map[string]string)
+	for _, approvedChange := range approvedChanges {
+		if err := RevertEntityChange(r, approvedChange.ID); err != nil {
+			log.Error("RevertingException: ", err.Error())
+			errorMessages[approvedChange.ID] = err.Error()
+		}
+	}
+	return errorMessages
+}
+
+// apply calls a regular entity handler on behalf of the approver with the stored entity
+func (wf *EntityChangeWorkflow) apply(r *http.Request, handler http.HandlerFunc, method string, entity []byte, id string, applicationType string) error {
+	vars := map[string]string{}
+	if id != "" {
+		vars[xcommon.ID] = id
+	}
+	query := url.Values{xshared.APPLICATION_TYPE: []string{applicationType}}
+	recorder := xhttp.ReplayHandler(r, handler, method, entity, vars, query)
+	if recorder.Status() >= http.StatusMultipleChoices {
+		return xwcommon.NewRemoteErrorAS(recorder.Status(), fmt.Sprintf("Unable to apply %s %s: %s", wf.entityType, method, string(recorder.Body())))
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testEntity struct {
+	ID string `json:"id"`
+}
+
+func TestIsNilEntity(t *testing.T) {
+	var entity *testEntity
+	assert.True(t, isNilEntity(nil))
+	assert.True(t, isNilEntity(entity))
+	assert.False(t, isNilEntity(&testEntity{ID: "id"}))
+}
+
+func TestSplitEntityChangeIds(t *testing.T) {
+	isEntityChange := func(id string) bool {
+		return id == "entity-1" || id == "entity-2"
+	}
+	changeIds, entityChangeIds := splitEntityChangeIds([]string{"change-1", "entity-1", "", "entity-2"}, isEntityChange)
+	assert.Equal(t, []string{"change-1", ""}, changeIds)
+	assert.Equal(t, []string{"entity-1", "entity-2"}, entityChangeIds)
+}
+
+func TestEntityChangeWorkflowPassThroughWhenDisabled(t *testing.T) {
+	oldTypes := xcommon.ChangeWorkflowEntityTypes
+	xcommon.ChangeWorkflowEntityTypes = util.Set{}
+	t.Cleanup(func() {
+		xcommon.ChangeWorkflowEntityTypes = oldTypes
+	})
+
+	called := false
+	next := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusCreated)
+	}
+	workflow := NewEntityChangeWorkflow("TEST_ENTITY", "", func(id string) interface{} { return nil }, next, next, next)
+	assert.False(t, IsEntityChangeWorkflowEnabled("TEST_ENTITY"))
+
+	rr := httptest.NewRecorder()
+	workflow.CreateHandler(rr, httptest.NewRequest(http.MethodPost, "/", nil))
+	assert.True(t, called)
+	assert.Equal(t, http.StatusCreated, rr.Code)
+
+	xcommon.ChangeWorkflowEntityTypes.Add("TEST_ENTITY")
+	assert.True(t, IsEntityChangeWorkflowEnabled("TEST_ENTITY"))
+	assert.False(t, IsEntityChangeWorkflowEnabled(xchange.FirmwareRule))
+}
+
+func TestEntityChangeWorkflowGuardHandler(t *testing.T) {
+	oldTypes := xcommon.ChangeWorkflowEntityTypes
+	xcommon.ChangeWorkflowEntityTypes = util.Set{}
+	t.Cleanup(func() {
+		xcommon.ChangeWorkflowEntityTypes = oldTypes
+	})
+
+	next := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	workflow := NewEntityChangeWorkflow("GUARDED_ENTITY", "", func(id string) interface{} { return nil }, next, next, next)
+	guarded := workflow.GuardHandler(next)
+
+	rr := httptest.NewRecorder()
+	guarded(rr, httptest.NewRequest(http.MethodPost, "/entities", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	xcommon.ChangeWorkflowEntityTypes.Add("GUARDED_ENTITY")
+	rr = httptest.NewRecorder()
+	guarded(rr, httptest.NewRequest(http.MethodPost, "/entities", nil))
+	assert.Equal(t, http.StatusConflict, rr.Code)
+}
@@ -0,0 +1,99 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+
+	"github.com/gorilla/mux"
+)
+
+// scheduleChange answers 202 with the scheduled change, or with the collected approvals when more approvers are needed
+func scheduleChange(w http.ResponseWriter, r *http.Request, kind string, changeId string, applyAtParam string) {
+	applyAt, err := ParseApplyAt(applyAtParam, time.Now())
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	scheduledChange, err := ScheduleChange(r, kind, changeId, applyAt)
+	if err != nil {
+		if writeAwaitingApprovalsResponse(w, r, err) {
+			return
+		}
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(scheduledChange, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusAccepted, xhttp.ContextTypeHeader(r))
+}
+
+func GetScheduledChangesHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanRead(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+
+	res, err := xhttp.ReturnJsonResponse(GetScheduledChanges(applicationType), r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func UnscheduleChangeHandler(w http.ResponseWriter, r *http.Request) {
+	applicationType, err := auth.CanWrite(r, auth.CHANGE_ENTITY)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+
+	changeId, found := mux.Vars(r)[xcommon.CHANGE_ID]
+	if !found || changeId == "" {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.CHANGE_ID))
+		return
+	}
+	if scheduledChange := xchange.GetOneScheduledChange(changeId); scheduledChange != nil &&
+		!xshared.ApplicationTypeEquals(applicationType, scheduledChange.ApplicationType) && !xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+		xhttp.AdminError(w, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Scheduled change "+changeId+" does not exist"))
+		return
+	}
+
+	if err := UnscheduleChange(r, changeId); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteXconfResponse(w, http.StatusOK, nil)
+}
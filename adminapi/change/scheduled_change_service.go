@@ -0,0 +1,338 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+	xutil "github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/rdkcentral/xconfwebconfig/db"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+var scheduledChangeLock = db.NewDistributedLock(xcommon.TABLE_XCONF_SCHEDULED_CHANGE, 60)
+
+// entityChangeModules maps entity change types to the lockdown modules they belong to
+var entityChangeModules = map[string]string{
+	xchange.FirmwareRule:   auth.FIRMWARE_MODULE,
+	xchange.PercentageBean: auth.FIRMWARE_MODULE,
+	xchange.FeatureRule:    auth.RFC_MODULE,
+	xchange.Feature:        auth.RFC_MODULE,
+	xchange.DCMGenericRule: auth.DCM_MODULE,
+	xchange.NamespacedList: auth.COMMON_MODULE,
+}
+
+// ParseApplyAt accepts the apply time either as epoch milliseconds or as RFC3339, it has to be in the future
+func ParseApplyAt(value string, now time.Time) (int64, error) {
+	var applyAt int64
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		applyAt = millis
+	} else if t, err := time.Parse(time.RFC3339, value); err == nil {
+		applyAt = xwutil.GetTimestamp(t)
+	} else {
+		return 0, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("%s must be epoch milliseconds or RFC3339: %s", xcommon.APPLY_AT, value))
+	}
+	if applyAt <= xwutil.GetTimestamp(now) {
+		return 0, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("%s must be in the future", xcommon.APPLY_AT))
+	}
+	return applyAt, nil
+}
+
+// checkNotScheduled rejects approving a change which is already waiting for its apply time
+func checkNotScheduled(changeId string) error {
+	if scheduledChange := xchange.GetOneScheduledChange(changeId); scheduledChange != nil && scheduledChange.Status == xchange.ScheduledStatus {
+		applyAt := time.UnixMilli(scheduledChange.ApplyAt).UTC().Format(time.RFC3339)
+		return xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Change %s is already scheduled to be applied at %s", changeId, applyAt))
+	}
+	return nil
+}
+
+// ScheduleChange records the approval of the current user and, once the approvals are satisfied,
+// schedules the change to be applied at applyAt instead of applying it right away
+func ScheduleChange(r *http.Request, kind string, changeId string, applyAt int64) (*xchange.ScheduledChange, error) {
+	if err := checkNotScheduled(changeId); err != nil {
+		return nil, err
+	}
+
+	scheduledChange := &xchange.ScheduledChange{
+		ID:          changeId,
+		Kind:        kind,
+		ApplyAt:     applyAt,
+		ScheduledBy: auth.GetUserNameOrUnknown(r),
+		Status:      xchange.ScheduledStatus,
+	}
+	var candidate *xchange.ChangeApproval
+	switch kind {
+	case xchange.TelemetryChangeKind:
+		change := xchange.GetOneChange(changeId)
+		if change == nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Change with "+changeId+" id does not exist")
+		}
+		candidate = newChangeApprovalForChange(change)
+		scheduledChange.Module = auth.TELEMETRY_MODULE
+	case xchange.TelemetryTwoChangeKind:
+		change := xchange.GetOneTelemetryTwoChange(changeId)
+		if change == nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Entity with id  %s does not exist", changeId))
+		}
+		candidate = newChangeApprovalForTelemetryTwoChange(change)
+		scheduledChange.Module = auth.TELEMETRY_MODULE
+	case xchange.EntityChangeKind:
+		change := xchange.GetOneEntityChange(changeId)
+		if change == nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Change with "+changeId+" id does not exist")
+		}
+		candidate = newChangeApprovalForEntityChange(change)
+		scheduledChange.Module = entityChangeModules[change.EntityType]
+	default:
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "Unknown change kind "+kind)
+	}
+	scheduledChange.EntityID = candidate.EntityID
+	scheduledChange.EntityType = candidate.EntityType
+	scheduledChange.ApplicationType = candidate.ApplicationType
+
+	approval, err := registerApproval(r, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	// the change is applied later on behalf of the approver, see newScheduledChangeRequest
+	if apiToken := xhttp.GetApiTokenFromContext(r); apiToken != nil {
+		scheduledChange.ApiTokenID = apiToken.ID
+	} else if loginToken := xhttp.GetLoginTokenFromContext(r); loginToken != nil {
+		scheduledChange.Permissions = xhttp.GetPermissionsFromContext(r)
+		scheduledChange.GrantsExpireAt = int64(loginToken.ExpirationTime * 1000)
+		scheduledChange.Groups = getLoginTokenGroups(loginToken)
+	}
+	if err := xchange.SetOneScheduledChange(scheduledChange); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	saveChangeApproval(approval)
+	log.Infof("Change %s approved by %s and scheduled at %d", changeId, scheduledChange.ScheduledBy, applyAt)
+	return scheduledChange, nil
+}
+
+func GetScheduledChanges(applicationType string) []*xchange.ScheduledChange {
+	result := []*xchange.ScheduledChange{}
+	for _, scheduledChange := range xchange.GetScheduledChangeList() {
+		if xshared.ApplicationTypeEquals(applicationType, scheduledChange.ApplicationType) || xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+			result = append(result, scheduledChange)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ApplyAt < result[j].ApplyAt
+	})
+	return result
+}
+
+// UnscheduleChange removes the schedule, the change stays pending and has to be approved again
+func UnscheduleChange(r *http.Request, changeId string) error {
+	if xchange.GetOneScheduledChange(changeId) == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, "Scheduled change "+changeId+" does not exist")
+	}
+	if err := xchange.DeleteOneScheduledChange(changeId); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	xchange.DeleteOneChangeApproval(changeId)
+	log.Infof("Scheduled change has been unscheduled by %s: %s", auth.GetUserNameOrUnknown(r), changeId)
+	return nil
+}
+
+// StartScheduledChangeApplier periodically applies the scheduled changes which are due
+func StartScheduledChangeApplier(interval time.Duration) {
+	if interval <= 0 {
+		log.Info("Scheduled change applier is disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ApplyDueScheduledChanges(time.Now())
+		}
+	}()
+}
+
+func ApplyDueScheduledChanges(now time.Time) {
+	if xhttp.WebConfServer != nil && xhttp.WebConfServer.DistributedLockConfig.Enabled {
+		owner, _ := os.Hostname()
+		owner = "scheduled-change-applier-" + owner
+		if err := scheduledChangeLock.Lock(owner); err != nil {
+			log.Debugf("Scheduled changes are applied by another instance: %v", err)
+			return
+		}
+		defer func() {
+			if err := scheduledChangeLock.Unlock(owner); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+
+	retention := time.Duration(xcommon.ScheduledChangeFailedRetentionInDays) * 24 * time.Hour
+	for _, scheduledChange := range xchange.GetScheduledChangeList() {
+		if retention > 0 && scheduledChange.IsExpiredFailure(now, retention) {
+			if err := xchange.DeleteOneScheduledChange(scheduledChange.ID); err != nil {
+				log.Errorf("Failed to remove failed scheduled change %s: %v", scheduledChange.ID, err)
+			}
+			continue
+		}
+		if !scheduledChange.IsDue(now) {
+			continue
+		}
+		// wait for the end of the lockdown window
+		if auth.IsModuleLocked(scheduledChange.Module) {
+			log.Infof("Scheduled change %s is waiting for the end of lockdown of %s module", scheduledChange.ID, scheduledChange.Module)
+			continue
+		}
+		applyScheduledChange(scheduledChange, now)
+	}
+}
+
+func applyScheduledChange(scheduledChange *xchange.ScheduledChange, now time.Time) {
+	r, err := newScheduledChangeRequest(scheduledChange, now)
+	if err == nil {
+		// the approver must still be allowed to approve the change
+		_, err = auth.CanWrite(r, auth.CHANGE_ENTITY, scheduledChange.ApplicationType)
+		if xwcommon.GetXconfErrorStatusCode(err) == http.StatusLocked {
+			log.Infof("Scheduled change %s is waiting for the end of lockdown of %s module", scheduledChange.ID, auth.CHANGE_MODULE)
+			return
+		}
+	}
+	if err != nil {
+		failScheduledChange(scheduledChange, fmt.Errorf("approver %s may no longer apply the change: %w", scheduledChange.ScheduledBy, err))
+		return
+	}
+	approval := xchange.GetOneChangeApproval(scheduledChange.ID)
+
+	found := true
+	switch scheduledChange.Kind {
+	case xchange.TelemetryChangeKind:
+		if change := xchange.GetOneChange(scheduledChange.ID); change == nil {
+			found = false
+		} else {
+			if approval == nil {
+				approval = newChangeApprovalForChange(change)
+			}
+			_, err = applyChange(r, change, approval)
+		}
+	case xchange.TelemetryTwoChangeKind:
+		if change := xchange.GetOneTelemetryTwoChange(scheduledChange.ID); change == nil {
+			found = false
+		} else {
+			if approval == nil {
+				approval = newChangeApprovalForTelemetryTwoChange(change)
+			}
+			_, err = applyTelemetryTwoChange(r, change, approval)
+		}
+	case xchange.EntityChangeKind:
+		change := xchange.GetOneEntityChange(scheduledChange.ID)
+		if change == nil {
+			found = false
+		} else if workflow := getEntityChangeWorkflow(change.EntityType); workflow == nil {
+			err = fmt.Errorf("change workflow is not supported for %s", change.EntityType)
+		} else {
+			if approval == nil {
+				approval = newChangeApprovalForEntityChange(change)
+			}
+			_, err = workflow.applyEntityChange(r, change, approval)
+		}
+	default:
+		err = fmt.Errorf("unknown change kind %s", scheduledChange.Kind)
+	}
+
+	if !found {
+		log.Warnf("Scheduled change %s is no longer pending, removing the schedule", scheduledChange.ID)
+		xchange.DeleteOneScheduledChange(scheduledChange.ID)
+		return
+	}
+	if err != nil {
+		failScheduledChange(scheduledChange, err)
+		return
+	}
+	xchange.DeleteOneScheduledChange(scheduledChange.ID)
+	log.Infof("Scheduled change %s approved by %s has been applied", scheduledChange.ID, scheduledChange.ScheduledBy)
+}
+
+// failScheduledChange keeps the failure until the retention, the change stays pending and can be approved again
+func failScheduledChange(scheduledChange *xchange.ScheduledChange, err error) {
+	log.Errorf("Failed to apply scheduled change %s: %v", scheduledChange.ID, err)
+	scheduledChange.Status = xchange.FailedStatus
+	scheduledChange.Error = err.Error()
+	if err := xchange.SetOneScheduledChange(scheduledChange); err != nil {
+		log.Errorf("Failed to save scheduled change %s: %v", scheduledChange.ID, err)
+	}
+	xchange.DeleteOneChangeApproval(scheduledChange.ID)
+}
+
+// getLoginTokenGroups returns the roles the login token has been issued with, the groups its roles are bound to
+func getLoginTokenGroups(loginToken *xhttp.LoginToken) []string {
+	groups := []string{}
+	for _, application := range loginToken.Application {
+		if application.Role != "" && !xutil.Contains(groups, application.Role) {
+			groups = append(groups, application.Role)
+		}
+	}
+	return groups
+}
+
+// newScheduledChangeRequest builds the request a scheduled change is applied with, it carries the identity of
+// the approver with the grants the approver has now: the current permissions of the api token, or the current
+// roles of the approver and the permissions of the login token as long as the token has not expired
+func newScheduledChangeRequest(scheduledChange *xchange.ScheduledChange, now time.Time) (*http.Request, error) {
+	ctx := context.Background()
+	if scheduledChange.ApiTokenID != "" {
+		apiToken := apitoken.GetOneApiToken(scheduledChange.ApiTokenID)
+		if apiToken == nil || apiToken.IsRevoked() || apiToken.IsExpired(xwutil.GetTimestamp(now)) {
+			return nil, fmt.Errorf("api token %s is revoked or expired", scheduledChange.ApiTokenID)
+		}
+		ctx = context.WithValue(ctx, xhttp.CTX_KEY_API_TOKEN, apiToken)
+		ctx = context.WithValue(ctx, xhttp.CTX_KEY_PERMISSIONS, apiToken.Permissions)
+	} else {
+		loginToken := &xhttp.LoginToken{Subject: scheduledChange.ScheduledBy}
+		for _, group := range scheduledChange.Groups {
+			loginToken.Application = append(loginToken.Application, xhttp.Application{Role: group})
+		}
+		ctx = context.WithValue(ctx, xhttp.CTX_KEY_TOKEN, loginToken)
+		if len(scheduledChange.Permissions) > 0 && xwutil.GetTimestamp(now) < scheduledChange.GrantsExpireAt {
+			ctx = context.WithValue(ctx, xhttp.CTX_KEY_PERMISSIONS, scheduledChange.Permissions)
+		}
+	}
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "/xconfAdminService/change/scheduled", nil)
+	r.URL.RawQuery = url.Values{xshared.APPLICATION_TYPE: []string{strings.ToLower(scheduledChange.ApplicationType)}}.Encode()
+	r.Header.Set(xhttp.AUTH_SUBJECT, scheduledChange.ScheduledBy)
+	return r, nil
+}
@@ -0,0 +1,97 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package change
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApplyAt(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	applyAt, err := ParseApplyAt("2025-03-02T02:00:00Z", now)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 3, 2, 2, 0, 0, 0, time.UTC).UnixMilli(), applyAt)
+
+	millis := now.Add(time.Hour).UnixMilli()
+	applyAt, err = ParseApplyAt("1740834000000", now)
+	assert.Nil(t, err)
+	assert.Equal(t, millis, applyAt)
+
+	_, err = ParseApplyAt("2025-03-01T11:00:00Z", now)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+
+	_, err = ParseApplyAt("tomorrow", now)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
+
+func TestScheduledChangeIsDue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	scheduledChange := &xchange.ScheduledChange{
+		ApplyAt: now.UnixMilli(),
+		Status:  xchange.ScheduledStatus,
+	}
+	assert.True(t, scheduledChange.IsDue(now))
+	assert.False(t, scheduledChange.IsDue(now.Add(-time.Second)))
+
+	scheduledChange.Status = xchange.FailedStatus
+	assert.False(t, scheduledChange.IsDue(now))
+}
+
+func TestNewScheduledChangeRequest(t *testing.T) {
+	now := time.Now()
+	scheduledChange := &xchange.ScheduledChange{
+		ID:              "change-1",
+		ApplicationType: "stb",
+		ScheduledBy:     "approver",
+		Permissions:     []string{"write-changes-*"},
+		GrantsExpireAt:  now.Add(time.Hour).UnixMilli(),
+		Groups:          []string{"ops"},
+	}
+	r, err := newScheduledChangeRequest(scheduledChange, now)
+	assert.Nil(t, err)
+	assert.Equal(t, "approver", r.Header.Get(xhttp.AUTH_SUBJECT))
+	assert.Equal(t, "stb", r.URL.Query().Get("applicationType"))
+	assert.Equal(t, []string{"write-changes-*"}, xhttp.GetPermissionsFromContext(r))
+	assert.Equal(t, []string{"ops"}, getLoginTokenGroups(xhttp.GetLoginTokenFromContext(r)))
+	assert.Empty(t, xhttp.GetCapabilitiesFromContext(r))
+
+	// the permissions of the login token are not replayed once the token has expired
+	r, err = newScheduledChangeRequest(scheduledChange, now.Add(2*time.Hour))
+	assert.Nil(t, err)
+	assert.Empty(t, xhttp.GetPermissionsFromContext(r))
+	assert.Equal(t, []string{"ops"}, getLoginTokenGroups(xhttp.GetLoginTokenFromContext(r)))
+}
+
+func TestScheduledChangeIsExpiredFailure(t *testing.T) {
+	now := time.Now()
+	scheduledChange := &xchange.ScheduledChange{Status: xchange.FailedStatus, Updated: now.Add(-48 * time.Hour).UnixMilli()}
+	assert.True(t, scheduledChange.IsExpiredFailure(now, 24*time.Hour))
+	assert.False(t, scheduledChange.IsExpiredFailure(now, 72*time.Hour))
+
+	scheduledChange.Status = xchange.ScheduledStatus
+	assert.False(t, scheduledChange.IsExpiredFailure(now, 24*time.Hour))
+}
@@ -108,8 +108,19 @@ func ApproveTwoChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if applyAt := r.URL.Query().Get(xcommon.APPLY_AT); applyAt != "" {
+		scheduleChange(w, r, xchange.TelemetryTwoChangeKind, changeId, applyAt)
+		return
+	}
+
 	approvedChange, err := ApproveTelemetryTwoChange(r, changeId)
 	if err != nil {
+		if writeAwaitingApprovalsResponse(w, r, err) {
+			return
+		}
+		if writeChangeConflictResponse(w, r, err) {
+			return
+		}
 		xhttp.AdminError(w, err)
 		return
 	}
@@ -167,11 +178,13 @@ func RevertTwoChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	target := findReviewTarget(approveId)
 	respEntity := RevertTelemetryTwoChange(r, approveId)
 	if respEntity.Error != nil {
 		xwhttp.WriteXconfResponse(w, respEntity.Status, []byte(respEntity.Error.Error()))
 		return
 	}
+	recordChangeReviewOf(r, approveId, target, xchange.Reverted)
 
 	res, err := xhttp.ReturnJsonResponse(respEntity.Data, r)
 	if err != nil {
@@ -202,7 +215,9 @@ func RevertTwoChangesHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	targets := findReviewTargets(idList)
 	errorMessages := RevertTelemetryTwoChanges(r, idList)
+	recordChangeReviewsOf(r, targets, errorMessages, xchange.Reverted)
 
 	res, err := xhttp.ReturnJsonResponse(errorMessages, r)
 	if err != nil {
@@ -226,10 +241,14 @@ func CancelTwoChangeHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	target := findReviewTarget(changeId)
 	if err := DeleteTelemetryTwoChange(changeId); err != nil {
 		xhttp.AdminError(w, err)
 		return
 	}
+	recordChangeReviewOf(r, changeId, target, xchange.Canceled)
+	xchange.DeleteOneChangeApproval(changeId)
+	xchange.DeleteOneScheduledChange(changeId)
 
 	userName := auth.GetUserNameOrUnknown(r)
 	log.Info(fmt.Sprintf("Change has been canceled by %s: %s", userName, changeId))
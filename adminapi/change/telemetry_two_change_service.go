@@ -18,6 +18,8 @@
 package change
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"sort"
@@ -140,6 +142,22 @@ func ApproveTelemetryTwoChange(r *http.Request, changeId string) (*xwchange.Appr
 		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Entity with id  %s does not exist", changeId))
 	}
 
+	if err := checkNotScheduled(changeId); err != nil {
+		return nil, err
+	}
+	approval, err := registerApproval(r, newChangeApprovalForTelemetryTwoChange(change))
+	if err != nil {
+		return nil, err
+	}
+	return applyTelemetryTwoChange(r, change, approval)
+}
+
+// applyTelemetryTwoChange applies a change whose approvals are satisfied
+func applyTelemetryTwoChange(r *http.Request, change *xwchange.TelemetryTwoChange, approval *xchange.ChangeApproval) (*xwchange.ApprovedTelemetryTwoChange, error) {
+	changeId := change.ID
+	if err := resolveTelemetryTwoProfileChange(change); err != nil {
+		return nil, err
+	}
 	if change.Operation == xchange.Create {
 		if _, err := CreateTelemetryTwoProfile(r, change.NewEntity); err != nil {
 			return nil, err
@@ -154,6 +172,7 @@ func ApproveTelemetryTwoChange(r *http.Request, changeId string) (*xwchange.Appr
 			return nil, err
 		}
 
+		saveChangeApproval(approval)
 		return approvedChange, nil
 	}
 
@@ -162,44 +181,57 @@ func ApproveTelemetryTwoChange(r *http.Request, changeId string) (*xwchange.Appr
 		return nil, err
 	}
 
+	saveChangeApproval(approval)
+	cancelChangesOfDeletedTelemetryTwoProfile(r, change)
 	return approvedChange, nil
 }
 
+// cancelChangesOfDeletedTelemetryTwoProfile cancels the pending changes of a profile the change has deleted, they can not be applied anymore
+func cancelChangesOfDeletedTelemetryTwoProfile(r *http.Request, change *xwchange.TelemetryTwoChange) {
+	if change.Operation != xchange.Delete {
+		return
+	}
+	if err := cancelApprovedTelemetryTwoChangesByEntityId(r, []string{change.EntityID}, []string{}); err != nil {
+		log.Errorf("Failed to cancel the changes of the deleted profile %s: %v", change.EntityID, err)
+	}
+}
+
 func ApproveTelemetryTwoChanges(r *http.Request, changeIds []string) map[string]string {
 	errorMessages := make(map[string]string)
-	mergedUpdateChangesByEntityId := make(map[string]*logupload.TelemetryTwoProfile)
-	entityToByCancelChange := []string{}
 	changesToApprove := GetTelemetryTwoChangesByIds(changeIds)
 	for _, change := range changesToApprove {
-		var err error
+		if err := checkNotScheduled(change.ID); err != nil {
+			errorMessages[change.ID] = err.Error()
+			continue
+		}
+		approval, err := registerApproval(r, newChangeApprovalForTelemetryTwoChange(change))
+		if err != nil {
+			errorMessages[change.ID] = err.Error()
+			continue
+		}
+		// changes of the same profile approved in one batch are merged one after the other
+		if err = resolveTelemetryTwoProfileChange(change); err != nil {
+			errorMessages[change.ID] = err.Error()
+			continue
+		}
 		switch {
 		case xchange.Create == change.Operation:
 			_, err = CreateTelemetryTwoProfile(r, change.NewEntity)
 		case xchange.Update == change.Operation:
-			var mergeResult *logupload.TelemetryTwoProfile
-			mergeResult, err = applyUpdateTelemetryTwoChange(mergedUpdateChangesByEntityId[change.EntityID], change)
-			if err == nil {
-				mergedUpdateChangesByEntityId[mergeResult.ID] = mergeResult
-				_, err = UpdateTelemetryTwoProfile(r, mergeResult)
-			}
+			_, err = UpdateTelemetryTwoProfile(r, change.NewEntity)
 		case xchange.Delete == change.Operation:
 			err = DeleteTelemetryTwoProfile(r, change.OldEntity.ID)
 		}
 		if err == nil {
-			entityToByCancelChange = append(entityToByCancelChange, change.EntityID)
-			saveToApprovedAndCleanUpTelemetryTwoChange(r, change)
+			if err := saveToApprovedAndCleanUpTelemetryTwoChange(r, change); err == nil {
+				saveChangeApproval(approval)
+				cancelChangesOfDeletedTelemetryTwoProfile(r, change)
+			}
 		} else {
 			errorMessages[change.ID] = err.Error()
 		}
 	}
 
-	ids := make([]string, 0, len(errorMessages))
-	for k := range errorMessages {
-		ids = append(ids, k)
-	}
-	if err := cancelApprovedTelemetryTwoChangesByEntityId(r, entityToByCancelChange, ids); err != nil {
-		log.Errorf("Failed to cancel approved changes (%v): %v", entityToByCancelChange, err)
-	}
 	if len(errorMessages) > 0 {
 		log.Errorf("Approving Error: %v", errorMessages)
 	}
@@ -474,11 +506,31 @@ func buildToDeleteTelemetryTwoChange(oldEntity *logupload.TelemetryTwoProfile, a
 	return change
 }
 
+// resolveTelemetryTwoProfileChange checks the change against the current profile, an update which does not
+// overlap with the modifications made since the change was created is merged into the current profile
+func resolveTelemetryTwoProfileChange(change *xwchange.TelemetryTwoChange) error {
+	current := logupload.GetOneTelemetryTwoProfile(change.EntityID)
+	proposed := entityBytes(change.NewEntity)
+	merged, err := resolveChange(change.ID, change.EntityID, change.Operation, entityBytes(change.OldEntity), entityBytes(current), proposed)
+	if err != nil {
+		return err
+	}
+	if xchange.Update != change.Operation || bytes.Equal(merged, proposed) {
+		return nil
+	}
+	mergedProfile := &logupload.TelemetryTwoProfile{}
+	if err := json.Unmarshal(merged, mergedProfile); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	// the approved change reverts to the profile it has actually been applied to
+	change.OldEntity = current
+	change.NewEntity = mergedProfile
+	return nil
+}
+
 func updateDeleteEntityTelemetryTwoChange(r *http.Request, change *xwchange.TelemetryTwoChange) (*xwchange.ApprovedTelemetryTwoChange, error) {
 	currentEntity := change.OldEntity
 	entityToChange := logupload.GetOneTelemetryTwoProfile(change.EntityID)
-	// in Java, equalPendingEntities(currentEntity, entityToChange) always return true
-	//if (entityToChange != null && equalPendingEntities(currentEntity, entityToChange)) {
 	if entityToChange != nil {
 		if change.Operation == xchange.Delete {
 			if err := DeleteTelemetryTwoProfile(r, currentEntity.ID); err != nil {
@@ -0,0 +1,70 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package adminapi
+
+import (
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/adminapi/change"
+	"github.com/rdkcentral/xconfadmin/adminapi/dcm"
+	"github.com/rdkcentral/xconfadmin/adminapi/queries"
+	"github.com/rdkcentral/xconfadmin/adminapi/rfc/feature"
+	xchange "github.com/rdkcentral/xconfadmin/shared/change"
+
+	"github.com/rdkcentral/xconfwebconfig/shared/firmware"
+)
+
+// entity writes that go through pending changes when enabled in change_workflow_entity_types
+var (
+	firmwareRuleWorkflow = change.NewEntityChangeWorkflow(xchange.FirmwareRule, auth.FIRMWARE_ENTITY,
+		func(id string) interface{} {
+			rule, _ := firmware.GetFirmwareRuleOneDB(id)
+			return rule
+		},
+		queries.PostFirmwareRuleHandler, queries.PutFirmwareRuleHandler, queries.DeleteFirmwareRuleByIdHandler)
+
+	percentageBeanWorkflow = change.NewEntityChangeWorkflow(xchange.PercentageBean, auth.FIRMWARE_ENTITY,
+		func(id string) interface{} {
+			bean, _ := queries.GetOnePercentageBeanFromDB(id)
+			return bean
+		},
+		queries.CreatePercentageBeanHandler, queries.UpdatePercentageBeanHandler, queries.DeletePercentageBeanByIdHandler)
+
+	featureRuleWorkflow = change.NewEntityChangeWorkflow(xchange.FeatureRule, auth.FIRMWARE_ENTITY,
+		func(id string) interface{} {
+			return queries.GetOne(id)
+		},
+		queries.CreateFeatureRuleHandler, queries.UpdateFeatureRuleHandler, queries.DeleteOneFeatureRuleHandler)
+
+	featureWorkflow = change.NewEntityChangeWorkflow(xchange.Feature, auth.DCM_ENTITY,
+		func(id string) interface{} {
+			return feature.GetFeatureEntityById(id)
+		},
+		queries.PostFeatureEntityHandler, queries.PutFeatureEntityHandler, feature.DeleteFeatureByIdHandler)
+
+	dcmGenericRuleWorkflow = change.NewEntityChangeWorkflow(xchange.DCMGenericRule, auth.DCM_ENTITY,
+		func(id string) interface{} {
+			return dcm.GetDcmFormula(id)
+		},
+		dcm.CreateDcmFormulaHandler, dcm.UpdateDcmFormulaHandler, dcm.DeleteDcmFormulaByIdHandler)
+
+	namespacedListWorkflow = change.NewEntityChangeWorkflow(xchange.NamespacedList, auth.COMMON_ENTITY,
+		func(id string) interface{} {
+			return queries.GetNamespacedListById(id)
+		},
+		queries.CreateNamespacedListHandler, queries.UpdateNamespacedListHandler, queries.DeleteNamespacedListHandler)
+)
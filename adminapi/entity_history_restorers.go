@@ -0,0 +1,54 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package adminapi
+
+import (
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/adminapi/change"
+	"github.com/rdkcentral/xconfadmin/adminapi/dcm"
+	"github.com/rdkcentral/xconfadmin/adminapi/history"
+	"github.com/rdkcentral/xconfadmin/adminapi/queries"
+	"github.com/rdkcentral/xconfadmin/adminapi/setting"
+	"github.com/rdkcentral/xconfadmin/adminapi/telemetry"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+)
+
+// registerEntityRestorers maps the versioned tables to the permissions of their entities and to the handlers
+// restoring an old version, entities with a change workflow are restored through it
+func registerEntityRestorers() {
+	history.RegisterEntityRestorer(db.TABLE_FIRMWARE_RULE, auth.FIRMWARE_ENTITY, firmwareRuleWorkflow.CreateHandler, firmwareRuleWorkflow.UpdateHandler)
+	history.RegisterEntityRestorer(db.TABLE_FIRMWARE_RULE_TEMPLATE, auth.COMMON_ENTITY, queries.PostFirmwareRuleTemplateHandler, queries.PutFirmwareRuleTemplateHandler)
+	history.RegisterEntityRestorer(db.TABLE_FIRMWARE_CONFIG, auth.FIRMWARE_ENTITY, queries.PostFirmwareConfigHandler, queries.PutFirmwareConfigHandler)
+	history.RegisterEntityRestorer(db.TABLE_MODEL, auth.COMMON_ENTITY, queries.CreateModelHandler, queries.UpdateModelHandler)
+	history.RegisterEntityRestorer(db.TABLE_ENVIRONMENT, auth.COMMON_ENTITY, queries.CreateEnvironmentHandler, queries.UpdateEnvironmentHandler)
+	history.RegisterEntityRestorer(db.TABLE_GENERIC_NS_LIST, auth.COMMON_ENTITY, namespacedListWorkflow.CreateHandler, namespacedListWorkflow.UpdateHandler)
+	history.RegisterEntityRestorer(db.TABLE_FEATURE_CONTROL_RULE, auth.DCM_ENTITY, featureRuleWorkflow.CreateHandler, featureRuleWorkflow.UpdateHandler)
+	history.RegisterEntityRestorer(db.TABLE_XCONF_FEATURE, auth.DCM_ENTITY, featureWorkflow.CreateHandler, featureWorkflow.UpdateHandler)
+	history.RegisterEntityRestorer(db.TABLE_DCM_RULE, auth.DCM_ENTITY, dcmGenericRuleWorkflow.CreateHandler, dcmGenericRuleWorkflow.UpdateHandler)
+	history.RegisterEntityRestorer(db.TABLE_DEVICE_SETTINGS, auth.DCM_ENTITY, dcm.CreateDeviceSettingsHandler, dcm.UpdateDeviceSettingsHandler)
+	history.RegisterEntityRestorer(db.TABLE_VOD_SETTINGS, auth.DCM_ENTITY, dcm.CreateVodSettingsHandler, dcm.UpdateVodSettingsHandler)
+	history.RegisterEntityRestorer(db.TABLE_UPLOAD_REPOSITORY, auth.DCM_ENTITY, dcm.CreateLogRepoSettingsHandler, dcm.UpdateLogRepoSettingsHandler)
+	history.RegisterEntityRestorer(db.TABLE_LOG_UPLOAD_SETTINGS, auth.DCM_ENTITY, dcm.CreateLogUploadSettingsHandler, dcm.UpdateLogUploadSettingsHandler)
+	history.RegisterEntityRestorer(db.TABLE_SETTING_PROFILES, auth.DCM_ENTITY, setting.CreateSettingProfileHandler, setting.UpdateSettingProfilesHandler)
+	history.RegisterEntityRestorer(db.TABLE_SETTING_RULES, auth.DCM_ENTITY, setting.CreateSettingRuleHandler, setting.UpdateSettingRulesHandler)
+	history.RegisterEntityRestorer(db.TABLE_TELEMETRY_RULES, auth.TELEMETRY_ENTITY, telemetry.CreateTelemetryRuleHandler, telemetry.UpdateTelemetryRuleHandler)
+	history.RegisterEntityRestorer(db.TABLE_TELEMETRY_TWO_RULES, auth.TELEMETRY_ENTITY, telemetry.CreateTelemetryTwoRuleHandler, telemetry.UpdateTelemetryTwoRuleHandler)
+	history.RegisterEntityRestorer(db.TABLE_PERMANENT_TELEMETRY, auth.TELEMETRY_ENTITY, change.CreateTelemetryProfileHandler, change.UpdateTelemetryProfileHandler)
+	history.RegisterEntityRestorer(db.TABLE_TELEMETRY_TWO_PROFILES, auth.TELEMETRY_ENTITY, change.CreateTelemetryTwoProfileHandler, change.UpdateTelemetryTwoProfileHandler)
+}
@@ -0,0 +1,109 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package history
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+func GetEntityHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	versions, err := GetEntityVersions(r, vars[xcommon.TABLE_NAME], vars[xcommon.ROW_KEY])
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(versions, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func GetEntityVersionHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	version, err := strconv.Atoi(vars[xcommon.VERSION])
+	if err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.VERSION))
+		return
+	}
+	entityVersion, err := GetEntityVersion(r, vars[xcommon.TABLE_NAME], vars[xcommon.ROW_KEY], version)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(entityVersion, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+// DiffEntityVersionsHandler compares the versions given by the from and to parameters
+func DiffEntityVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	fromVersion, err := strconv.Atoi(r.URL.Query().Get(xcommon.FROM_VERSION))
+	if err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.FROM_VERSION))
+		return
+	}
+	toVersion, err := strconv.Atoi(r.URL.Query().Get(xcommon.TO_VERSION))
+	if err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.TO_VERSION))
+		return
+	}
+	vars := mux.Vars(r)
+	diff, err := DiffEntityVersions(r, vars[xcommon.TABLE_NAME], vars[xcommon.ROW_KEY], fromVersion, toVersion)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(diff, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+// RestoreEntityVersionHandler answers with the response of the regular handler which wrote the version,
+// e.g. 202 with the pending change when the entity goes through pending changes
+func RestoreEntityVersionHandler(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	version, err := strconv.Atoi(vars[xcommon.VERSION])
+	if err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("%v is invalid", xcommon.VERSION))
+		return
+	}
+	status, body, err := RestoreEntityVersion(r, vars[xcommon.TABLE_NAME], vars[xcommon.ROW_KEY], version)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, body, status, xhttp.ContextTypeHeader(r))
+}
@@ -0,0 +1,218 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/adminapi/change"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xshared "github.com/rdkcentral/xconfadmin/shared"
+	xhistory "github.com/rdkcentral/xconfadmin/shared/history"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwshared "github.com/rdkcentral/xconfwebconfig/shared"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// EntityRestorer writes an old version through the regular create and update handlers of the entity,
+// the versions are read and restored with the permissions of the entity
+type EntityRestorer struct {
+	authEntity    string
+	createHandler http.HandlerFunc
+	updateHandler http.HandlerFunc
+}
+
+var (
+	restorersMutex sync.RWMutex
+	restorers      = make(map[string]*EntityRestorer)
+)
+
+// RegisterEntityRestorer makes the table versioned, only the tables which can be restored are versioned
+func RegisterEntityRestorer(entityType string, authEntity string, createHandler http.HandlerFunc, updateHandler http.HandlerFunc) {
+	restorersMutex.Lock()
+	defer restorersMutex.Unlock()
+	restorers[entityType] = &EntityRestorer{
+		authEntity:    authEntity,
+		createHandler: createHandler,
+		updateHandler: updateHandler,
+	}
+}
+
+func getEntityRestorer(entityType string) *EntityRestorer {
+	restorersMutex.RLock()
+	defer restorersMutex.RUnlock()
+	return restorers[entityType]
+}
+
+func isVersioned(entityType string) bool {
+	return getEntityRestorer(entityType) != nil
+}
+
+// FieldChange is a field which differs between two versions of an entity
+type FieldChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+type EntityVersionDiff struct {
+	EntityType  string        `json:"entityType"`
+	EntityID    string        `json:"entityId"`
+	FromVersion int           `json:"fromVersion"`
+	ToVersion   int           `json:"toVersion"`
+	Changes     []FieldChange `json:"changes"`
+}
+
+// GetEntityVersions returns the versions of an entity the user may read, the versions of an entity of
+// another application type are not found
+func GetEntityVersions(r *http.Request, entityType string, entityId string) ([]*xhistory.EntityVersion, error) {
+	restorer := getEntityRestorer(entityType)
+	if restorer == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("%s is not versioned", entityType))
+	}
+	applicationType, err := auth.CanRead(r, restorer.authEntity)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := xhistory.GetEntityVersions(entityType, entityId)
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	if len(versions) == 0 || !isApplicationTypeOf(applicationType, versions) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("No history found for %s %s", entityType, entityId))
+	}
+	return versions, nil
+}
+
+// isApplicationTypeOf is true when the entity has no application type or the one the user has access to
+func isApplicationTypeOf(applicationType string, versions []*xhistory.EntityVersion) bool {
+	if applicationType == "" || xshared.ApplicationTypeEquals(applicationType, xwshared.ALL) {
+		return true
+	}
+	for _, version := range versions {
+		if entityApplicationType := getApplicationType(version.Entity); entityApplicationType != "" && !xshared.ApplicationTypeEquals(applicationType, entityApplicationType) {
+			return false
+		}
+	}
+	return true
+}
+
+func getApplicationType(entity json.RawMessage) string {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(entity, &fields); err != nil {
+		return ""
+	}
+	applicationType, _ := fields[xshared.APPLICATION_TYPE].(string)
+	return applicationType
+}
+
+func GetEntityVersion(r *http.Request, entityType string, entityId string, version int) (*xhistory.EntityVersion, error) {
+	versions, err := GetEntityVersions(r, entityType, entityId)
+	if err != nil {
+		return nil, err
+	}
+	return getVersion(versions, version)
+}
+
+func getVersion(versions []*xhistory.EntityVersion, version int) (*xhistory.EntityVersion, error) {
+	if version < 1 || version > len(versions) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Version %d does not exist", version))
+	}
+	return versions[version-1], nil
+}
+
+func DiffEntityVersions(r *http.Request, entityType string, entityId string, fromVersion int, toVersion int) (*EntityVersionDiff, error) {
+	versions, err := GetEntityVersions(r, entityType, entityId)
+	if err != nil {
+		return nil, err
+	}
+	from, err := getVersion(versions, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := getVersion(versions, toVersion)
+	if err != nil {
+		return nil, err
+	}
+	return &EntityVersionDiff{
+		EntityType:  entityType,
+		EntityID:    entityId,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Changes:     diffEntities(from.Entity, to.Entity),
+	}, nil
+}
+
+// RestoreEntityVersion writes an old version back through the regular handler of the entity, so it is
+// validated like any other write and goes through pending changes when they are enabled for the entity.
+// The status and the body of that handler are returned.
+func RestoreEntityVersion(r *http.Request, entityType string, entityId string, version int) (int, []byte, error) {
+	versions, err := GetEntityVersions(r, entityType, entityId)
+	if err != nil {
+		return 0, nil, err
+	}
+	entityVersion, err := getVersion(versions, version)
+	if err != nil {
+		return 0, nil, err
+	}
+	if entityVersion.IsDeleted() {
+		return 0, nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("Version %d of %s %s records a delete and can not be restored", version, entityType, entityId))
+	}
+	restorer := getEntityRestorer(entityType)
+	if _, err := auth.CanWrite(r, restorer.authEntity, getApplicationType(entityVersion.Entity)); err != nil {
+		return 0, nil, err
+	}
+
+	handler, method := restorer.updateHandler, http.MethodPut
+	if versions[len(versions)-1].IsDeleted() {
+		handler, method = restorer.createHandler, http.MethodPost
+	}
+	query := url.Values{}
+	if applicationType := getApplicationType(entityVersion.Entity); applicationType != "" {
+		query.Set(xshared.APPLICATION_TYPE, applicationType)
+	} else if r.URL.Query().Get(xshared.APPLICATION_TYPE) != "" {
+		query.Set(xshared.APPLICATION_TYPE, r.URL.Query().Get(xshared.APPLICATION_TYPE))
+	}
+
+	recorder := xhttp.ReplayHandler(r, handler, method, entityVersion.Entity, nil, query)
+	if recorder.Status() >= http.StatusMultipleChoices {
+		return 0, nil, xwcommon.NewRemoteErrorAS(recorder.Status(), fmt.Sprintf("Unable to restore version %d of %s %s: %s", version, entityType, entityId, string(recorder.Body())))
+	}
+	log.Infof("Version %d of %s %s restored by %s", version, entityType, entityId, auth.GetUserNameOrUnknown(r))
+	return recorder.Status(), recorder.Body(), nil
+}
+
+// diffEntities lists the fields which differ with the diff of the pending changes
+func diffEntities(from json.RawMessage, to json.RawMessage) []FieldChange {
+	changes := []FieldChange{}
+	for _, diff := range change.DiffEntities(from, to) {
+		changes = append(changes, FieldChange{
+			Field: diff.Field,
+			From:  diff.Base,
+			To:    diff.Current,
+		})
+	}
+	return changes
+}
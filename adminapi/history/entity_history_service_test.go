@@ -0,0 +1,83 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package history
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	xhistory "github.com/rdkcentral/xconfadmin/shared/history"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffEntities(t *testing.T) {
+	from := json.RawMessage(`{"id":"r1","name":"a","rule":{"operation":"IS","value":"1"},"ids":["x"]}`)
+	to := json.RawMessage(`{"id":"r1","name":"b","rule":{"operation":"IS","value":"2"},"ids":["x","y"],"active":true}`)
+
+	changes := diffEntities(from, to)
+	assert.Equal(t, []FieldChange{
+		{Field: "active", From: nil, To: true},
+		{Field: "ids", From: []interface{}{"x"}, To: []interface{}{"x", "y"}},
+		{Field: "name", From: "a", To: "b"},
+		{Field: "rule.value", From: "1", To: "2"},
+	}, changes)
+
+	// a deleted version differs in the whole entity
+	changes = diffEntities(from, nil)
+	assert.Equal(t, 1, len(changes))
+	assert.Equal(t, "", changes[0].Field)
+}
+
+func TestGetVersion(t *testing.T) {
+	versions := []*xhistory.EntityVersion{
+		{Version: 1, Entity: json.RawMessage(`{"id":"r1"}`)},
+		{Version: 2},
+	}
+	version, err := getVersion(versions, 2)
+	assert.Nil(t, err)
+	assert.True(t, version.IsDeleted())
+
+	for _, missing := range []int{0, 3} {
+		_, err = getVersion(versions, missing)
+		assert.Equal(t, http.StatusNotFound, xwcommon.GetXconfErrorStatusCode(err))
+	}
+}
+
+func TestIsApplicationTypeOf(t *testing.T) {
+	versions := []*xhistory.EntityVersion{
+		{Version: 1, Entity: json.RawMessage(`{"id":"r1","applicationType":"stb"}`)},
+		{Version: 2},
+	}
+	assert.True(t, isApplicationTypeOf("stb", versions))
+	assert.True(t, isApplicationTypeOf("", versions))
+	assert.False(t, isApplicationTypeOf("xhome", versions))
+	assert.True(t, isApplicationTypeOf("xhome", []*xhistory.EntityVersion{{Version: 1, Entity: json.RawMessage(`{"id":"m1"}`)}}))
+}
+
+func TestUnregisteredTablesAreNotVersioned(t *testing.T) {
+	assert.False(t, isVersioned("XconfRole"))
+	r, _ := http.NewRequest(http.MethodPost, "/xconfAdminService/history/XconfRole/x/1/restore", nil)
+	_, _, err := RestoreEntityVersion(r, "XconfRole", "x", 1)
+	assert.Equal(t, http.StatusNotFound, xwcommon.GetXconfErrorStatusCode(err))
+
+	RegisterEntityRestorer("VersionedTable", "CommonEntity", nil, nil)
+	assert.True(t, isVersioned("VersionedTable"))
+}
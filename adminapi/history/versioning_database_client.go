@@ -0,0 +1,169 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package history
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	xhistory "github.com/rdkcentral/xconfadmin/shared/history"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// a write and the changed key logged for it are paired within this delay
+const entityWriterTimeout = time.Minute
+
+// VersioningDatabaseClient appends a version of the versioned entities as they are written, whichever service
+// writes them. The user of a write is the one the cache logs with the changed key of the write.
+type VersioningDatabaseClient struct {
+	db.DatabaseClient
+	mutex   sync.Mutex
+	writers map[string]*entityWriter
+}
+
+// entityWriter pairs a version with the user of the changed key, whichever of them is written first
+type entityWriter struct {
+	writtenAt int64
+	userName  string
+	created   time.Time
+}
+
+func NewVersioningDatabaseClient(dbClient db.DatabaseClient) *VersioningDatabaseClient {
+	return &VersioningDatabaseClient{
+		DatabaseClient: dbClient,
+		writers:        make(map[string]*entityWriter),
+	}
+}
+
+// Unwrap returns the client the entities are written with
+func (c *VersioningDatabaseClient) Unwrap() db.DatabaseClient {
+	return c.DatabaseClient
+}
+
+func (c *VersioningDatabaseClient) SetXconfData(tableName string, rowKey string, value []byte, ttl int) error {
+	if err := c.DatabaseClient.SetXconfData(tableName, rowKey, value, ttl); err != nil {
+		return err
+	}
+	if tableName == db.TABLE_XCONF_CHANGED_KEYS {
+		c.recordWriter(value)
+	} else if json.Valid(value) {
+		c.recordVersion(tableName, rowKey, value)
+	}
+	return nil
+}
+
+func (c *VersioningDatabaseClient) SetXconfDataTwoKeys(tableName string, rowKey interface{}, key2FieldName string, key2 interface{}, value []byte, ttl int) error {
+	if err := c.DatabaseClient.SetXconfDataTwoKeys(tableName, rowKey, key2FieldName, key2, value, ttl); err != nil {
+		return err
+	}
+	if tableName == db.TABLE_XCONF_CHANGED_KEYS {
+		c.recordWriter(value)
+	}
+	return nil
+}
+
+// SetXconfCompressedData versions the entity read back from the compressed chunks which have just been written
+func (c *VersioningDatabaseClient) SetXconfCompressedData(tableName string, rowKey string, values [][]byte, ttl int) error {
+	if err := c.DatabaseClient.SetXconfCompressedData(tableName, rowKey, values, ttl); err != nil {
+		return err
+	}
+	if !isVersioned(tableName) {
+		return nil
+	}
+	entity, err := db.GetCompressingDataDao().GetOne(tableName, rowKey)
+	if err != nil {
+		log.Errorf("Failed to read %s %s to version it: %v", tableName, rowKey, err)
+		return nil
+	}
+	if value, err := json.Marshal(entity); err == nil {
+		c.recordVersion(tableName, rowKey, value)
+	}
+	return nil
+}
+
+func (c *VersioningDatabaseClient) DeleteXconfData(tableName string, rowKey string) error {
+	if err := c.DatabaseClient.DeleteXconfData(tableName, rowKey); err != nil {
+		return err
+	}
+	c.recordVersion(tableName, rowKey, nil)
+	return nil
+}
+
+func (c *VersioningDatabaseClient) recordVersion(tableName string, rowKey string, entity []byte) {
+	if !isVersioned(tableName) {
+		return
+	}
+	now := time.Now()
+	writtenAt := now.UnixMicro()
+	c.mutex.Lock()
+	key := tableName + "|" + rowKey
+	writer := c.getWriter(key, now)
+	userName := writer.userName
+	if userName != "" {
+		delete(c.writers, key)
+	} else {
+		writer.writtenAt = writtenAt
+	}
+	c.mutex.Unlock()
+
+	if err := xhistory.AddEntityVersion(tableName, rowKey, writtenAt, userName, entity); err != nil {
+		log.Errorf("Failed to record version of %s %s: %v", tableName, rowKey, err)
+	}
+}
+
+func (c *VersioningDatabaseClient) recordWriter(value []byte) {
+	var changedData db.ChangedData
+	if err := json.Unmarshal(value, &changedData); err != nil || changedData.UserName == "" || !isVersioned(changedData.CfName) {
+		return
+	}
+	c.mutex.Lock()
+	key := changedData.CfName + "|" + changedData.ChangedKey
+	writer := c.getWriter(key, time.Now())
+	writtenAt := writer.writtenAt
+	if writtenAt > 0 {
+		delete(c.writers, key)
+	} else {
+		writer.userName = changedData.UserName
+	}
+	c.mutex.Unlock()
+
+	if writtenAt > 0 {
+		if err := xhistory.SetEntityVersionUser(changedData.CfName, changedData.ChangedKey, writtenAt, changedData.UserName); err != nil {
+			log.Errorf("Failed to record user of %s %s: %v", changedData.CfName, changedData.ChangedKey, err)
+		}
+	}
+}
+
+// getWriter returns the pending writer of the key and drops the writers which were never paired, the mutex is held
+func (c *VersioningDatabaseClient) getWriter(key string, now time.Time) *entityWriter {
+	for k, writer := range c.writers {
+		if now.Sub(writer.created) > entityWriterTimeout {
+			delete(c.writers, k)
+		}
+	}
+	writer, ok := c.writers[key]
+	if !ok {
+		writer = &entityWriter{created: now}
+		c.writers[key] = writer
+	}
+	return writer
+}
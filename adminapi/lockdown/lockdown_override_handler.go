@@ -0,0 +1,84 @@
+/**
+ * Copyright 2023 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lockdown
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	ccommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+
+	"github.com/gorilla/mux"
+)
+
+const ACTIVE = "active"
+
+func GetLockdownOverridesHandler(w http.ResponseWriter, r *http.Request) {
+	if !auth.HasReadPermissionForTool(r) {
+		xhttp.WriteAdminErrorResponse(w, http.StatusForbidden, "No read permission: tools")
+		return
+	}
+	activeOnly := r.URL.Query().Get(ACTIVE) == "true"
+	writeLockdownResponse(w, r, GetLockdownOverrides(activeOnly, time.Now()), http.StatusOK)
+}
+
+func GetLockdownOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	if !auth.HasReadPermissionForTool(r) {
+		xhttp.WriteAdminErrorResponse(w, http.StatusForbidden, "No read permission: tools")
+		return
+	}
+	override, err := GetLockdownOverride(mux.Vars(r)[ccommon.ID])
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeLockdownResponse(w, r, override, http.StatusOK)
+}
+
+func CreateLockdownOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	xw, ok := w.(*xhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "responsewriter cast error")
+		return
+	}
+	request := LockdownOverrideRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	override, err := CreateLockdownOverride(r, &request, time.Now())
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xw.SetAuditData("lockdown_override", override.ID)
+	writeLockdownResponse(w, r, override, http.StatusCreated)
+}
+
+func RevokeLockdownOverrideHandler(w http.ResponseWriter, r *http.Request) {
+	override, err := RevokeLockdownOverride(r, mux.Vars(r)[ccommon.ID], time.Now())
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeLockdownResponse(w, r, override, http.StatusOK)
+}
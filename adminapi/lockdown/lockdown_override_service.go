@@ -0,0 +1,143 @@
+/**
+ * Copyright 2023 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package lockdown
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	ccommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// LockdownOverrideRequest asks for a break-glass override, the duration defaults to the longest allowed
+type LockdownOverrideRequest struct {
+	Modules           []string `json:"modules"`
+	Justification     string   `json:"justification"`
+	DurationInMinutes int32    `json:"durationInMinutes,omitempty"`
+}
+
+func CreateLockdownOverride(r *http.Request, request *LockdownOverrideRequest, now time.Time) (*xlockdown.LockdownOverride, error) {
+	if !auth.HasBreakGlassPermission(r) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No permission: "+auth.LOCKDOWN_BREAK_GLASS)
+	}
+	userName := r.Header.Get(xhttp.AUTH_SUBJECT)
+	if userName == "" {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusForbidden, "A lockdown override requires a known user")
+	}
+	if util.IsBlank(request.Justification) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "justification is required")
+	}
+	if len(request.Modules) == 0 {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "modules are required")
+	}
+	modules := []string{}
+	for _, module := range request.Modules {
+		module = strings.ToLower(strings.TrimSpace(module))
+		if !util.Contains(xlockdown.LockdownModules, module) {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("module must be one of: %s", strings.Join(xlockdown.LockdownModules, ", ")))
+		}
+		modules = append(modules, module)
+	}
+	duration := request.DurationInMinutes
+	if duration == 0 {
+		duration = ccommon.LockdownOverrideMaxDurationInMins
+	}
+	if duration < 0 || duration > ccommon.LockdownOverrideMaxDurationInMins {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("durationInMinutes must be from 1 to %d", ccommon.LockdownOverrideMaxDurationInMins))
+	}
+
+	override := &xlockdown.LockdownOverride{
+		ID:            uuid.New().String(),
+		Modules:       modules,
+		Justification: strings.TrimSpace(request.Justification),
+		RequestedBy:   userName,
+		Created:       now.UnixMilli(),
+		Expires:       now.Add(time.Duration(duration) * time.Minute).UnixMilli(),
+	}
+	if err := xlockdown.SetOneLockdownOverride(override); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	log.Warnf("Lockdown override %s of modules %s for %d minutes requested by %s: %s", override.ID, strings.Join(modules, ","), duration, userName, override.Justification)
+	return override, nil
+}
+
+// GetLockdownOverride returns a copy of the override with its writes
+func GetLockdownOverride(id string) (*xlockdown.LockdownOverride, error) {
+	override := xlockdown.GetOneLockdownOverride(id)
+	if override == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Lockdown override %s is not found", id))
+	}
+	withWrites := *override
+	writes, err := xlockdown.GetLockdownOverrideWrites(id)
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	withWrites.Writes = writes
+	return &withWrites, nil
+}
+
+// GetLockdownOverrides returns all overrides, or only the ones active at the time
+func GetLockdownOverrides(activeOnly bool, now time.Time) []*xlockdown.LockdownOverride {
+	overrides := xlockdown.GetLockdownOverrideList()
+	if !activeOnly {
+		return overrides
+	}
+	active := []*xlockdown.LockdownOverride{}
+	for _, override := range overrides {
+		if override.IsActive(now) {
+			active = append(active, override)
+		}
+	}
+	return active
+}
+
+// RevokeLockdownOverride ends the override before it expires, by its user or a tools writer
+func RevokeLockdownOverride(r *http.Request, id string, now time.Time) (*xlockdown.LockdownOverride, error) {
+	override := xlockdown.GetOneLockdownOverride(id)
+	if override == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Lockdown override %s is not found", id))
+	}
+	userName := auth.GetUserNameOrUnknown(r)
+	if userName != override.RequestedBy && !auth.HasWritePermissionForTool(r) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusForbidden, "No write permission: tools")
+	}
+	if !override.IsActive(now) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Lockdown override %s is not active", id))
+	}
+	// the override may be shared with the cache, the copy is saved
+	revoked := *override
+	revoked.Writes = nil
+	revoked.Revoked = now.UnixMilli()
+	revoked.RevokedBy = userName
+	if err := xlockdown.SetOneLockdownOverride(&revoked); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	log.Warnf("Lockdown override %s revoked by %s", id, userName)
+	return &revoked, nil
+}
@@ -0,0 +1,55 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lockdown
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	ccommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateLockdownOverrideValidates(t *testing.T) {
+	satOn, maxDuration := ccommon.SatOn, ccommon.LockdownOverrideMaxDurationInMins
+	ccommon.SatOn, ccommon.LockdownOverrideMaxDurationInMins = false, 60
+	t.Cleanup(func() {
+		ccommon.SatOn, ccommon.LockdownOverrideMaxDurationInMins = satOn, maxDuration
+	})
+
+	r, _ := http.NewRequest(http.MethodPost, "/xconfAdminService/lockdownOverrides", nil)
+	_, err := CreateLockdownOverride(r, &LockdownOverrideRequest{Modules: []string{"firmware"}, Justification: "incident 42"}, time.Now())
+	assert.Equal(t, http.StatusForbidden, xwcommon.GetXconfErrorStatusCode(err))
+
+	r.Header.Set(xhttp.AUTH_SUBJECT, "alice")
+	invalid := []*LockdownOverrideRequest{
+		{Modules: []string{"firmware"}, Justification: "  "},
+		{Justification: "incident 42"},
+		{Modules: []string{"unknown"}, Justification: "incident 42"},
+		{Modules: []string{"firmware"}, Justification: "incident 42", DurationInMinutes: 61},
+		{Modules: []string{"firmware"}, Justification: "incident 42", DurationInMinutes: -1},
+	}
+	for _, request := range invalid {
+		_, err := CreateLockdownOverride(r, request, time.Now())
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err), request)
+	}
+}
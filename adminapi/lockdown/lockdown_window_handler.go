@@ -0,0 +1,122 @@
+/**
+ * Copyright 2023 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lockdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	ccommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+
+	"github.com/gorilla/mux"
+)
+
+const DAYS = "days"
+
+func GetLockdownWindowsHandler(w http.ResponseWriter, r *http.Request) {
+	// No permission check needed
+	writeLockdownResponse(w, r, xlockdown.GetLockdownWindowList(), http.StatusOK)
+}
+
+func GetLockdownWindowHandler(w http.ResponseWriter, r *http.Request) {
+	// No permission check needed
+	window, err := GetLockdownWindow(mux.Vars(r)[ccommon.ID])
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeLockdownResponse(w, r, window, http.StatusOK)
+}
+
+// GetLockdownPeriodsHandler returns the active lockdown periods and the upcoming ones within days, 7 by default
+func GetLockdownPeriodsHandler(w http.ResponseWriter, r *http.Request) {
+	// No permission check needed
+	days := DefaultUpcomingDays
+	if value := r.URL.Query().Get(DAYS); value != "" {
+		var err error
+		if days, err = strconv.Atoi(value); err != nil {
+			xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("days %s is not a number", value))
+			return
+		}
+	}
+	schedule, err := GetLockdownSchedule(time.Now(), days)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeLockdownResponse(w, r, schedule, http.StatusOK)
+}
+
+func CreateLockdownWindowHandler(w http.ResponseWriter, r *http.Request) {
+	saveLockdownWindowHandler(w, r, CreateLockdownWindow, http.StatusCreated)
+}
+
+func UpdateLockdownWindowHandler(w http.ResponseWriter, r *http.Request) {
+	saveLockdownWindowHandler(w, r, UpdateLockdownWindow, http.StatusOK)
+}
+
+func saveLockdownWindowHandler(w http.ResponseWriter, r *http.Request, save func(r *http.Request, window *xlockdown.LockdownWindow) (*xlockdown.LockdownWindow, error), status int) {
+	if !auth.HasWritePermissionForTool(r) {
+		xhttp.WriteAdminErrorResponse(w, http.StatusForbidden, "No write permission: tools")
+		return
+	}
+	xw, ok := w.(*xhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "responsewriter cast error")
+		return
+	}
+	window := xlockdown.LockdownWindow{}
+	if err := json.Unmarshal([]byte(xw.Body()), &window); err != nil {
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	saved, err := save(r, &window)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	writeLockdownResponse(w, r, saved, status)
+}
+
+func DeleteLockdownWindowHandler(w http.ResponseWriter, r *http.Request) {
+	if !auth.HasWritePermissionForTool(r) {
+		xhttp.WriteAdminErrorResponse(w, http.StatusForbidden, "No write permission: tools")
+		return
+	}
+	if err := DeleteLockdownWindow(r, mux.Vars(r)[ccommon.ID]); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, http.StatusNoContent, nil)
+}
+
+func writeLockdownResponse(w http.ResponseWriter, r *http.Request, obj interface{}, status int) {
+	res, err := xhttp.ReturnJsonResponse(obj, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, status, res)
+}
@@ -0,0 +1,144 @@
+/**
+ * Copyright 2023 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package lockdown
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+)
+
+const (
+	DefaultUpcomingDays = 7
+	MaxUpcomingDays     = 366
+)
+
+// LockdownSchedule is what is locked now and the periods starting until the end of the upcoming days
+type LockdownSchedule struct {
+	Now           time.Time                   `json:"now"`
+	LockedModules []string                    `json:"lockedModules"`
+	Active        []*xlockdown.LockdownPeriod `json:"active"`
+	Upcoming      []*xlockdown.LockdownPeriod `json:"upcoming"`
+}
+
+func GetLockdownWindow(id string) (*xlockdown.LockdownWindow, error) {
+	window := xlockdown.GetOneLockdownWindow(id)
+	if window == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Lockdown window %s is not found", id))
+	}
+	return window, nil
+}
+
+func CreateLockdownWindow(r *http.Request, window *xlockdown.LockdownWindow) (*xlockdown.LockdownWindow, error) {
+	if err := window.Validate(); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, err.Error())
+	}
+	if xlockdown.GetOneLockdownWindow(window.ID) != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("Lockdown window %s already exists", window.ID))
+	}
+	return saveLockdownWindow(r, window)
+}
+
+func UpdateLockdownWindow(r *http.Request, window *xlockdown.LockdownWindow) (*xlockdown.LockdownWindow, error) {
+	if err := window.Validate(); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, err.Error())
+	}
+	existing := xlockdown.GetOneLockdownWindow(window.ID)
+	if existing == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Lockdown window %s is not found", window.ID))
+	}
+	if err := checkActiveLockdownWindow(r, existing, time.Now()); err != nil {
+		return nil, err
+	}
+	return saveLockdownWindow(r, window)
+}
+
+// checkActiveLockdownWindow refuses to change a window which locks its modules now, unless the user breaks the glass
+func checkActiveLockdownWindow(r *http.Request, window *xlockdown.LockdownWindow, now time.Time) error {
+	if window.Enabled && window.IsActive(now) && !auth.HasBreakGlassPermission(r) {
+		return xwcommon.NewRemoteErrorAS(http.StatusLocked, fmt.Sprintf("Lockdown window %s is active, it can only be changed with break-glass permission", window.ID))
+	}
+	return nil
+}
+
+func saveLockdownWindow(r *http.Request, window *xlockdown.LockdownWindow) (*xlockdown.LockdownWindow, error) {
+	for i, module := range window.Modules {
+		window.Modules[i] = strings.ToLower(module)
+	}
+	window.UpdatedBy = auth.GetUserNameOrUnknown(r)
+	window.Updated = xwutil.GetTimestamp(time.Now().UTC())
+	if err := xlockdown.SetOneLockdownWindow(window); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return window, nil
+}
+
+func DeleteLockdownWindow(r *http.Request, id string) error {
+	existing := xlockdown.GetOneLockdownWindow(id)
+	if existing == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Lockdown window %s is not found", id))
+	}
+	if err := checkActiveLockdownWindow(r, existing, time.Now()); err != nil {
+		return err
+	}
+	if err := xlockdown.DeleteOneLockdownWindow(id); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
+
+// GetLockdownSchedule evaluates the enabled windows, the lockdown settings included
+func GetLockdownSchedule(now time.Time, days int) (*LockdownSchedule, error) {
+	if days < 1 || days > MaxUpcomingDays {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("days must be from 1 to %d", MaxUpcomingDays))
+	}
+	return NewLockdownSchedule(xlockdown.GetEnabledLockdownWindows(), now, days), nil
+}
+
+func NewLockdownSchedule(windows []*xlockdown.LockdownWindow, now time.Time, days int) *LockdownSchedule {
+	schedule := &LockdownSchedule{
+		Now:           now,
+		LockedModules: []string{},
+		Active:        xlockdown.GetActivePeriods(windows, now),
+		Upcoming:      []*xlockdown.LockdownPeriod{},
+	}
+	for _, period := range xlockdown.GetPeriods(windows, now, now.AddDate(0, 0, days)) {
+		if period.Start.After(now) {
+			schedule.Upcoming = append(schedule.Upcoming, period)
+		}
+	}
+	for _, module := range xlockdown.LockdownModules[1:] {
+		for _, period := range schedule.Active {
+			window := xlockdown.LockdownWindow{Modules: period.Modules}
+			if window.LocksModule(module) {
+				schedule.LockedModules = append(schedule.LockedModules, module)
+				break
+			}
+		}
+	}
+	sort.Strings(schedule.LockedModules)
+	return schedule
+}
@@ -0,0 +1,86 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package lockdown
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/common"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewLockdownSchedule(t *testing.T) {
+	location, _ := time.LoadLocation("America/New_York")
+	windows := []*xlockdown.LockdownWindow{
+		{ID: "daily", Enabled: true, Timezone: "America/New_York", Modules: []string{"dcm", "rfc"}, StartTime: "12:00", EndTime: "13:00"},
+		{ID: "holidays", Enabled: true, Timezone: "America/New_York", Modules: []string{"all"},
+			DateRanges: []xlockdown.DateRange{{Start: "2026-12-24 00:00", End: "2027-01-02 00:00"}}},
+	}
+
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, location)
+	schedule := NewLockdownSchedule(windows, now, 2)
+	assert.Equal(t, []string{"dcm", "rfc"}, schedule.LockedModules)
+	assert.Len(t, schedule.Active, 1)
+	// the active period is not upcoming
+	assert.Len(t, schedule.Upcoming, 2)
+	assert.Equal(t, time.Date(2026, 10, 17, 12, 0, 0, 0, location), schedule.Upcoming[0].Start)
+
+	now = time.Date(2026, 12, 25, 12, 30, 0, 0, location)
+	schedule = NewLockdownSchedule(windows, now, 1)
+	assert.Equal(t, []string{"changes", "common", "dcm", "firmware", "rfc", "telemetry", "tools"}, schedule.LockedModules)
+}
+
+func TestGetLockdownScheduleDays(t *testing.T) {
+	_, err := GetLockdownSchedule(time.Now(), 0)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+	_, err = GetLockdownSchedule(time.Now(), MaxUpcomingDays+1)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
+
+func TestCreateLockdownWindowValidates(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, "/xconfAdminService/lockdownWindows", nil)
+	_, err := CreateLockdownWindow(r, &xlockdown.LockdownWindow{ID: "no-schedule", Timezone: "UTC", Modules: []string{"all"}})
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
+
+func TestActiveLockdownWindowNeedsBreakGlass(t *testing.T) {
+	satOn, getPermissions := common.SatOn, auth.GetPermissionsFunc
+	defer func() { common.SatOn, auth.GetPermissionsFunc = satOn, getPermissions }()
+	common.SatOn = true
+	permissions := []string{auth.WRITE_TOOLS}
+	auth.GetPermissionsFunc = func(r *http.Request) []string { return permissions }
+
+	r, _ := http.NewRequest(http.MethodDelete, "/xconfAdminService/lockdownWindows/daily", nil)
+	now := time.Now()
+	window := &xlockdown.LockdownWindow{ID: "daily", Enabled: true, Timezone: "UTC", Modules: []string{"dcm"}, StartTime: "00:00", EndTime: "00:00"}
+	err := checkActiveLockdownWindow(r, window, now)
+	assert.Equal(t, http.StatusLocked, xwcommon.GetXconfErrorStatusCode(err))
+
+	window.Enabled = false
+	assert.Nil(t, checkActiveLockdownWindow(r, window, now))
+
+	window.Enabled = true
+	permissions = append(permissions, auth.LOCKDOWN_BREAK_GLASS)
+	assert.Nil(t, checkActiveLockdownWindow(r, window, now))
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package queries
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	AUDIT_FROM        = "from"
+	AUDIT_TO          = "to"
+	AUDIT_USER_NAME   = "userName"
+	AUDIT_CF_NAME     = "cfName"
+	AUDIT_OPERATION   = "operation"
+	AUDIT_CHANGED_KEY = "changedKey"
+	AUDIT_CURSOR      = "cursor"
+	AUDIT_LIMIT       = "limit"
+	AUDIT_FORMAT      = "format"
+
+	AuditFormatNdjson = "ndjson"
+	AuditFormatCsv    = "csv"
+)
+
+// GetAuditLogHandler searches the changed keys between from and to, the current UTC day by default.
+// Pages are returned as json, format=ndjson or format=csv exports every matching entry from the cursor on.
+func GetAuditLogHandler(w http.ResponseWriter, r *http.Request) {
+	if _, err := auth.CanRead(r, auth.TOOL_ENTITY); err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	filter, err := newAuditFilter(r, time.Now().UTC())
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	var cursor *AuditCursor
+	if value := r.URL.Query().Get(AUDIT_CURSOR); value != "" {
+		if cursor, err = DecodeAuditCursor(value); err != nil {
+			xhttp.AdminError(w, err)
+			return
+		}
+	}
+
+	switch format := r.URL.Query().Get(AUDIT_FORMAT); format {
+	case "", "json":
+	case AuditFormatNdjson, AuditFormatCsv:
+		exportAuditLog(w, filter, cursor, format)
+		return
+	default:
+		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("format %s is not supported, json, ndjson or csv are expected", format))
+		return
+	}
+
+	limit := defaultAuditLimit
+	if value := r.URL.Query().Get(AUDIT_LIMIT); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 1 || limit > maxAuditLimit {
+			xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("limit must be a number between 1 and %d", maxAuditLimit))
+			return
+		}
+	}
+	page, err := GetAuditPage(filter, cursor, limit)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	res, err := xhttp.ReturnJsonResponse(page, r)
+	if err != nil {
+		xhttp.AdminError(w, err)
+		return
+	}
+	xwhttp.WriteResponseBytes(w, res, http.StatusOK, xhttp.ContextTypeHeader(r))
+}
+
+func newAuditFilter(r *http.Request, now time.Time) (*AuditFilter, error) {
+	query := r.URL.Query()
+	year, month, day := now.Date()
+	filter := &AuditFilter{
+		From:       time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+		To:         now,
+		UserName:   query.Get(AUDIT_USER_NAME),
+		CfName:     query.Get(AUDIT_CF_NAME),
+		Operation:  query.Get(AUDIT_OPERATION),
+		ChangedKey: query.Get(AUDIT_CHANGED_KEY),
+	}
+	var err error
+	if value := query.Get(AUDIT_FROM); value != "" {
+		if filter.From, err = ParseAuditTime(value); err != nil {
+			return nil, err
+		}
+	}
+	if value := query.Get(AUDIT_TO); value != "" {
+		if filter.To, err = ParseAuditTime(value); err != nil {
+			return nil, err
+		}
+	}
+	if !filter.From.Before(filter.To) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "from must be before to")
+	}
+	if maxRange := time.Duration(xcommon.AuditMaxRangeInDays) * 24 * time.Hour; maxRange > 0 && filter.To.Sub(filter.From) > maxRange {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("from and to must be at most %d days apart", xcommon.AuditMaxRangeInDays))
+	}
+	if retention := time.Duration(xcommon.ChangedKeysRetentionInDays) * 24 * time.Hour; retention > 0 && filter.From.Before(now.Add(-retention)) {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("from must be within the last %d days, older changes are not kept", xcommon.ChangedKeysRetentionInDays))
+	}
+	return filter, nil
+}
+
+// exportAuditLog streams the entries, so an export of a long range is not held in memory
+func exportAuditLog(w http.ResponseWriter, filter *AuditFilter, cursor *AuditCursor, format string) {
+	fileName := fmt.Sprintf("audit_%s_%s.%s", filter.From.Format("20060102T150405Z"), filter.To.Format("20060102T150405Z"), format)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s", fileName))
+
+	var write func(entry *AuditEntry) error
+	var flush func() error
+	if format == AuditFormatCsv {
+		w.Header().Set("Content-Type", "text/csv")
+		w.WriteHeader(http.StatusOK)
+		csvWriter := csv.NewWriter(w)
+		csvWriter.Write([]string{"timestamp", "changedKey", "operationType", "cfName", "userName", "lockdownOverrideId"})
+		write = func(entry *AuditEntry) error {
+			return csvWriter.Write([]string{
+				time.UnixMilli(entry.Timestamp).UTC().Format(time.RFC3339),
+				entry.ChangedKey,
+				string(entry.Operation),
+				entry.CfName,
+				entry.UserName,
+				entry.LockdownOverrideId,
+			})
+		}
+		flush = func() error {
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+		encoder := json.NewEncoder(w)
+		write = func(entry *AuditEntry) error {
+			return encoder.Encode(entry)
+		}
+		flush = func() error {
+			return nil
+		}
+	}
+
+	var writeErr error
+	_, err := SearchAuditLog(filter, cursor, func(entry *AuditEntry) bool {
+		writeErr = write(entry)
+		return writeErr == nil
+	})
+	if err == nil {
+		err = writeErr
+	}
+	if err == nil {
+		err = flush()
+	}
+	if err != nil {
+		// the status has been sent already, the export ends early
+		log.Errorf("Audit log export failed: %v", err)
+	}
+}
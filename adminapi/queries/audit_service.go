@@ -0,0 +1,228 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package queries
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/rdkcentral/xconfwebconfig/db"
+)
+
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+// auditTableModules are the lockdown modules of the tables, a write to another table is only tagged
+// with an override of all modules
+var auditTableModules = map[string]string{
+	db.TABLE_FIRMWARE_RULE:                       "firmware",
+	db.TABLE_FIRMWARE_CONFIG:                     "firmware",
+	db.TABLE_FIRMWARE_RULE_TEMPLATE:              "common",
+	db.TABLE_MODEL:                               "common",
+	db.TABLE_ENVIRONMENT:                         "common",
+	db.TABLE_GENERIC_NS_LIST:                     "common",
+	db.TABLE_FEATURE_CONTROL_RULE:                "rfc",
+	db.TABLE_XCONF_FEATURE:                       "rfc",
+	db.TABLE_DCM_RULE:                            "dcm",
+	db.TABLE_DEVICE_SETTINGS:                     "dcm",
+	db.TABLE_VOD_SETTINGS:                        "dcm",
+	db.TABLE_UPLOAD_REPOSITORY:                   "dcm",
+	db.TABLE_LOG_UPLOAD_SETTINGS:                 "dcm",
+	db.TABLE_SETTING_PROFILES:                    "dcm",
+	db.TABLE_SETTING_RULES:                       "dcm",
+	db.TABLE_TELEMETRY_RULES:                     "telemetry",
+	db.TABLE_TELEMETRY_TWO_RULES:                 "telemetry",
+	db.TABLE_TELEMETRY_TWO_PROFILES:              "telemetry",
+	db.TABLE_PERMANENT_TELEMETRY:                 "telemetry",
+	db.TABLE_XCONF_CHANGE:                        "changes",
+	db.TABLE_XCONF_APPROVED_CHANGE:               "changes",
+	db.TABLE_XCONF_TELEMETRY_TWO_CHANGE:          "changes",
+	db.TABLE_XCONF_APPROVED_TELEMETRY_TWO_CHANGE: "changes",
+	db.TABLE_APP_SETTINGS:                        "tools",
+}
+
+// AuditEntry is one write logged in the changed keys. The changed keys carry no time of their own,
+// the timestamp is the start of the interval of the log the write was read from.
+// A write of a user holding a break-glass lockdown override of its module during that interval is tagged with the override.
+type AuditEntry struct {
+	Timestamp          int64            `json:"timestamp"`
+	ChangedKey         string           `json:"changedKey"`
+	Operation          db.OperationType `json:"operationType"`
+	CfName             string           `json:"cfName"`
+	UserName           string           `json:"userName"`
+	LockdownOverrideId string           `json:"lockdownOverrideId,omitempty"`
+}
+
+type AuditFilter struct {
+	From       time.Time
+	To         time.Time
+	UserName   string
+	CfName     string
+	Operation  string
+	ChangedKey string
+}
+
+func (f *AuditFilter) Matches(entry *AuditEntry) bool {
+	if f.UserName != "" && !strings.EqualFold(f.UserName, entry.UserName) {
+		return false
+	}
+	if f.CfName != "" && !strings.EqualFold(f.CfName, entry.CfName) {
+		return false
+	}
+	if f.Operation != "" && !strings.EqualFold(f.Operation, string(entry.Operation)) {
+		return false
+	}
+	if f.ChangedKey != "" && f.ChangedKey != entry.ChangedKey {
+		return false
+	}
+	return true
+}
+
+// AuditCursor points at an entry of the log: the interval and the position of the entry in it
+type AuditCursor struct {
+	IntervalStart int64
+	Offset        int
+}
+
+func (c *AuditCursor) Encode() string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", c.IntervalStart, c.Offset)))
+}
+
+func DecodeAuditCursor(cursor string) (*AuditCursor, error) {
+	invalid := xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "cursor is invalid")
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, invalid
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 {
+		return nil, invalid
+	}
+	intervalStart, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, invalid
+	}
+	offset, err := strconv.Atoi(parts[1])
+	if err != nil || offset < 0 {
+		return nil, invalid
+	}
+	return &AuditCursor{IntervalStart: intervalStart, Offset: offset}, nil
+}
+
+type AuditPage struct {
+	Entries    []*AuditEntry `json:"entries"`
+	NextCursor string        `json:"nextCursor,omitempty"`
+}
+
+// ParseAuditTime accepts epoch milliseconds or RFC3339
+func ParseAuditTime(value string) (time.Time, error) {
+	if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("%s is not a valid time, epoch milliseconds or RFC3339 are expected", value))
+	}
+	return t.UTC(), nil
+}
+
+// auditIntervalStep is the resolution of the audit log, the window of the changed keys when it is known
+func auditIntervalStep() time.Duration {
+	if windowSize := db.GetCacheManager().GetChangedKeysTimeWindowSize(); windowSize > 0 {
+		return time.Duration(windowSize) * time.Millisecond
+	}
+	return time.Hour
+}
+
+// SearchAuditLog reads the changed keys of the time range from the cursor on, and calls visit for every
+// matching entry until visit returns false. The cursor of the first entry not visited is returned,
+// it is empty when the whole range has been read.
+func SearchAuditLog(filter *AuditFilter, cursor *AuditCursor, visit func(entry *AuditEntry) bool) (string, error) {
+	return searchAuditLog(filter, cursor, auditIntervalStep(), func(start time.Time, end time.Time) ([]interface{}, error) {
+		return db.GetCacheManager().SyncChanges(start, end, false)
+	}, xlockdown.GetLockdownOverrideList(), visit)
+}
+
+func searchAuditLog(filter *AuditFilter, cursor *AuditCursor, step time.Duration, syncChanges func(start time.Time, end time.Time) ([]interface{}, error), overrides []*xlockdown.LockdownOverride, visit func(entry *AuditEntry) bool) (string, error) {
+	start := filter.From
+	offset := 0
+	if cursor != nil {
+		start = time.UnixMilli(cursor.IntervalStart).UTC()
+		offset = cursor.Offset
+	}
+	for start.Before(filter.To) {
+		end := start.Add(step)
+		if end.After(filter.To) {
+			end = filter.To
+		}
+		changedList, err := syncChanges(start, end)
+		if err != nil {
+			return "", xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		for i := offset; i < len(changedList); i++ {
+			changedData, ok := changedList[i].(*db.ChangedData)
+			if !ok {
+				continue
+			}
+			entry := &AuditEntry{
+				Timestamp:  start.UnixMilli(),
+				ChangedKey: changedData.ChangedKey,
+				Operation:  changedData.Operation,
+				CfName:     changedData.CfName,
+				UserName:   changedData.UserName,
+			}
+			if override := xlockdown.FindLockdownOverride(overrides, entry.UserName, auditTableModules[entry.CfName], start, end); override != nil {
+				entry.LockdownOverrideId = override.ID
+			}
+			if !filter.Matches(entry) {
+				continue
+			}
+			if !visit(entry) {
+				next := AuditCursor{IntervalStart: start.UnixMilli(), Offset: i}
+				return next.Encode(), nil
+			}
+		}
+		start = end
+		offset = 0
+	}
+	return "", nil
+}
+
+// GetAuditPage returns up to limit entries, with the cursor of the next page when there are more
+func GetAuditPage(filter *AuditFilter, cursor *AuditCursor, limit int) (*AuditPage, error) {
+	page := &AuditPage{Entries: []*AuditEntry{}}
+	nextCursor, err := SearchAuditLog(filter, cursor, func(entry *AuditEntry) bool {
+		if len(page.Entries) == limit {
+			return false
+		}
+		page.Entries = append(page.Entries, entry)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	page.NextCursor = nextCursor
+	return page, nil
+}
@@ -0,0 +1,143 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+package queries
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/rdkcentral/xconfwebconfig/db"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAuditLogFiltersAndResumesFromCursor(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	changes := map[int64][]interface{}{
+		from.UnixMilli(): {
+			&db.ChangedData{CfName: "GenericXconfNamedList", ChangedKey: "list1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+			&db.ChangedData{CfName: "FirmwareRule", ChangedKey: "rule1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+		},
+		from.Add(time.Hour).UnixMilli(): {
+			&db.ChangedData{CfName: "GenericXconfNamedList", ChangedKey: "list1", Operation: db.UPDATE_OPERATION, UserName: "bob"},
+			&db.ChangedData{CfName: "GenericXconfNamedList", ChangedKey: "list1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+		},
+	}
+	syncChanges := func(start time.Time, end time.Time) ([]interface{}, error) {
+		return changes[start.UnixMilli()], nil
+	}
+	filter := &AuditFilter{From: from, To: from.Add(2 * time.Hour), CfName: "genericxconfnamedlist", ChangedKey: "list1"}
+
+	entries := []*AuditEntry{}
+	next, err := searchAuditLog(filter, nil, time.Hour, syncChanges, nil, func(entry *AuditEntry) bool {
+		if len(entries) == 2 {
+			return false
+		}
+		entries = append(entries, entry)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, []string{entries[0].UserName, entries[1].UserName})
+	assert.Equal(t, from.Add(time.Hour).UnixMilli(), entries[1].Timestamp)
+	assert.NotEmpty(t, next)
+
+	cursor, err := DecodeAuditCursor(next)
+	assert.Nil(t, err)
+	assert.Equal(t, AuditCursor{IntervalStart: from.Add(time.Hour).UnixMilli(), Offset: 1}, *cursor)
+
+	entries = []*AuditEntry{}
+	next, err = searchAuditLog(filter, cursor, time.Hour, syncChanges, nil, func(entry *AuditEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(entries))
+	assert.Equal(t, "alice", entries[0].UserName)
+	assert.Empty(t, next)
+}
+
+func TestSearchAuditLogTagsLockdownOverrides(t *testing.T) {
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	changes := map[int64][]interface{}{
+		from.UnixMilli(): {
+			&db.ChangedData{CfName: "FirmwareRule", ChangedKey: "rule1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+			&db.ChangedData{CfName: "FirmwareRule", ChangedKey: "rule2", Operation: db.UPDATE_OPERATION, UserName: "bob"},
+			&db.ChangedData{CfName: db.TABLE_DCM_RULE, ChangedKey: "dcm1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+		},
+		from.Add(time.Hour).UnixMilli(): {
+			&db.ChangedData{CfName: "FirmwareRule", ChangedKey: "rule1", Operation: db.UPDATE_OPERATION, UserName: "alice"},
+		},
+	}
+	syncChanges := func(start time.Time, end time.Time) ([]interface{}, error) {
+		return changes[start.UnixMilli()], nil
+	}
+	overrides := []*xlockdown.LockdownOverride{
+		{ID: "override1", Modules: []string{"firmware"}, RequestedBy: "alice", Created: from.Add(10 * time.Minute).UnixMilli(), Expires: from.Add(40 * time.Minute).UnixMilli()},
+	}
+	filter := &AuditFilter{From: from, To: from.Add(2 * time.Hour)}
+
+	entries := []*AuditEntry{}
+	_, err := searchAuditLog(filter, nil, time.Hour, syncChanges, overrides, func(entry *AuditEntry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, len(entries))
+	assert.Equal(t, "override1", entries[0].LockdownOverrideId)
+	// another user, a module the override does not cover and a write after the override expired are not tagged
+	assert.Empty(t, entries[1].LockdownOverrideId)
+	assert.Empty(t, entries[2].LockdownOverrideId)
+	assert.Empty(t, entries[3].LockdownOverrideId)
+}
+
+func TestParseAuditTimeAndCursor(t *testing.T) {
+	parsed, err := ParseAuditTime("2025-03-01T10:00:00Z")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), parsed)
+
+	parsed, err = ParseAuditTime("1740823200000")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC), parsed)
+
+	_, err = ParseAuditTime("last month")
+	assert.NotNil(t, err)
+
+	_, err = DecodeAuditCursor("not a cursor")
+	assert.NotNil(t, err)
+}
+
+func TestNewAuditFilterLimitsTheRange(t *testing.T) {
+	xcommon.AuditMaxRangeInDays = 7
+	xcommon.ChangedKeysRetentionInDays = 30
+	now := time.Date(2025, 3, 20, 12, 0, 0, 0, time.UTC)
+
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/audit?from=2025-03-14T00:00:00Z&to=2025-03-20T00:00:00Z", nil)
+	filter, err := newAuditFilter(r, now)
+	assert.Nil(t, err)
+	assert.Equal(t, 6*24*time.Hour, filter.To.Sub(filter.From))
+
+	for _, query := range []string{"from=2025-03-01T00:00:00Z&to=2025-03-20T00:00:00Z", "from=2025-02-01T00:00:00Z&to=2025-02-02T00:00:00Z"} {
+		r = httptest.NewRequest(http.MethodGet, "/xconfAdminService/audit?"+query, nil)
+		_, err = newAuditFilter(r, now)
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err), query)
+	}
+}
@@ -20,6 +20,7 @@ package adminapi
 import (
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rdkcentral/xconfadmin/adminapi/auth"
@@ -28,12 +29,14 @@ import (
 	ipmacrule "github.com/rdkcentral/xconfadmin/adminapi/configuration/ip-macrule"
 	"github.com/rdkcentral/xconfadmin/adminapi/dcm"
 	"github.com/rdkcentral/xconfadmin/adminapi/firmware"
+	"github.com/rdkcentral/xconfadmin/adminapi/history"
 	"github.com/rdkcentral/xconfadmin/adminapi/lockdown"
 	"github.com/rdkcentral/xconfadmin/adminapi/queries"
 	"github.com/rdkcentral/xconfadmin/adminapi/rfc/feature"
 	"github.com/rdkcentral/xconfadmin/adminapi/setting"
 	"github.com/rdkcentral/xconfadmin/adminapi/telemetry"
 	"github.com/rdkcentral/xconfadmin/adminapi/xcrp"
+	"github.com/rdkcentral/xconfadmin/common"
 
 	xhttp "github.com/rdkcentral/xconfadmin/http"
 	"github.com/rdkcentral/xconfadmin/taggingapi"
@@ -51,9 +54,11 @@ func XconfSetup(server *xhttp.WebconfigServer, r *mux.Router) {
 	dataapi.WebServerInjection(server.XW_XconfServer, xc)
 	auth.WebServerInjection(server)
 	dataapi.RegisterTables()
+	registerTables()
 
 	db.GetCacheManager() // Initialize cache manager
 	initDB()
+	xhttp.StartJwksCacheRefresher(server.JwksCacheConfig.RefreshInterval)
 
 	if server.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.dataservice_enabled") {
 		dataapi.XconfSetup(server.XW_XconfServer, r)
@@ -61,6 +66,11 @@ func XconfSetup(server *xhttp.WebconfigServer, r *mux.Router) {
 
 	if server.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.adminservice_enabled") {
 		RouteXconfAdminserviceApis(server, r)
+		change.StartScheduledChangeApplier(time.Duration(common.ScheduledChangeIntervalInSecs) * time.Second)
+		registerEntityRestorers()
+		if server.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.entity_history_enabled") {
+			db.SetDatabaseClient(history.NewVersioningDatabaseClient(db.GetDatabaseClient()))
+		}
 	}
 
 	if server.XW_XconfServer.ServerConfig.GetBoolean("xconfwebconfig.xconf.enable_tagging_service_admin") {
@@ -89,6 +99,30 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	authInfoPath.HandleFunc("", auth.AuthInfoHandler).Methods("GET").Name("Auth-Uncategorized")
 	paths = append(paths, authInfoPath)
 
+	apiTokenPath := r.PathPrefix("/xconfAdminService/auth/apiTokens").Subrouter()
+	apiTokenPath.HandleFunc("", auth.CreateApiTokenHandler).Methods("POST").Name("Auth-Uncategorized")
+	apiTokenPath.HandleFunc("", auth.GetApiTokensHandler).Methods("GET").Name("Auth-Uncategorized")
+	apiTokenPath.HandleFunc("/{id}", auth.RevokeApiTokenHandler).Methods("DELETE").Name("Auth-Uncategorized")
+	paths = append(paths, apiTokenPath)
+
+	permissionsPath := r.PathPrefix("/xconfAdminService/auth/permissions").Subrouter()
+	permissionsPath.HandleFunc("", auth.GetEffectivePermissionsHandler).Methods("GET").Name("Auth-Uncategorized")
+	paths = append(paths, permissionsPath)
+
+	rolePath := r.PathPrefix("/xconfAdminService/roles").Subrouter()
+	rolePath.HandleFunc("", auth.GetRolesHandler).Methods("GET").Name("Auth-Uncategorized")
+	rolePath.HandleFunc("", auth.CreateRoleHandler).Methods("POST").Name("Auth-Uncategorized")
+	rolePath.HandleFunc("", auth.UpdateRoleHandler).Methods("PUT").Name("Auth-Uncategorized")
+	rolePath.HandleFunc("/{id}", auth.GetRoleHandler).Methods("GET").Name("Auth-Uncategorized")
+	rolePath.HandleFunc("/{id}", auth.DeleteRoleHandler).Methods("DELETE").Name("Auth-Uncategorized")
+	paths = append(paths, rolePath)
+
+	roleBindingPath := r.PathPrefix("/xconfAdminService/roleBindings").Subrouter()
+	roleBindingPath.HandleFunc("", auth.GetRoleBindingsHandler).Methods("GET").Name("Auth-Uncategorized")
+	roleBindingPath.HandleFunc("", auth.SetRoleBindingHandler).Methods("PUT").Name("Auth-Uncategorized")
+	roleBindingPath.HandleFunc("/{id}", auth.DeleteRoleBindingHandler).Methods("DELETE").Name("Auth-Uncategorized")
+	paths = append(paths, roleBindingPath)
+
 	basicAuthpath := r.PathPrefix("/xconfAdminService/auth/basic").Subrouter()
 	basicAuthpath.HandleFunc("", auth.BasicAuthHandler).Methods("POST").Name("Auth-Basic")
 	paths = append(paths, authInfoPath)
@@ -198,22 +232,22 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	updatePath.HandleFunc("/rules/ips", queries.UpdateIpRule).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/rules/macs", queries.SaveMACRule).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/rules/envModels", queries.UpdateEnvModelRuleHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/ipAddressGroups", queries.CreateIpAddressGroupHandler).Methods("POST", "PUT").Name("Updates")
-	updatePath.HandleFunc("/ipAddressGroups/{listId}/addData", queries.AddDataIpAddressGroupHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/ipAddressGroups/{listId}/removeData", queries.RemoveDataIpAddressGroupHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/v2/ipAddressGroups", queries.CreateIpAddressGroupHandlerV2).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/v2/ipAddressGroups", queries.UpdateIpAddressGroupHandlerV2).Methods("PUT").Name("Updates")
-	updatePath.HandleFunc("/nsLists", queries.SaveMacListHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/nsLists/{listId}/addData", queries.AddDataMacListHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/nsLists/{listId}/removeData", queries.RemoveDataMacListHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/v2/nsLists", queries.CreateMacListHandlerV2).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/v2/nsLists", queries.UpdateMacListHandlerV2).Methods("PUT").Name("Updates")
-	updatePath.HandleFunc("/v2/nsLists/{listId}/addData", queries.AddDataMacListHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/v2/nsLists/{listId}/removeData", queries.RemoveDataMacListHandler).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/ipAddressGroups", namespacedListWorkflow.GuardHandler(queries.CreateIpAddressGroupHandler)).Methods("POST", "PUT").Name("Updates")
+	updatePath.HandleFunc("/ipAddressGroups/{listId}/addData", namespacedListWorkflow.GuardHandler(queries.AddDataIpAddressGroupHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/ipAddressGroups/{listId}/removeData", namespacedListWorkflow.GuardHandler(queries.RemoveDataIpAddressGroupHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/v2/ipAddressGroups", namespacedListWorkflow.GuardHandler(queries.CreateIpAddressGroupHandlerV2)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/v2/ipAddressGroups", namespacedListWorkflow.GuardHandler(queries.UpdateIpAddressGroupHandlerV2)).Methods("PUT").Name("Updates")
+	updatePath.HandleFunc("/nsLists", namespacedListWorkflow.GuardHandler(queries.SaveMacListHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/nsLists/{listId}/addData", namespacedListWorkflow.GuardHandler(queries.AddDataMacListHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/nsLists/{listId}/removeData", namespacedListWorkflow.GuardHandler(queries.RemoveDataMacListHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/v2/nsLists", namespacedListWorkflow.GuardHandler(queries.CreateMacListHandlerV2)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/v2/nsLists", namespacedListWorkflow.GuardHandler(queries.UpdateMacListHandlerV2)).Methods("PUT").Name("Updates")
+	updatePath.HandleFunc("/v2/nsLists/{listId}/addData", namespacedListWorkflow.GuardHandler(queries.AddDataMacListHandler)).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/v2/nsLists/{listId}/removeData", namespacedListWorkflow.GuardHandler(queries.RemoveDataMacListHandler)).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/firmwares", queries.PostFirmwareConfigHandler).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/firmwares", queries.PutFirmwareConfigHandler).Methods("PUT").Name("Updates")
-	updatePath.HandleFunc("/percentageBean", queries.CreatePercentageBeanHandler).Methods("POST").Name("Updates")
-	updatePath.HandleFunc("/percentageBean", queries.UpdatePercentageBeanHandler).Methods("PUT").Name("Updates")
+	updatePath.HandleFunc("/percentageBean", percentageBeanWorkflow.CreateHandler).Methods("POST").Name("Updates")
+	updatePath.HandleFunc("/percentageBean", percentageBeanWorkflow.UpdateHandler).Methods("PUT").Name("Updates")
 	updatePath.HandleFunc("/logFile", queries.CreateLogFile).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/logUploadSettings/{timezone}/{scheduleTimezone}", queries.NotImplementedHandler).Methods("POST").Name("Updates")
 	updatePath.HandleFunc("/deviceSettings", queries.NotImplementedHandler).Methods("POST").Name("Updates")
@@ -235,12 +269,12 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	deletePath.HandleFunc("/rules/ips/{name}", queries.DeleteIpRule).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/rules/macs/{name}", queries.DeleteMACRule).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/rules/envModels/{name}", queries.DeleteEnvModelRuleBeanHandler).Methods("DELETE").Name("Delete")
-	deletePath.HandleFunc("/ipAddressGroups/{id}", queries.DeleteIpAddressGroupHandler).Methods("DELETE").Name("Delete")
-	deletePath.HandleFunc("/v2/ipAddressGroups/{id}", queries.DeleteIpAddressGroupHandlerV2).Methods("DELETE").Name("Delete")
-	deletePath.HandleFunc("/nsLists/{id}", queries.DeleteMacListHandler).Methods("DELETE").Name("Delete")
-	deletePath.HandleFunc("/v2/nsLists/{id}", queries.DeleteMacListHandlerV2).Methods("DELETE").Name("Delete")
+	deletePath.HandleFunc("/ipAddressGroups/{id}", namespacedListWorkflow.GuardHandler(queries.DeleteIpAddressGroupHandler)).Methods("DELETE").Name("Delete")
+	deletePath.HandleFunc("/v2/ipAddressGroups/{id}", namespacedListWorkflow.GuardHandler(queries.DeleteIpAddressGroupHandlerV2)).Methods("DELETE").Name("Delete")
+	deletePath.HandleFunc("/nsLists/{id}", namespacedListWorkflow.GuardHandler(queries.DeleteMacListHandler)).Methods("DELETE").Name("Delete")
+	deletePath.HandleFunc("/v2/nsLists/{id}", namespacedListWorkflow.GuardHandler(queries.DeleteMacListHandlerV2)).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/firmwares/{id}", queries.DeleteFirmwareConfigHandler).Methods("DELETE").Name("Delete")
-	deletePath.HandleFunc("/percentageBean/{id}", queries.DeletePercentageBeanHandler).Methods("DELETE").Name("Delete")
+	deletePath.HandleFunc("/percentageBean/{id}", percentageBeanWorkflow.DeleteHandler).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/filters/ips/{name}", queries.DeleteIpsFilterHandler).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/filters/time/{name}", queries.DeleteTimeFilterHandler).Methods("DELETE").Name("Delete")
 	deletePath.HandleFunc("/filters/locations/{name}", queries.DeleteLocationFilterHandler).Methods("DELETE").Name("Delete")
@@ -277,17 +311,17 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	// genericnamespacedlist
 	nameSpacedListPath := r.PathPrefix("/xconfAdminService/genericnamespacedlist").Subrouter()
 	nameSpacedListPath.HandleFunc("", queries.GetNamespacedListsHandler).Methods("GET").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("", queries.CreateNamespacedListHandler).Methods("POST").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("", queries.UpdateNamespacedListHandler).Methods("PUT").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("", namespacedListWorkflow.CreateHandler).Methods("POST").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("", namespacedListWorkflow.UpdateHandler).Methods("PUT").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/ids", queries.GetNamespacedListIdsHandler).Methods("GET").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/ipAddressGroups", queries.GetIpAddressGroupsHandler).Methods("GET").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/page", queries.NotImplementedHandler).Methods("GET").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/filtered", queries.PostNamespacedListFilteredHandler).Methods("POST").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("/entities", queries.PostNamespacedListEntitiesHandler).Methods("POST").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("/entities", queries.PutNamespacedListEntitiesHandler).Methods("PUT").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("/entities", namespacedListWorkflow.GuardHandler(queries.PostNamespacedListEntitiesHandler)).Methods("POST").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("/entities", namespacedListWorkflow.GuardHandler(queries.PutNamespacedListEntitiesHandler)).Methods("PUT").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/{id}", queries.GetNamespacedListHandler).Methods("GET").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("/{id}", queries.RenameNamespacedListHandler).Methods("PUT").Name("NameSpaced-Lists")
-	nameSpacedListPath.HandleFunc("/{id}", queries.DeleteNamespacedListHandler).Methods("DELETE").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("/{id}", namespacedListWorkflow.GuardHandler(queries.RenameNamespacedListHandler)).Methods("PUT").Name("NameSpaced-Lists")
+	nameSpacedListPath.HandleFunc("/{id}", namespacedListWorkflow.DeleteHandler).Methods("DELETE").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/{type}/ids", queries.GetNamespacedListIdsByTypeHandler).Methods("GET").Name("NameSpaced-Lists")
 	nameSpacedListPath.HandleFunc("/all/{type}", queries.GetNamespacedListsByTypeHandler).Methods("GET").Name("NameSpaced-Lists")
 	paths = append(paths, nameSpacedListPath)
@@ -295,21 +329,21 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	// firmwarerule
 	firmwareRulePath := r.PathPrefix("/xconfAdminService/firmwarerule").Subrouter()
 	firmwareRulePath.HandleFunc("/filtered", queries.GetFirmwareRuleFilteredHandler).Methods("GET").Name("Firmware-Rules")
-	firmwareRulePath.HandleFunc("/importAll", queries.PostFirmwareRuleImportAllHandler).Methods("POST").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("/importAll", firmwareRuleWorkflow.GuardHandler(queries.PostFirmwareRuleImportAllHandler)).Methods("POST").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/{type}/names", queries.GetFirmwareRuleByTypeNamesHandler).Methods("GET").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/byTemplate/{templateId}/names", queries.GetFirmwareRuleByTemplateByTemplateIdNamesHandler).Methods("GET").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/export/byType", queries.GetFirmwareRuleExportByTypeHandler).Methods("GET").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/export/allTypes", queries.GetFirmwareRuleExportAllTypesHandler).Methods("GET").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/testpage", firmware.GetFirmwareTestPageHandler).Methods("GET").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("", queries.GetFirmwareRuleHandler).Methods("GET").Name("Firmware-Rules")
-	firmwareRulePath.HandleFunc("", queries.PostFirmwareRuleHandler).Methods("POST").Name("Firmware-Rules")
-	firmwareRulePath.HandleFunc("", queries.PutFirmwareRuleHandler).Methods("PUT").Name("Firmware-Rules")
-	firmwareRulePath.HandleFunc("/entities", queries.PostFirmwareRuleEntitiesHandler).Methods("POST").Name("Firmware-Rules")
-	firmwareRulePath.HandleFunc("/entities", queries.PutFirmwareRuleEntitiesHandler).Methods("PUT").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("", firmwareRuleWorkflow.CreateHandler).Methods("POST").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("", firmwareRuleWorkflow.UpdateHandler).Methods("PUT").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("/entities", firmwareRuleWorkflow.GuardHandler(queries.PostFirmwareRuleEntitiesHandler)).Methods("POST").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("/entities", firmwareRuleWorkflow.GuardHandler(queries.PutFirmwareRuleEntitiesHandler)).Methods("PUT").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/filtered", queries.PostFirmwareRuleFilteredHandler).Methods("POST").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/page", queries.NotImplementedHandler).Methods("GET").Name("Firmware-Rules")
 	// url with var has to be placed last otherwise, it gets confused with url with defined paths
-	firmwareRulePath.HandleFunc("/{id}", queries.DeleteFirmwareRuleByIdHandler).Methods("DELETE").Name("Firmware-Rules")
+	firmwareRulePath.HandleFunc("/{id}", firmwareRuleWorkflow.DeleteHandler).Methods("DELETE").Name("Firmware-Rules")
 	firmwareRulePath.HandleFunc("/{id}", queries.GetFirmwareRuleByIdHandler).Methods("GET").Name("Firmware-Rules")
 	paths = append(paths, firmwareRulePath)
 
@@ -357,16 +391,16 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	// percentfilter/percentageBean
 	percentageBeanPath := r.PathPrefix("/xconfAdminService/percentfilter/percentageBean").Subrouter()
 	percentageBeanPath.HandleFunc("", queries.GetPercentageBeanAllHandler).Methods("GET").Name("Firmware-PercentFilter")
-	percentageBeanPath.HandleFunc("", queries.CreatePercentageBeanHandler).Methods("POST").Name("Firmware-PercentFilter")
-	percentageBeanPath.HandleFunc("", queries.UpdatePercentageBeanHandler).Methods("PUT").Name("Firmware-PercentFilter")
+	percentageBeanPath.HandleFunc("", percentageBeanWorkflow.CreateHandler).Methods("POST").Name("Firmware-PercentFilter")
+	percentageBeanPath.HandleFunc("", percentageBeanWorkflow.UpdateHandler).Methods("PUT").Name("Firmware-PercentFilter")
 	percentageBeanPath.HandleFunc("/page", queries.NotImplementedHandler).Methods("GET").Name("Firmware-PercentFilter")
 	percentageBeanPath.HandleFunc("/filtered", queries.PostPercentageBeanFilteredWithParamsHandler).Methods("POST").Name("Firmware-PercentFilter")
-	percentageBeanPath.HandleFunc("/entities", queries.PostPercentageBeanEntitiesHandler).Methods("POST").Name("Firmware-PercentFilter")
-	percentageBeanPath.HandleFunc("/entities", queries.PutPercentageBeanEntitiesHandler).Methods("PUT").Name("Firmware-PercentFilter")
+	percentageBeanPath.HandleFunc("/entities", percentageBeanWorkflow.GuardHandler(queries.PostPercentageBeanEntitiesHandler)).Methods("POST").Name("Firmware-PercentFilter")
+	percentageBeanPath.HandleFunc("/entities", percentageBeanWorkflow.GuardHandler(queries.PutPercentageBeanEntitiesHandler)).Methods("PUT").Name("Firmware-PercentFilter")
 	percentageBeanPath.HandleFunc("/allAsRules", queries.GetAllPercentageBeanAsRule).Methods("GET").Name("Firmware-PercentFilter")
 	percentageBeanPath.HandleFunc("/asRule/{id}", queries.GetPercentageBeanAsRuleById).Methods("GET").Name("Firmware-PercentFilter")
 	percentageBeanPath.HandleFunc("/{id}", queries.GetPercentageBeanByIdHandler).Methods("GET").Name("Firmware-PercentFilter")
-	percentageBeanPath.HandleFunc("/{id}", queries.DeletePercentageBeanByIdHandler).Methods("DELETE").Name("Firmware-PercentFilter")
+	percentageBeanPath.HandleFunc("/{id}", percentageBeanWorkflow.DeleteHandler).Methods("DELETE").Name("Firmware-PercentFilter")
 	paths = append(paths, percentageBeanPath)
 
 	// percentfilter
@@ -446,11 +480,11 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	featureRulePath.HandleFunc("", queries.GetFeatureRulesHandler).Methods("GET").Name("RFC-FeatureRules")
 	featureRulePath.HandleFunc("/filtered", queries.GetFeatureRulesFiltered).Methods("GET").Name("RFC-FeatureRules")
 	featureRulePath.HandleFunc("/{id}", queries.GetFeatureRuleOne).Methods("GET").Name("RFC-FeatureRules")
-	featureRulePath.HandleFunc("", queries.CreateFeatureRuleHandler).Methods("POST").Name("RFC-FeatureRules")
-	featureRulePath.HandleFunc("", queries.UpdateFeatureRuleHandler).Methods("PUT").Name("RFC-FeatureRules")
-	featureRulePath.HandleFunc("/importAll", queries.ImportAllFeatureRulesHandler).Methods("POST").Name("RFC-FeatureRules")
-	featureRulePath.HandleFunc("/{id}", queries.DeleteOneFeatureRuleHandler).Methods("DELETE").Name("RFC-FeatureRules")
-	featureRulePath.HandleFunc("/", queries.DeleteOneFeatureRuleHandler).Methods("DELETE").Name("RFC-FeatureRules")
+	featureRulePath.HandleFunc("", featureRuleWorkflow.CreateHandler).Methods("POST").Name("RFC-FeatureRules")
+	featureRulePath.HandleFunc("", featureRuleWorkflow.UpdateHandler).Methods("PUT").Name("RFC-FeatureRules")
+	featureRulePath.HandleFunc("/importAll", featureRuleWorkflow.GuardHandler(queries.ImportAllFeatureRulesHandler)).Methods("POST").Name("RFC-FeatureRules")
+	featureRulePath.HandleFunc("/{id}", featureRuleWorkflow.DeleteHandler).Methods("DELETE").Name("RFC-FeatureRules")
+	featureRulePath.HandleFunc("/", featureRuleWorkflow.GuardHandler(queries.DeleteOneFeatureRuleHandler)).Methods("DELETE").Name("RFC-FeatureRules")
 	paths = append(paths, featureRulePath)
 
 	// feature
@@ -458,38 +492,38 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	featurePath.HandleFunc("", queries.GetFeatureEntityHandler).Methods("GET").Name("RFC-Feature")
 	featurePath.HandleFunc("/filtered", queries.GetFeatureEntityFilteredHandler).Methods("GET").Name("RFC-Feature")
 	featurePath.HandleFunc("/{id}", queries.GetFeatureEntityByIdHandler).Methods("GET").Name("RFC-Feature")
-	featurePath.HandleFunc("/{id}", feature.DeleteFeatureByIdHandler).Methods("DELETE").Name("RFC-Feature")
-	featurePath.HandleFunc("", queries.PostFeatureEntityHandler).Methods("POST").Name("RFC-Feature")
-	featurePath.HandleFunc("", queries.PutFeatureEntityHandler).Methods("PUT").Name("RFC-Feature")
-	featurePath.HandleFunc("/importAll", queries.PostFeatureEntityImportAllHandler).Methods("POST").Name("RFC-Feature")
+	featurePath.HandleFunc("/{id}", featureWorkflow.DeleteHandler).Methods("DELETE").Name("RFC-Feature")
+	featurePath.HandleFunc("", featureWorkflow.CreateHandler).Methods("POST").Name("RFC-Feature")
+	featurePath.HandleFunc("", featureWorkflow.UpdateHandler).Methods("PUT").Name("RFC-Feature")
+	featurePath.HandleFunc("/importAll", featureWorkflow.GuardHandler(queries.PostFeatureEntityImportAllHandler)).Methods("POST").Name("RFC-Feature")
 	paths = append(paths, featurePath)
 
 	// rfc
 	rfcFeaturerulePath := r.PathPrefix("/xconfAdminService/rfc").Subrouter()
-	rfcFeaturerulePath.HandleFunc("/featurerule/{id}/priority/{newPriority}", queries.ChangeFeatureRulePrioritiesHandler).Methods("POST").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule/{id}/priority/{newPriority}", featureRuleWorkflow.GuardHandler(queries.ChangeFeatureRulePrioritiesHandler)).Methods("POST").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule/size", queries.GetFeatureRulesSizeHandler).Methods("GET").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule/allowedNumberOfFeatures", queries.GetAllowedNumberOfFeaturesHandler).Methods("GET").Name("RFC-FeatureRules")
-	rfcFeaturerulePath.HandleFunc("/featurerule", queries.CreateFeatureRuleHandler).Methods("POST").Name("RFC-FeatureRules")
-	rfcFeaturerulePath.HandleFunc("/featurerule/entities", queries.CreateFeatureRulesHandler).Methods("POST").Name("RFC-FeatureRules")
-	rfcFeaturerulePath.HandleFunc("/featurerule", queries.UpdateFeatureRuleHandler).Methods("PUT").Name("RFC-FeatureRules")
-	rfcFeaturerulePath.HandleFunc("/featurerule/entities", queries.UpdateFeatureRulesHandler).Methods("PUT").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule", featureRuleWorkflow.CreateHandler).Methods("POST").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule/entities", featureRuleWorkflow.GuardHandler(queries.CreateFeatureRulesHandler)).Methods("POST").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule", featureRuleWorkflow.UpdateHandler).Methods("PUT").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule/entities", featureRuleWorkflow.GuardHandler(queries.UpdateFeatureRulesHandler)).Methods("PUT").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule", queries.GetFeatureRulesExportHandler).Methods("GET").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule/page", queries.NotImplementedHandler).Methods("GET").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule/{id}", queries.GetFeatureRuleOneExport).Methods("GET").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/featurerule/filtered", queries.GetFeatureRulesFilteredWithPage).Methods("POST").Name("RFC-FeatureRules")
-	rfcFeaturerulePath.HandleFunc("/featurerule/{id}", queries.DeleteOneFeatureRuleHandler).Methods("DELETE").Name("RFC-FeatureRules")
+	rfcFeaturerulePath.HandleFunc("/featurerule/{id}", featureRuleWorkflow.DeleteHandler).Methods("DELETE").Name("RFC-FeatureRules")
 	rfcFeaturerulePath.HandleFunc("/test", queries.FeatureRuleTestPageHandler).Methods("POST").Name("RFC-FeatureRules")
 	paths = append(paths, rfcFeaturerulePath)
 
 	// rfc/feature
 	rfcFeaturePath := r.PathPrefix("/xconfAdminService/rfc/feature").Subrouter()
-	rfcFeaturePath.HandleFunc("", feature.PostFeatureHandler).Methods("POST").Name("RFC-Feature")
-	rfcFeaturePath.HandleFunc("", feature.PutFeatureHandler).Methods("PUT").Name("RFC-Feature")
-	rfcFeaturePath.HandleFunc("/entities", feature.PostFeatureEntitiesHandler).Methods("POST").Name("RFC-Feature")
-	rfcFeaturePath.HandleFunc("/entities", feature.PutFeatureEntitiesHandler).Methods("PUT").Name("RFC-Feature")
+	rfcFeaturePath.HandleFunc("", featureWorkflow.GuardHandler(feature.PostFeatureHandler)).Methods("POST").Name("RFC-Feature")
+	rfcFeaturePath.HandleFunc("", featureWorkflow.GuardHandler(feature.PutFeatureHandler)).Methods("PUT").Name("RFC-Feature")
+	rfcFeaturePath.HandleFunc("/entities", featureWorkflow.GuardHandler(feature.PostFeatureEntitiesHandler)).Methods("POST").Name("RFC-Feature")
+	rfcFeaturePath.HandleFunc("/entities", featureWorkflow.GuardHandler(feature.PutFeatureEntitiesHandler)).Methods("PUT").Name("RFC-Feature")
 	rfcFeaturePath.HandleFunc("", feature.GetFeaturesHandler).Methods("GET").Name("RFC-Feature")
 	rfcFeaturePath.HandleFunc("/{id}", feature.GetFeatureByIdHandler).Methods("GET").Name("RFC-Feature")
-	rfcFeaturePath.HandleFunc("/{id}", feature.DeleteFeatureByIdHandler).Methods("DELETE").Name("RFC-Feature")
+	rfcFeaturePath.HandleFunc("/{id}", featureWorkflow.DeleteHandler).Methods("DELETE").Name("RFC-Feature")
 	rfcFeaturePath.HandleFunc("/filtered", feature.GetFeaturesFilteredHandler).Methods("POST").Name("RFC-Feature")
 	rfcFeaturePath.HandleFunc("/byIdList", feature.GetFeaturesByIdListHandler).Methods("POST").Name("RFC-Feature")
 	paths = append(paths, rfcFeaturePath)
@@ -497,22 +531,22 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	// dcm/formula
 	dcmFormulaPath := r.PathPrefix("/xconfAdminService/dcm/formula").Subrouter()
 	dcmFormulaPath.HandleFunc("", dcm.GetDcmFormulaHandler).Methods("GET").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("", dcm.CreateDcmFormulaHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("", dcm.UpdateDcmFormulaHandler).Methods("PUT").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("", dcmGenericRuleWorkflow.CreateHandler).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("", dcmGenericRuleWorkflow.UpdateHandler).Methods("PUT").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/page", queries.NotImplementedHandler).Methods("GET").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/entities", dcm.PostDcmFormulaListHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/entities", dcm.PutDcmFormulaListHandler).Methods("PUT").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/list", dcm.PostDcmFormulaListHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/list", dcm.PutDcmFormulaListHandler).Methods("PUT").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/entities", dcmGenericRuleWorkflow.GuardHandler(dcm.PostDcmFormulaListHandler)).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/entities", dcmGenericRuleWorkflow.GuardHandler(dcm.PutDcmFormulaListHandler)).Methods("PUT").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/list", dcmGenericRuleWorkflow.GuardHandler(dcm.PostDcmFormulaListHandler)).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/list", dcmGenericRuleWorkflow.GuardHandler(dcm.PutDcmFormulaListHandler)).Methods("PUT").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/settingsAvailability", dcm.DcmFormulaSettingsAvailabilitygHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/import/{overwrite}", dcm.ImportDcmFormulaWithOverwriteHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/import", dcm.ImportDcmFormulasHandler).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/import/{overwrite}", dcmGenericRuleWorkflow.GuardHandler(dcm.ImportDcmFormulaWithOverwriteHandler)).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/import", dcmGenericRuleWorkflow.GuardHandler(dcm.ImportDcmFormulasHandler)).Methods("POST").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/formulasAvailability", dcm.DcmFormulasAvailabilitygHandler).Methods("POST").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/size", dcm.GetDcmFormulaSizeHandler).Methods("GET").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/names", dcm.GetDcmFormulaNamesHandler).Methods("GET").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/filtered", dcm.PostDcmFormulaFilteredWithParamsHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/{id}/priority/{newPriority}", dcm.DcmFormulaChangePriorityHandler).Methods("POST").Name("DCM-Formulas")
-	dcmFormulaPath.HandleFunc("/{id}", dcm.DeleteDcmFormulaByIdHandler).Methods("DELETE").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/{id}/priority/{newPriority}", dcmGenericRuleWorkflow.GuardHandler(dcm.DcmFormulaChangePriorityHandler)).Methods("POST").Name("DCM-Formulas")
+	dcmFormulaPath.HandleFunc("/{id}", dcmGenericRuleWorkflow.DeleteHandler).Methods("DELETE").Name("DCM-Formulas")
 	dcmFormulaPath.HandleFunc("/{id}", dcm.GetDcmFormulaByIdHandler).Methods("GET").Name("DCM-Formulas")
 	paths = append(paths, dcmFormulaPath)
 
@@ -676,6 +710,17 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	changePath.HandleFunc("/revertChanges", change.RevertChangesHandler).Methods("POST").Name("Telemetry1-Changes")
 	changePath.HandleFunc("/approved/filtered", change.GetApprovedFilteredHandler).Methods("POST").Name("Telemetry1-Changes")
 	changePath.HandleFunc("/changes/filtered", change.GetChangesFilteredHandler).Methods("POST").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/approvals/{changeId}", change.GetChangeApprovalHandler).Methods("GET").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/scheduled", change.GetScheduledChangesHandler).Methods("GET").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/scheduled/{changeId}", change.UnscheduleChangeHandler).Methods("DELETE").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/comments/{changeId}", change.GetChangeCommentsHandler).Methods("GET").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/comments/{changeId}", change.PostChangeCommentHandler).Methods("POST").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/reject/{changeId}", change.RejectChangeHandler).Methods("POST").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/requestChanges/{changeId}", change.RequestChangesHandler).Methods("POST").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/reviews/{changeId}", change.GetChangeReviewsHandler).Methods("GET").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/rejected", change.GetRejectedChangesHandler).Methods("GET").Name("Telemetry1-Changes")
+	changePath.HandleFunc("/entity/all", change.GetEntityChangesHandler).Methods("GET").Name("Entity-Changes")
+	changePath.HandleFunc("/entity/approved", change.GetApprovedEntityChangesHandler).Methods("GET").Name("Entity-Changes")
 	paths = append(paths, changePath)
 
 	// telemetry/change
@@ -692,6 +737,15 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	telemetryChangePath.HandleFunc("/revertChanges", change.RevertChangesHandler).Methods("POST").Name("Telemetry1-Changes")
 	telemetryChangePath.HandleFunc("/approved/filtered", change.GetApprovedFilteredHandler).Methods("POST").Name("Telemetry1-Changes")
 	telemetryChangePath.HandleFunc("/changes/filtered", change.GetChangesFilteredHandler).Methods("POST").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/approvals/{changeId}", change.GetChangeApprovalHandler).Methods("GET").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/scheduled", change.GetScheduledChangesHandler).Methods("GET").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/scheduled/{changeId}", change.UnscheduleChangeHandler).Methods("DELETE").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/comments/{changeId}", change.GetChangeCommentsHandler).Methods("GET").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/comments/{changeId}", change.PostChangeCommentHandler).Methods("POST").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/reject/{changeId}", change.RejectChangeHandler).Methods("POST").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/requestChanges/{changeId}", change.RequestChangesHandler).Methods("POST").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/reviews/{changeId}", change.GetChangeReviewsHandler).Methods("GET").Name("Telemetry1-Changes")
+	telemetryChangePath.HandleFunc("/rejected", change.GetRejectedChangesHandler).Methods("GET").Name("Telemetry1-Changes")
 	paths = append(paths, telemetryChangePath)
 
 	// telemetry/v2/change
@@ -708,6 +762,15 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	telemetryTwoChangePath.HandleFunc("/revertChanges", change.RevertTwoChangesHandler).Methods("POST").Name("Telemetry2-Changes")
 	telemetryTwoChangePath.HandleFunc("/approved/filtered", change.GetApprovedTwoChangesFilteredHandler).Methods("POST").Name("Telemetry2-Changes")
 	telemetryTwoChangePath.HandleFunc("/changes/filtered", change.GetTwoChangesFilteredHandler).Methods("POST").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/approvals/{changeId}", change.GetChangeApprovalHandler).Methods("GET").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/scheduled", change.GetScheduledChangesHandler).Methods("GET").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/scheduled/{changeId}", change.UnscheduleChangeHandler).Methods("DELETE").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/comments/{changeId}", change.GetChangeCommentsHandler).Methods("GET").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/comments/{changeId}", change.PostChangeCommentHandler).Methods("POST").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/reject/{changeId}", change.RejectChangeHandler).Methods("POST").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/requestChanges/{changeId}", change.RequestChangesHandler).Methods("POST").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/reviews/{changeId}", change.GetChangeReviewsHandler).Methods("GET").Name("Telemetry2-Changes")
+	telemetryTwoChangePath.HandleFunc("/rejected", change.GetRejectedChangesHandler).Methods("GET").Name("Telemetry2-Changes")
 	paths = append(paths, telemetryTwoChangePath)
 
 	// changelog
@@ -715,6 +778,19 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	changelogPath.HandleFunc("", queries.GetChangeLogForTheDay).Methods("GET").Name("General-Uncategorized")
 	paths = append(paths, changelogPath)
 
+	// audit
+	auditPath := r.PathPrefix("/xconfAdminService/audit").Subrouter()
+	auditPath.HandleFunc("", queries.GetAuditLogHandler).Methods("GET").Name("General-Uncategorized")
+	paths = append(paths, auditPath)
+
+	// entity history
+	historyPath := r.PathPrefix("/xconfAdminService/history").Subrouter()
+	historyPath.HandleFunc("/{tableName}/{rowKey}", history.GetEntityHistoryHandler).Methods("GET").Name("General-Uncategorized")
+	historyPath.HandleFunc("/{tableName}/{rowKey}/diff", history.DiffEntityVersionsHandler).Methods("GET").Name("General-Uncategorized")
+	historyPath.HandleFunc("/{tableName}/{rowKey}/{version:[0-9]+}", history.GetEntityVersionHandler).Methods("GET").Name("General-Uncategorized")
+	historyPath.HandleFunc("/{tableName}/{rowKey}/{version:[0-9]+}/restore", history.RestoreEntityVersionHandler).Methods("POST").Name("General-Uncategorized")
+	paths = append(paths, historyPath)
+
 	// log
 	logPath := r.PathPrefix("/xconfAdminService/log").Subrouter()
 	logPath.HandleFunc("/{macStr}", queries.GetLogs).Methods("GET").Name("Firmware-Logs")
@@ -749,6 +825,22 @@ func RouteXconfAdminserviceApis(s *xhttp.WebconfigServer, r *mux.Router) {
 	lockdownsettingsPath.HandleFunc("", lockdown.PutLockdownSettingsHandler).Methods("PUT").Name("LockdownSettings")
 	paths = append(paths, lockdownsettingsPath)
 
+	lockdownWindowsPath := r.PathPrefix("/xconfAdminService/lockdownWindows").Subrouter()
+	lockdownWindowsPath.HandleFunc("", lockdown.GetLockdownWindowsHandler).Methods("GET").Name("LockdownWindows")
+	lockdownWindowsPath.HandleFunc("", lockdown.CreateLockdownWindowHandler).Methods("POST").Name("LockdownWindows")
+	lockdownWindowsPath.HandleFunc("", lockdown.UpdateLockdownWindowHandler).Methods("PUT").Name("LockdownWindows")
+	lockdownWindowsPath.HandleFunc("/periods", lockdown.GetLockdownPeriodsHandler).Methods("GET").Name("LockdownWindows")
+	lockdownWindowsPath.HandleFunc("/{id}", lockdown.GetLockdownWindowHandler).Methods("GET").Name("LockdownWindows")
+	lockdownWindowsPath.HandleFunc("/{id}", lockdown.DeleteLockdownWindowHandler).Methods("DELETE").Name("LockdownWindows")
+	paths = append(paths, lockdownWindowsPath)
+
+	lockdownOverridesPath := r.PathPrefix("/xconfAdminService/lockdownOverrides").Subrouter()
+	lockdownOverridesPath.HandleFunc("", lockdown.GetLockdownOverridesHandler).Methods("GET").Name("LockdownOverrides")
+	lockdownOverridesPath.HandleFunc("", lockdown.CreateLockdownOverrideHandler).Methods("POST").Name("LockdownOverrides")
+	lockdownOverridesPath.HandleFunc("/{id}", lockdown.GetLockdownOverrideHandler).Methods("GET").Name("LockdownOverrides")
+	lockdownOverridesPath.HandleFunc("/{id}", lockdown.RevokeLockdownOverrideHandler).Methods("DELETE").Name("LockdownOverrides")
+	paths = append(paths, lockdownOverridesPath)
+
 	//lockdown during recooking
 	xcrpRecookingPath := r.PathPrefix("/xconfAdminService/rfc/recooking").Subrouter()
 	xcrpRecookingPath.HandleFunc("", xcrp.PostRecookingLockdownSettingsHandler).Methods("POST").Name("Recooking")
@@ -10,6 +10,7 @@ import (
 	"github.com/rdkcentral/xconfadmin/adminapi/lockdown"
 	"github.com/rdkcentral/xconfadmin/common"
 	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
 
 	dao "github.com/rdkcentral/xconfwebconfig/db"
 	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
@@ -61,7 +62,7 @@ func PostRecookingLockdownSettingsHandler(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	if isLockdownMode() && *(lockdownSettingFromDB.LockdownModules) == "rfc" {
+	if isRfcLockdown(lockdownSettingFromDB, time.Now()) {
 		xhttp.WriteAdminErrorResponse(w, http.StatusBadRequest, "Lockdown rfc is enabled.")
 		return
 	}
@@ -111,47 +112,22 @@ func PostRecookingLockdownSettingsHandler(w http.ResponseWriter, r *http.Request
 	xhttp.WriteXconfResponse(w, respEntity.Status, nil)
 }
 
-// integrate with the lockdown settings api function, since it is not exported,copied the function here
+// isLockdownMode is true when the lockdown settings lock now
 func isLockdownMode() bool {
-	if common.GetBooleanAppSetting(common.PROP_LOCKDOWN_ENABLED, false) {
-		startTime := common.GetStringAppSetting(common.PROP_LOCKDOWN_STARTTIME)
-		endTime := common.GetStringAppSetting(common.PROP_LOCKDOWN_ENDTIME)
-
-		timezone, err := time.LoadLocation(common.DefaultLockdownTimezone)
-		if err != nil {
-			log.Errorf("Error loading timezone: %s", common.DefaultLockdownTimezone)
-			return false
-		}
-
-		t := time.Now().In(timezone).Format(common.DefaultTimeDateFormatLayout)
-		CurrentDate := time.Now().In(timezone).Format(common.DefaultDateFormatLayout)
-
-		Currenttime, err := time.Parse(common.DefaultTimeDateFormatLayout, t)
-
-		if err != nil {
-			log.Errorf("Unable to Parse currenttime: %s", Currenttime)
-			return false
-		}
-		LockdownStartTime, err := time.Parse(common.DefaultTimeDateFormatLayout, CurrentDate+" "+startTime)
-		if err != nil {
-			log.Errorf("Unable to Parse LockdownStartTime: %s", LockdownStartTime)
-			return false
-		}
-		LockdownEndTime, err := time.Parse(common.DefaultTimeDateFormatLayout, CurrentDate+" "+endTime)
-		if err != nil {
-			log.Errorf("Unable to Parse LockdownEndTime: %s", LockdownEndTime)
-			return false
-		}
-
-		if LockdownStartTime.After(LockdownEndTime) || LockdownStartTime.Equal(LockdownEndTime) {
-			LockdownStartTime = LockdownStartTime.AddDate(0, 0, -1)
-		}
+	settingsWindow := xlockdown.GetLockdownSettingsWindow()
+	return settingsWindow != nil && settingsWindow.IsActive(time.Now())
+}
 
-		if (Currenttime.Equal(LockdownStartTime) || Currenttime.After(LockdownStartTime)) && Currenttime.Before(LockdownEndTime) {
-			log.Infof("Lockdown Mode is Scheduled Now. Current time=%s, Lockdown StartTime=%s, Lockdown EndTime=%s", t, startTime, endTime)
+// isRfcLockdown is true when the lockdown settings lock the rfc module only, as a precook sets them,
+// or when an enabled lockdown window locks the rfc module
+func isRfcLockdown(lockdownSettings *common.LockdownSettings, now time.Time) bool {
+	if isLockdownMode() && lockdownSettings.LockdownModules != nil && *lockdownSettings.LockdownModules == "rfc" {
+		return true
+	}
+	for _, window := range xlockdown.GetLockdownWindowList() {
+		if window.Enabled && window.LocksModule("rfc") && window.IsActive(now) {
 			return true
 		}
-		return false
 	}
 	return false
 }
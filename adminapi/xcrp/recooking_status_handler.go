@@ -24,7 +24,7 @@ type RecookingStatus struct {
 }
 
 func GetRecookingStatusHandler(w http.ResponseWriter, r *http.Request) {
-	cc, ok := db.GetDatabaseClient().(*db.CassandraClient)
+	cc, ok := getCassandraClient()
 	if !ok {
 		http.Error(w, "Database client is not Cassandra client", http.StatusInternalServerError)
 		return
@@ -58,7 +58,7 @@ func GetRecookingStatusHandler(w http.ResponseWriter, r *http.Request) {
 }
 
 func GetRecookingStatusDetailsHandler(w http.ResponseWriter, r *http.Request) {
-	cc, ok := db.GetDatabaseClient().(*db.CassandraClient)
+	cc, ok := getCassandraClient()
 	if !ok {
 		http.Error(w, "Database client is not Cassandra client", http.StatusInternalServerError)
 		return
@@ -78,3 +78,13 @@ func GetRecookingStatusDetailsHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.Write(response)
 }
+
+// getCassandraClient unwraps the database client, e.g. the one recording the entity versions
+func getCassandraClient() (*db.CassandraClient, bool) {
+	dbClient := db.GetDatabaseClient()
+	if wrapper, ok := dbClient.(interface{ Unwrap() db.DatabaseClient }); ok {
+		dbClient = wrapper.Unwrap()
+	}
+	cc, ok := dbClient.(*db.CassandraClient)
+	return cc, ok
+}
@@ -32,6 +32,16 @@ var CanaryWakeupPercentFilterNameSet = util.Set{}
 var WakeupPoolTagName string
 var AuthProvider string
 var ApplicationTypes []string
+var ChangeApprovalBlockSelfApproval bool
+var ChangeApprovalRequiredApprovers int
+var ChangeApprovalRequiredApproversByType map[string]int
+var ChangeWorkflowEntityTypes = util.Set{}
+var ScheduledChangeIntervalInSecs int32
+var ScheduledChangeFailedRetentionInDays int32
+var AuditMaxRangeInDays int32
+var ChangedKeysRetentionInDays int32
+var ApiTokenMaxTtlInDays int32
+var LockdownOverrideMaxDurationInMins int32
 
 const (
 	DATE_TIME_FORMATTER = "1/2/2006 15:04"
@@ -132,6 +142,10 @@ const (
 	TEMPLATE_ID            = "templateId"
 	CHANGE_ID              = "changeId"
 	APPROVE_ID             = "approveId"
+	APPLY_AT               = "applyAt"
+	REASON                 = "reason"
+	FROM_VERSION           = "from"
+	TO_VERSION             = "to"
 	PAGE_NUMBER            = "pageNumber"
 	PAGE_SIZE              = "pageSize"
 	DESCRIPTION            = "description"
@@ -182,11 +196,18 @@ const (
 )
 
 const (
-	Member     = "member"
-	Tag        = "tag"
-	TagValue   = "value"
-	StartRange = "startRange"
-	EndRange   = "endRange"
+	Member          = "member"
+	Tag             = "tag"
+	TagValue        = "value"
+	TagPrefix       = "prefix"
+	TagOwner        = "owner"
+	TagMetadata     = "metadata"
+	TagTtl          = "ttl"
+	TagImportFormat = "format"
+	TagToEstb       = "estb"
+	TagRepair       = "repair"
+	StartRange      = "startRange"
+	EndRange        = "endRange"
 )
 
 const (
@@ -197,7 +218,22 @@ const (
 
 // db
 const (
-	TABLE_APP_SETTINGS = "AppSettings"
+	TABLE_APP_SETTINGS                 = "AppSettings"
+	TABLE_XCONF_CHANGE_APPROVAL        = "XconfChangeApproval"
+	TABLE_XCONF_ENTITY_CHANGE          = "XconfEntityChange"
+	TABLE_XCONF_APPROVED_ENTITY_CHANGE = "XconfApprovedEntityChange"
+	TABLE_XCONF_SCHEDULED_CHANGE       = "XconfScheduledChange"
+	TABLE_XCONF_CHANGE_COMMENT         = "XconfChangeComment"
+	TABLE_XCONF_CHANGE_REVIEW          = "XconfChangeReview"
+	TABLE_XCONF_API_TOKEN              = "XconfApiToken"
+	TABLE_XCONF_API_TOKEN_USE          = "XconfApiTokenUse"
+	TABLE_XCONF_ROLE                   = "XconfRole"
+	TABLE_XCONF_ROLE_BINDING           = "XconfRoleBinding"
+	TABLE_XCONF_LOCKDOWN_WINDOW        = "XconfLockdownWindow"
+	TABLE_XCONF_LOCKDOWN_OVERRIDE      = "XconfLockdownOverride"
+	TABLE_XCONF_TAG_NAMESPACE          = "XconfTagNamespace"
+	TABLE_XCONF_TAG_METADATA           = "XconfTagMetadata"
+	TABLE_XCONF_TAG_JOB                = "XconfTagJob"
 )
 const (
 	HeaderAuthorization        = "Authorization"
@@ -3,6 +3,8 @@ package common
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/go-akka/configuration"
 	log "github.com/sirupsen/logrus"
@@ -37,3 +39,27 @@ func ServerOriginId() string {
 		return fmt.Sprintf("%s:%d", hostname, os.Getpid())
 	}
 }
+
+// ParseRequiredApprovers parses a "key:count,key:count" list, e.g. "stb:2,TELEMETRY_TWO_PROFILE:3".
+// Keys are matched against application types and change entity types, invalid entries are skipped.
+func ParseRequiredApprovers(value string) map[string]int {
+	result := make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			log.Warnf("invalid required approvers entry: %s", entry)
+			continue
+		}
+		count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+		if err != nil || count < 1 {
+			log.Warnf("invalid required approvers count: %s", entry)
+			continue
+		}
+		result[strings.TrimSpace(parts[0])] = count
+	}
+	return result
+}
@@ -42,3 +42,12 @@ func TestServerOriginId(t *testing.T) {
 	pid := os.Getpid()
 	assert.Assert(t, pid > 0)
 }
+
+func TestParseRequiredApprovers(t *testing.T) {
+	result := ParseRequiredApprovers("stb:2, TELEMETRY_TWO_PROFILE:3,bad,rdkcloud:0,xhome:x,")
+	assert.Equal(t, 2, len(result))
+	assert.Equal(t, 2, result["stb"])
+	assert.Equal(t, 3, result["TELEMETRY_TWO_PROFILE"])
+
+	assert.Equal(t, 0, len(ParseRequiredApprovers("")))
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/shared/apitoken"
+
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const CTX_KEY_API_TOKEN AuthCtxKey = "ApiToken"
+
+// the last use is written at most once per interval, not on every request
+const apiTokenLastUsedInterval = int64(60 * 1000)
+
+// the last use recorded by this instance, by token id
+var apiTokenLastUsed sync.Map
+
+// getApiTokenFromRequest returns the api token given as a bearer token in the Authorization header
+func getApiTokenFromRequest(r *http.Request) string {
+	value := strings.TrimSpace(r.Header.Get(AUTHORIZATION))
+	if fragments := strings.SplitN(value, " ", 2); len(fragments) == 2 && strings.EqualFold(fragments[0], "Bearer") {
+		value = strings.TrimSpace(fragments[1])
+	}
+	if strings.HasPrefix(value, apitoken.TokenPrefix) {
+		return value
+	}
+	return ""
+}
+
+// ValidateApiToken returns the api token when it is known, not revoked and not expired
+func ValidateApiToken(token string) (*apitoken.ApiToken, error) {
+	id, secret, ok := apitoken.ParseToken(token)
+	if !ok {
+		return nil, errors.New("api token is malformed")
+	}
+	apiToken := apitoken.GetOneApiToken(id)
+	if apiToken == nil || !apiToken.Matches(secret) {
+		return nil, errors.New("api token is not known")
+	}
+	if apiToken.IsRevoked() {
+		return nil, errors.New("api token has been revoked")
+	}
+	now := xwutil.GetTimestamp(time.Now().UTC())
+	if apiToken.IsExpired(now) {
+		return nil, errors.New("api token is expired")
+	}
+	if lastUsed, ok := apiTokenLastUsed.Load(apiToken.ID); !ok || now-lastUsed.(int64) >= apiTokenLastUsedInterval {
+		apiTokenLastUsed.Store(apiToken.ID, now)
+		if err := apitoken.SetApiTokenLastUsed(apiToken.ID, now); err != nil {
+			log.Warnf("error saving last use of api token %s: %v", apiToken.ID, err)
+		}
+	}
+	return apiToken, nil
+}
+
+func GetApiTokenFromContext(r *http.Request) *apitoken.ApiToken {
+	token := r.Context().Value(CTX_KEY_API_TOKEN)
+	if token == nil {
+		return nil
+	}
+	return token.(*apitoken.ApiToken)
+}
@@ -23,7 +23,9 @@ import (
 	"math"
 	"math/big"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/rdkcentral/xconfadmin/common"
 
@@ -160,32 +162,38 @@ func ValidateAndGetLoginToken(authToken string) (*LoginToken, error) {
 	if authToken == "" {
 		return nil, errors.New("auth token is empty")
 	}
-	var publicKey *rsa.PublicKey
 	var token *jwt.Token
 	var err error
-	// first parse without validation to get the public key information
-	jwtToken, _ := jwt.Parse(authToken, nil)
-	if jwtToken == nil {
-		return nil, errors.New("error parsing auth token")
+	if common.AuthProvider != "acl" && WebConfServer != nil {
+		// an idp which validates its own tokens, like oidc with the jwks of the issuer
+		if validator, ok := WebConfServer.IdpServiceConnector.(IdpTokenValidator); ok {
+			return validator.ValidateToken(authToken)
+		}
 	}
 	if common.AuthProvider != "acl" {
-		publicKey = getPublicKey(jwtToken.Header)
+		// first parse without validation to get the public key information
+		jwtToken, _ := jwt.Parse(authToken, nil)
+		if jwtToken == nil {
+			return nil, errors.New("error parsing auth token")
+		}
+		publicKey := getPublicKey(jwtToken.Header)
 		if publicKey == nil {
 			return nil, errors.New("error getting public key")
 		}
 		// parse and validate
 		token, err = jwt.Parse(authToken, func(token *jwt.Token) (interface{}, error) {
 			return publicKey, nil
-		})
+		}, jwt.WithValidMethods(idpTokenAlgorithms))
 		if err != nil {
 			return nil, fmt.Errorf("error parsing auth token with public key: %s", err.Error())
 		}
 	} else {
-		token, err = jwt.Parse(authToken, func(token *jwt.Token) (interface{}, error) {
-			return []byte("xconf"), nil
-		})
+		if WebConfServer == nil || WebConfServer.LoginTokenKeys == nil {
+			return nil, errors.New("login token keys are not configured")
+		}
+		token, err = WebConfServer.LoginTokenKeys.Parse(authToken)
 		if err != nil {
-			return nil, fmt.Errorf("error parsing auth token with public key: %s", err.Error())
+			return nil, fmt.Errorf("error parsing auth token with login token key: %s", err.Error())
 		}
 	}
 
@@ -193,6 +201,10 @@ func ValidateAndGetLoginToken(authToken string) (*LoginToken, error) {
 	if !ok || !token.Valid {
 		return nil, errors.New("error getting claims from auth token")
 	}
+	// a token without exp would never expire
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("auth token has no expiration time or is expired")
+	}
 	return NewLoginToken(claims), nil
 }
 
@@ -305,11 +317,24 @@ func getPermissionsFromLoginToken(LoginToken *LoginToken) []string {
 
 func getWebValidator() *WebValidator {
 	keysUrl := fmt.Sprintf("%s%s", WebConfServer.XW_XconfServer.SatServiceConnector.ConsumerHost(), KeysURL)
-	return &WebValidator{
+	return newSatWebValidator(keysUrl)
+}
+
+// newSatWebValidator returns a validator with the keys cached for all the requests
+func newSatWebValidator(keysUrl string) *WebValidator {
+	webValidator := &WebValidator{
 		Client:  http.DefaultClient,
 		KeysURL: keysUrl,
 		Keys:    make(map[string]interface{}),
 	}
+	webValidator.Cache = GetJwksCache("sat", keysUrl, func(kid string) (map[string]interface{}, error) {
+		key, err := webValidator.FetchKey(kid)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{kid: key}, nil
+	})
+	return webValidator
 }
 
 func getSubjectAndCapabilitiesFromSatToken(token string, verifyStageHost bool) (string, []string, error) {
@@ -341,11 +366,7 @@ func getSubjectAndCapabilitiesFromSatToken(token string, verifyStageHost bool) (
 	} else {
 		// Validate with sat service host if flag is disabled.
 		satServiceKeysUrl := fmt.Sprintf("%s%s", WebConfServer.XW_XconfServer.SatServiceConnector.SatServiceHost(), KeysURL)
-		webValidator = &WebValidator{
-			Client:  http.DefaultClient,
-			KeysURL: satServiceKeysUrl,
-			Keys:    make(map[string]interface{}),
-		}
+		webValidator = newSatWebValidator(satServiceKeysUrl)
 		claims, err = webValidator.Validate(token)
 		if err != nil {
 			log.Error("Validation failed with prod host")
@@ -360,42 +381,51 @@ func getSubjectAndCapabilitiesFromSatToken(token string, verifyStageHost bool) (
 	return claims.Subject, capabilities, nil
 }
 
-func getJsonWebKey(header map[string]interface{}) *JsonWebKey {
-	var kid string
-	if val, ok := header["kid"]; ok {
-		kid = val.(string)
-	} else {
-		log.Errorf("kid attribute not found")
-		return nil
-	}
-
-	if val, ok := WebConfServer.IdpServiceConnector.GetIdpServiceConfig().KidMap.Load(kid); ok {
-		log.Debugf("kid=%s, fetched=cached", kid)
-		jsonWebKey := val.(JsonWebKey)
-		return &jsonWebKey
+// getIdpJwksUrl returns the configured jwks url, or the jku of the token when it is on the idp host:
+// a token must not name a key server of its own choosing
+func getIdpJwksUrl(header map[string]interface{}) string {
+	if config := WebConfServer.IdpServiceConnector.GetIdpServiceConfig(); config != nil && config.JwksUrl != "" {
+		return config.JwksUrl
 	}
-	// if kid not in KidMap, try to get public key url from jku in header
-	var url string
-	if val, ok := header["jku"]; ok {
-		url = val.(string)
-	} else {
+	jku, ok := header["jku"].(string)
+	if !ok {
 		log.Errorf("jku attribute not found")
-		return nil
+		return ""
 	}
-	jsonWebKeyResponse := WebConfServer.IdpServiceConnector.GetJsonWebKeyResponse(url)
-	if jsonWebKeyResponse != nil {
-		jsonWebKeyList := jsonWebKeyResponse.Keys
-		for _, jsonWebKey := range jsonWebKeyList {
-			if jsonWebKey.Kid == kid {
-				log.Debugf("kid=%s, fetched=url", kid)
-				WebConfServer.IdpServiceConnector.GetIdpServiceConfig().KidMap.Store(kid, jsonWebKey)
-				return &jsonWebKey
-			}
-		}
+	jkuUrl, err := url.Parse(jku)
+	if err != nil {
+		log.Errorf("jku %s is not valid: %s", jku, err.Error())
+		return ""
+	}
+	hostUrl, err := url.Parse(WebConfServer.IdpServiceConnector.IdpServiceHost())
+	if err != nil || jkuUrl.Scheme != hostUrl.Scheme || jkuUrl.Host != hostUrl.Host {
+		log.Errorf("jku %s is not on the idp host", jku)
+		return ""
 	}
+	return jku
+}
 
-	log.Errorf("kid=%s, not found in cache or url", kid)
-	return nil
+// getIdpKeys fetches the RSA signing keys of the jwks
+func getIdpKeys(jwksUrl string) (map[string]interface{}, error) {
+	jsonWebKeyResponse := WebConfServer.IdpServiceConnector.GetJsonWebKeyResponse(jwksUrl)
+	if jsonWebKeyResponse == nil {
+		return nil, fmt.Errorf("error getting jwks from %s", jwksUrl)
+	}
+	return getRsaKeys(jsonWebKeyResponse), nil
+}
+
+func getRsaKeys(jsonWebKeyResponse *JsonWebKeyResponse) map[string]interface{} {
+	keys := map[string]interface{}{}
+	for i := range jsonWebKeyResponse.Keys {
+		jsonWebKey := &jsonWebKeyResponse.Keys[i]
+		if !strings.EqualFold(jsonWebKey.KeyType, "RSA") || (jsonWebKey.Use != "" && jsonWebKey.Use != "sig") {
+			continue
+		}
+		if publicKey := getRsaKeySpec(jsonWebKey); publicKey != nil {
+			keys[jsonWebKey.Kid] = publicKey
+		}
+	}
+	return keys
 }
 
 func getRsaKeySpec(jsonWebKey *JsonWebKey) *rsa.PublicKey {
@@ -416,14 +446,22 @@ func getRsaKeySpec(jsonWebKey *JsonWebKey) *rsa.PublicKey {
 }
 
 func getPublicKey(header map[string]interface{}) *rsa.PublicKey {
-	jsonWebKey := getJsonWebKey(header)
-	if jsonWebKey == nil {
-		log.Errorf("error getting jsonWebKey: %v", header)
+	kid, ok := header["kid"].(string)
+	if !ok {
+		log.Errorf("kid attribute not found")
 		return nil
 	}
-	if strings.EqualFold(jsonWebKey.KeyType, "RSA") {
-		return getRsaKeySpec(jsonWebKey)
+	jwksUrl := getIdpJwksUrl(header)
+	if jwksUrl == "" {
+		return nil
+	}
+	cache := GetJwksCache("idp", jwksUrl, func(string) (map[string]interface{}, error) {
+		return getIdpKeys(jwksUrl)
+	})
+	key, err := cache.GetKey(kid)
+	if err != nil {
+		log.Errorf("kid=%s: %s", kid, err.Error())
+		return nil
 	}
-	log.Errorf("error alg not supported: %s", jsonWebKey)
-	return nil
+	return key.(*rsa.PublicKey)
 }
@@ -38,10 +38,12 @@ const (
 )
 
 type IdpServiceConfig struct {
-	ClientId        string
-	ClientSecret    string
+	ClientId     string
+	ClientSecret string
+	// Deprecated: the token keys are cached by the shared JwksCache, KidMap is no longer read or written
 	KidMap          sync.Map // map[string]JsonWebKey
 	AuthHeaderValue string
+	JwksUrl         string
 }
 
 type JsonWebKeyResponse struct {
@@ -79,6 +81,8 @@ type DefaultIdpService struct {
 func NewIdpServiceConnector(conf *configuration.Config, externalIdpService IdpServiceConnector) IdpServiceConnector {
 	if externalIdpService != nil {
 		return externalIdpService
+	} else if conf.GetString("xconfwebconfig.xconf.authprovider") == OidcAuthProvider {
+		return NewOidcIdpService(conf)
 	} else {
 		idpServiceName = conf.GetString("xconfwebconfig.xconf.idp_service_name")
 		confKey := fmt.Sprintf("xconfwebconfig.%v.host", idpServiceName)
@@ -86,31 +90,7 @@ func NewIdpServiceConnector(conf *configuration.Config, externalIdpService IdpSe
 		if util.IsBlank(host) {
 			panic(fmt.Errorf("%s is required", confKey))
 		}
-		clientId := os.Getenv("IDP_CLIENT_ID")
-		if util.IsBlank(clientId) {
-			confKey := fmt.Sprintf("xconfwebconfig.%v.client_id", idpServiceName)
-			clientId = conf.GetString(confKey)
-			if util.IsBlank(clientId) {
-				panic("No env IDP_CLIENT_ID")
-			}
-		}
-		clientSecret := os.Getenv("IDP_CLIENT_SECRET")
-		if util.IsBlank(clientSecret) {
-			confKey := fmt.Sprintf("xconfwebconfig.%v.client_secret", idpServiceName)
-			clientSecret = conf.GetString(confKey)
-			if util.IsBlank(clientSecret) {
-				panic("No env IDP_CLIENT_SECRET")
-			}
-		}
-		auth := fmt.Sprintf("%s:%s", clientId, clientSecret)
-		authHeader := fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(auth)))
-
-		idpServiceConfig := &IdpServiceConfig{
-			ClientId:        clientId,
-			ClientSecret:    clientSecret,
-			KidMap:          sync.Map{},
-			AuthHeaderValue: authHeader,
-		}
+		idpServiceConfig := newIdpServiceConfig(conf)
 
 		// Read path configurations with defaults
 		getTokenUrl := conf.GetString(
@@ -196,3 +176,33 @@ func (xc *DefaultIdpService) Logout(url string) error {
 	_, err := xc.DoWithRetries("GET", url, nil, nil, nil, idpServiceName)
 	return err
 }
+
+// newIdpServiceConfig reads the client credentials from the IDP_CLIENT_ID and IDP_CLIENT_SECRET env or the config
+func newIdpServiceConfig(conf *configuration.Config) *IdpServiceConfig {
+	clientId := os.Getenv("IDP_CLIENT_ID")
+	if util.IsBlank(clientId) {
+		confKey := fmt.Sprintf("xconfwebconfig.%v.client_id", idpServiceName)
+		clientId = conf.GetString(confKey)
+		if util.IsBlank(clientId) {
+			panic("No env IDP_CLIENT_ID")
+		}
+	}
+	clientSecret := os.Getenv("IDP_CLIENT_SECRET")
+	if util.IsBlank(clientSecret) {
+		confKey := fmt.Sprintf("xconfwebconfig.%v.client_secret", idpServiceName)
+		clientSecret = conf.GetString(confKey)
+		if util.IsBlank(clientSecret) {
+			panic("No env IDP_CLIENT_SECRET")
+		}
+	}
+	auth := fmt.Sprintf("%s:%s", clientId, clientSecret)
+	authHeader := fmt.Sprintf("Basic %s", base64.StdEncoding.EncodeToString([]byte(auth)))
+
+	return &IdpServiceConfig{
+		ClientId:        clientId,
+		ClientSecret:    clientSecret,
+		KidMap:          sync.Map{},
+		AuthHeaderValue: authHeader,
+		JwksUrl:         conf.GetString(fmt.Sprintf("xconfwebconfig.%v.jwks_url", idpServiceName)),
+	}
+}
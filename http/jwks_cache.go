@@ -0,0 +1,325 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-akka/configuration"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	log "github.com/sirupsen/logrus"
+)
+
+// results of a key lookup
+const (
+	JwksCacheHit      = "hit"
+	JwksCacheStale    = "stale"
+	JwksCacheMiss     = "miss"
+	JwksCacheNegative = "negative"
+)
+
+var (
+	jwksCacheLookups = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "xconf",
+			Subsystem: "jwks_cache",
+			Name:      "lookups_total",
+			Help:      "key lookups by cache and result",
+		},
+		[]string{"cache", "result"},
+	)
+	jwksCacheRefreshSeconds = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "xconf",
+			Subsystem: "jwks_cache",
+			Name:      "refresh_seconds",
+			Help:      "elapsed time to fetch the keys",
+			Buckets:   prometheus.DefBuckets,
+		},
+		[]string{"cache", "status"},
+	)
+	jwksCacheKeys = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "xconf",
+			Subsystem: "jwks_cache",
+			Name:      "keys",
+			Help:      "keys held by the cache",
+		},
+		[]string{"cache"},
+	)
+)
+
+var ErrUnknownKid = errors.New("kid is not known")
+
+// KeyFetcher returns the keys by kid. A jwks returns all the keys of the issuer, a keys url returns the key of the kid.
+type KeyFetcher func(kid string) (map[string]interface{}, error)
+
+const (
+	defaultJwksCacheTtlInSecs             = 3600
+	defaultJwksCacheNegativeTtlInSecs     = 60
+	defaultJwksCacheRefreshIntervalInSecs = 300
+	// the unknown kids remembered at most, tokens with random kids can not grow the cache further
+	maxJwksUnknownKids = 1000
+)
+
+type JwksCacheConfig struct {
+	Ttl             time.Duration
+	NegativeTtl     time.Duration
+	RefreshInterval time.Duration
+}
+
+func NewJwksCacheConfig(conf *configuration.Config) *JwksCacheConfig {
+	return &JwksCacheConfig{
+		Ttl:             time.Duration(conf.GetInt32("xconfwebconfig.xconf.jwks_cache_ttl_in_secs", defaultJwksCacheTtlInSecs)) * time.Second,
+		NegativeTtl:     time.Duration(conf.GetInt32("xconfwebconfig.xconf.jwks_cache_negative_ttl_in_secs", defaultJwksCacheNegativeTtlInSecs)) * time.Second,
+		RefreshInterval: time.Duration(conf.GetInt32("xconfwebconfig.xconf.jwks_cache_refresh_interval_in_secs", defaultJwksCacheRefreshIntervalInSecs)) * time.Second,
+	}
+}
+
+type cachedKey struct {
+	key     interface{}
+	fetched time.Time
+}
+
+// JwksCache keeps the token verification keys by kid. A key older than the ttl is still used while it is
+// refreshed in the background, so a slow idp only delays the first request for a kid. An unknown kid is fetched
+// at once, which picks up rotated keys, and is then remembered as unknown for the negative ttl. An unknown kid
+// fetches at most once per negative ttl, whichever the kid. A key which the issuer no longer returns is removed
+// at its next refresh.
+type JwksCache struct {
+	Name        string
+	config      *JwksCacheConfig
+	fetch       KeyFetcher
+	lock        sync.RWMutex
+	keys        map[string]*cachedKey
+	unknownKids map[string]time.Time
+	refreshing  map[string]bool
+	fetchLock   sync.Mutex
+	lastForced  time.Time
+	now         func() time.Time
+}
+
+func NewJwksCache(name string, config *JwksCacheConfig, fetch KeyFetcher) *JwksCache {
+	return &JwksCache{
+		Name:        name,
+		config:      config,
+		fetch:       fetch,
+		keys:        map[string]*cachedKey{},
+		unknownKids: map[string]time.Time{},
+		refreshing:  map[string]bool{},
+		now:         time.Now,
+	}
+}
+
+// caches by kind and keys url, shared by all requests
+var jwksCaches sync.Map
+
+// GetJwksCache returns the cache of the keys url, the fetcher is only used when the cache is created
+func GetJwksCache(kind string, keysUrl string, fetch KeyFetcher) *JwksCache {
+	id := kind + " " + keysUrl
+	if cache, ok := jwksCaches.Load(id); ok {
+		return cache.(*JwksCache)
+	}
+	cache, _ := jwksCaches.LoadOrStore(id, NewJwksCache(kind, getJwksCacheConfig(), fetch))
+	return cache.(*JwksCache)
+}
+
+func getJwksCacheConfig() *JwksCacheConfig {
+	if WebConfServer != nil && WebConfServer.JwksCacheConfig != nil {
+		return WebConfServer.JwksCacheConfig
+	}
+	return &JwksCacheConfig{
+		Ttl:             defaultJwksCacheTtlInSecs * time.Second,
+		NegativeTtl:     defaultJwksCacheNegativeTtlInSecs * time.Second,
+		RefreshInterval: defaultJwksCacheRefreshIntervalInSecs * time.Second,
+	}
+}
+
+// StartJwksCacheRefresher refreshes the keys of all caches before they are stale
+func StartJwksCacheRefresher(interval time.Duration) {
+	if interval <= 0 {
+		log.Info("Jwks cache refresher is disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			jwksCaches.Range(func(_, cache interface{}) bool {
+				cache.(*JwksCache).RefreshAll()
+				return true
+			})
+		}
+	}()
+}
+
+func (c *JwksCache) GetKey(kid string) (interface{}, error) {
+	now := c.now()
+	c.lock.RLock()
+	cached, found := c.keys[kid]
+	unknownUntil, unknown := c.unknownKids[kid]
+	c.lock.RUnlock()
+
+	if found {
+		if now.Sub(cached.fetched) < c.config.Ttl {
+			jwksCacheLookups.WithLabelValues(c.Name, JwksCacheHit).Inc()
+		} else {
+			jwksCacheLookups.WithLabelValues(c.Name, JwksCacheStale).Inc()
+			c.refreshInBackground(kid)
+		}
+		return cached.key, nil
+	}
+	if unknown && now.Before(unknownUntil) {
+		jwksCacheLookups.WithLabelValues(c.Name, JwksCacheNegative).Inc()
+		return nil, ErrUnknownKid
+	}
+	jwksCacheLookups.WithLabelValues(c.Name, JwksCacheMiss).Inc()
+	return c.forceRefresh(kid)
+}
+
+// forceRefresh fetches an unknown kid, the requests for the same kid wait for one fetch.
+// No kid is fetched within the negative ttl of the last forced fetch.
+func (c *JwksCache) forceRefresh(kid string) (interface{}, error) {
+	c.fetchLock.Lock()
+	defer c.fetchLock.Unlock()
+
+	if key := c.lookup(kid); key != nil {
+		return key, nil
+	}
+	now := c.now()
+	if nextForced := c.lastForced.Add(c.config.NegativeTtl); !c.lastForced.IsZero() && now.Before(nextForced) {
+		c.setUnknown(kid, now, nextForced)
+		return nil, ErrUnknownKid
+	}
+	c.lastForced = now
+	err := c.refresh(kid)
+	if key := c.lookup(kid); key != nil {
+		return key, nil
+	}
+	now = c.now()
+	c.setUnknown(kid, now, now.Add(c.config.NegativeTtl))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKid, err.Error())
+	}
+	return nil, ErrUnknownKid
+}
+
+// setUnknown remembers the kid as unknown until the given time, the expired kids are dropped once the cache of
+// unknown kids is full
+func (c *JwksCache) setUnknown(kid string, now time.Time, until time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.unknownKids) >= maxJwksUnknownKids {
+		for unknownKid, unknownUntil := range c.unknownKids {
+			if !now.Before(unknownUntil) {
+				delete(c.unknownKids, unknownKid)
+			}
+		}
+		if len(c.unknownKids) >= maxJwksUnknownKids {
+			return
+		}
+	}
+	c.unknownKids[kid] = until
+}
+
+func (c *JwksCache) refreshInBackground(kid string) {
+	c.lock.Lock()
+	if c.refreshing[kid] {
+		c.lock.Unlock()
+		return
+	}
+	c.refreshing[kid] = true
+	c.lock.Unlock()
+
+	go func() {
+		defer func() {
+			c.lock.Lock()
+			delete(c.refreshing, kid)
+			c.lock.Unlock()
+		}()
+		c.fetchLock.Lock()
+		defer c.fetchLock.Unlock()
+		c.refresh(kid)
+	}()
+}
+
+// RefreshAll fetches every known kid, a jwks refreshes all its keys with the first fetch
+func (c *JwksCache) RefreshAll() {
+	c.fetchLock.Lock()
+	defer c.fetchLock.Unlock()
+
+	start := c.now()
+	c.lock.RLock()
+	kids := make([]string, 0, len(c.keys))
+	for kid := range c.keys {
+		kids = append(kids, kid)
+	}
+	c.lock.RUnlock()
+
+	for _, kid := range kids {
+		c.lock.RLock()
+		cached, found := c.keys[kid]
+		c.lock.RUnlock()
+		if found && cached.fetched.Before(start) {
+			c.refresh(kid)
+		}
+	}
+}
+
+// refresh stores the fetched keys, and removes the kid when the fetch succeeds without it.
+// The caller holds the fetch lock.
+func (c *JwksCache) refresh(kid string) error {
+	start := time.Now()
+	status := "failure"
+	defer func() {
+		jwksCacheRefreshSeconds.WithLabelValues(c.Name, status).Observe(time.Since(start).Seconds())
+	}()
+
+	keys, err := c.fetch(kid)
+	if err != nil {
+		log.Warnf("error fetching %s keys for kid=%s: %s", c.Name, kid, err.Error())
+		return err
+	}
+	now := c.now()
+	c.lock.Lock()
+	for fetchedKid, key := range keys {
+		c.keys[fetchedKid] = &cachedKey{key: key, fetched: now}
+		delete(c.unknownKids, fetchedKid)
+	}
+	if _, ok := keys[kid]; !ok {
+		if _, found := c.keys[kid]; found {
+			log.Infof("%s kid=%s is no longer published, removed", c.Name, kid)
+			delete(c.keys, kid)
+		}
+	}
+	jwksCacheKeys.WithLabelValues(c.Name).Set(float64(len(c.keys)))
+	c.lock.Unlock()
+	status = "success"
+	return nil
+}
+
+func (c *JwksCache) lookup(kid string) interface{} {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if cached, ok := c.keys[kid]; ok {
+		return cached.key
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+// testKeyServer publishes keys like a jwks and counts the fetches
+type testKeyServer struct {
+	lock    sync.Mutex
+	keys    map[string]interface{}
+	fetches int
+	err     error
+}
+
+func (s *testKeyServer) fetch(kid string) (map[string]interface{}, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.fetches++
+	if s.err != nil {
+		return nil, s.err
+	}
+	keys := map[string]interface{}{}
+	for k, v := range s.keys {
+		keys[k] = v
+	}
+	return keys, nil
+}
+
+func (s *testKeyServer) set(keys map[string]interface{}, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys, s.err = keys, err
+}
+
+func (s *testKeyServer) fetchCount() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.fetches
+}
+
+func newTestJwksCache(server *testKeyServer, now *time.Time) *JwksCache {
+	cache := NewJwksCache("test", &JwksCacheConfig{Ttl: time.Hour, NegativeTtl: time.Minute}, server.fetch)
+	cache.now = func() time.Time { return *now }
+	return cache
+}
+
+func TestJwksCacheHitAndNegative(t *testing.T) {
+	now := time.Now()
+	server := &testKeyServer{keys: map[string]interface{}{"k1": "key1", "k2": "key2"}}
+	cache := newTestJwksCache(server, &now)
+
+	key, err := cache.GetKey("k1")
+	assert.NilError(t, err)
+	assert.Equal(t, "key1", key)
+	// the jwks fetch cached all its keys
+	key, err = cache.GetKey("k2")
+	assert.NilError(t, err)
+	assert.Equal(t, "key2", key)
+	assert.Equal(t, 1, server.fetchCount())
+
+	// no kid is fetched within the negative ttl of the last fetch
+	_, err = cache.GetKey("unknown")
+	assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	assert.Equal(t, 1, server.fetchCount())
+
+	now = now.Add(2 * time.Minute)
+	_, err = cache.GetKey("unknown")
+	assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	assert.Equal(t, 2, server.fetchCount())
+	// an unknown kid is not fetched again within the negative ttl
+	_, err = cache.GetKey("unknown")
+	assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	assert.Equal(t, 2, server.fetchCount())
+
+	now = now.Add(2 * time.Minute)
+	_, err = cache.GetKey("unknown")
+	assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	assert.Equal(t, 3, server.fetchCount())
+}
+
+func TestJwksCacheRotation(t *testing.T) {
+	now := time.Now()
+	server := &testKeyServer{keys: map[string]interface{}{"k1": "key1"}}
+	cache := newTestJwksCache(server, &now)
+
+	_, err := cache.GetKey("k1")
+	assert.NilError(t, err)
+
+	// a new key is fetched the first time a token is signed with it
+	server.set(map[string]interface{}{"k1": "key1", "k2": "key2"}, nil)
+	now = now.Add(2 * time.Minute)
+	key, err := cache.GetKey("k2")
+	assert.NilError(t, err)
+	assert.Equal(t, "key2", key)
+
+	// the old key is removed once the issuer no longer publishes it
+	server.set(map[string]interface{}{"k2": "key2"}, nil)
+	now = now.Add(2 * time.Hour)
+	cache.RefreshAll()
+	assert.Assert(t, cache.lookup("k1") == nil)
+	assert.Equal(t, "key2", cache.lookup("k2"))
+}
+
+func TestJwksCacheUnknownKidsAreCapped(t *testing.T) {
+	now := time.Now()
+	server := &testKeyServer{keys: map[string]interface{}{"k1": "key1"}}
+	cache := newTestJwksCache(server, &now)
+
+	for i := 0; i < maxJwksUnknownKids+10; i++ {
+		_, err := cache.GetKey(fmt.Sprintf("random-%d", i))
+		assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	}
+	assert.Equal(t, 1, server.fetchCount())
+	assert.Equal(t, maxJwksUnknownKids, len(cache.unknownKids))
+
+	// the expired kids make room again
+	now = now.Add(2 * time.Minute)
+	_, err := cache.GetKey("random-new")
+	assert.Assert(t, errors.Is(err, ErrUnknownKid))
+	assert.Equal(t, 1, len(cache.unknownKids))
+}
+
+func TestJwksCacheStaleKeyRefreshedInBackground(t *testing.T) {
+	now := time.Now()
+	server := &testKeyServer{keys: map[string]interface{}{"k1": "key1"}}
+	cache := newTestJwksCache(server, &now)
+
+	_, err := cache.GetKey("k1")
+	assert.NilError(t, err)
+
+	// a stale key is still used while the idp fails
+	server.set(nil, errors.New("idp is down"))
+	now = now.Add(2 * time.Hour)
+	key, err := cache.GetKey("k1")
+	assert.NilError(t, err)
+	assert.Equal(t, "key1", key)
+	waitForJwksRefresh(t, cache, server, 2)
+
+	server.set(map[string]interface{}{"k1": "key1b"}, nil)
+	key, err = cache.GetKey("k1")
+	assert.NilError(t, err)
+	assert.Equal(t, "key1", key)
+	waitForJwksRefresh(t, cache, server, 3)
+	assert.Equal(t, "key1b", cache.lookup("k1"))
+}
+
+func TestGetIdpJwksUrl(t *testing.T) {
+	server := WebConfServer
+	t.Cleanup(func() { WebConfServer = server })
+	WebConfServer = &WebconfigServer{IdpServiceConnector: &MockIdpServiceConnector{}}
+
+	WebConfServer.IdpServiceConnector.SetIdpServiceHost("https://idp.example.com")
+	assert.Equal(t, "https://idp.example.com/keys", getIdpJwksUrl(map[string]interface{}{"jku": "https://idp.example.com/keys"}))
+	assert.Equal(t, "", getIdpJwksUrl(map[string]interface{}{"jku": "https://attacker.example.com/keys"}))
+	assert.Equal(t, "", getIdpJwksUrl(map[string]interface{}{"jku": "http://idp.example.com/keys"}))
+	assert.Equal(t, "", getIdpJwksUrl(map[string]interface{}{}))
+}
+
+// waitForJwksRefresh waits until the background refresh is done
+func waitForJwksRefresh(t *testing.T, cache *JwksCache, server *testKeyServer, fetches int) {
+	for i := 0; i < 100; i++ {
+		cache.lock.RLock()
+		refreshing := len(cache.refreshing)
+		cache.lock.RUnlock()
+		if refreshing == 0 && server.fetchCount() >= fetches {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	assert.Equal(t, fetches, server.fetchCount())
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const CTX_KEY_OVERRIDDEN_WRITES AuthCtxKey = "OverriddenWrites"
+
+// OverriddenWrites are the writes to locked modules let through by a break-glass override while a request
+// is handled, they are recorded with the override once the request has succeeded
+type OverriddenWrites struct {
+	lock   sync.Mutex
+	writes []*overriddenWrite
+}
+
+type overriddenWrite struct {
+	overrideId string
+	userName   string
+	write      *xlockdown.LockdownOverrideWrite
+}
+
+// WithOverriddenWrites returns the request collecting the writes let through by an override
+func WithOverriddenWrites(r *http.Request) (*http.Request, *OverriddenWrites) {
+	overriddenWrites := &OverriddenWrites{}
+	return r.WithContext(context.WithValue(r.Context(), CTX_KEY_OVERRIDDEN_WRITES, overriddenWrites)), overriddenWrites
+}
+
+// AddOverriddenWrite keeps the write until the request has succeeded, a module is kept once per request.
+// A request which does not collect the writes records it at once.
+func AddOverriddenWrite(r *http.Request, overrideId string, write *xlockdown.LockdownOverrideWrite) {
+	userName := r.Header.Get(AUTH_SUBJECT)
+	overriddenWrites, ok := r.Context().Value(CTX_KEY_OVERRIDDEN_WRITES).(*OverriddenWrites)
+	if !ok {
+		recordOverriddenWrite(&overriddenWrite{overrideId: overrideId, userName: userName, write: write})
+		return
+	}
+	overriddenWrites.lock.Lock()
+	defer overriddenWrites.lock.Unlock()
+	for _, kept := range overriddenWrites.writes {
+		if kept.overrideId == overrideId && kept.write.Module == write.Module {
+			return
+		}
+	}
+	overriddenWrites.writes = append(overriddenWrites.writes, &overriddenWrite{overrideId: overrideId, userName: userName, write: write})
+}
+
+// Record appends the kept writes to their overrides when the status is a success, they are dropped otherwise
+func (o *OverriddenWrites) Record(status int) {
+	o.lock.Lock()
+	writes := o.writes
+	o.writes = nil
+	o.lock.Unlock()
+	if status < http.StatusOK || status >= http.StatusBadRequest {
+		return
+	}
+	for _, write := range writes {
+		recordOverriddenWrite(write)
+	}
+}
+
+func recordOverriddenWrite(w *overriddenWrite) {
+	if err := xlockdown.AddLockdownOverrideWrite(w.overrideId, w.write); err != nil {
+		log.Errorf("Unable to record the write of %s under lockdown override %s: %s", w.userName, w.overrideId, err.Error())
+	}
+	log.WithFields(log.Fields{
+		"lockdown_override": w.overrideId,
+		"auth_subject":      w.userName,
+		"module":            w.write.Module,
+		"method":            w.write.Method,
+		"path":              w.write.Path,
+	}).Warn("write to a locked module under a break-glass override")
+}
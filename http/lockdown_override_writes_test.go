@@ -0,0 +1,41 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	xlockdown "github.com/rdkcentral/xconfadmin/shared/lockdown"
+
+	"gotest.tools/assert"
+)
+
+func TestOverriddenWritesAreKeptUntilTheRequestSucceeds(t *testing.T) {
+	r, overriddenWrites := WithOverriddenWrites(httptest.NewRequest(http.MethodPut, "/xconfAdminService/firmwarerule", nil))
+	r.Header.Set(AUTH_SUBJECT, "alice")
+
+	// the permission of a request may be checked several times, the module is kept once
+	AddOverriddenWrite(r, "override1", &xlockdown.LockdownOverrideWrite{Module: "firmware", Method: http.MethodPut, Path: r.URL.Path})
+	AddOverriddenWrite(r, "override1", &xlockdown.LockdownOverrideWrite{Module: "firmware", Method: http.MethodPut, Path: r.URL.Path})
+	AddOverriddenWrite(r, "override1", &xlockdown.LockdownOverrideWrite{Module: "common", Method: http.MethodPut, Path: r.URL.Path})
+	assert.Equal(t, 2, len(overriddenWrites.writes))
+
+	// a failed request records nothing
+	overriddenWrites.Record(http.StatusBadRequest)
+	assert.Equal(t, 0, len(overriddenWrites.writes))
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/rdkcentral/xconfadmin/util"
+
+	"github.com/go-akka/configuration"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	LoginTokenKeysEnv          = "LOGIN_TOKEN_KEYS"
+	DefaultLoginTokenAlgorithm = "HS256"
+	minLoginTokenSecretLength  = 32
+)
+
+var loginTokenAlgorithms = []string{"HS256", "HS384", "HS512", "RS256", "RS384", "RS512"}
+
+// the idp signs its tokens with the RSA keys published in its jwks
+var idpTokenAlgorithms = []string{"RS256", "RS384", "RS512"}
+
+// LoginTokenKeys are the keys of the login tokens issued with the acl auth provider. Every key has a kid,
+// tokens are signed with the key of SigningKid and verified with the key named by their kid, so keys can be
+// rotated by adding the new key, switching SigningKid and removing the old key once its tokens have expired.
+type LoginTokenKeys struct {
+	Algorithm   string
+	SigningKid  string
+	signingKeys map[string]interface{}
+	verifyKeys  map[string]interface{}
+}
+
+// NewLoginTokenKeys reads the keys from the LOGIN_TOKEN_KEYS env or login_token_keys as kid:secret pairs
+// separated by commas, and from login_token_key_file, a json object of kid to secret or PEM key.
+func NewLoginTokenKeys(conf *configuration.Config) (*LoginTokenKeys, error) {
+	algorithm := conf.GetString("xconfwebconfig.xconf.login_token_algorithm", DefaultLoginTokenAlgorithm)
+	signingKid := conf.GetString("xconfwebconfig.xconf.login_token_signing_kid")
+
+	keys := map[string]string{}
+	keysValue := os.Getenv(LoginTokenKeysEnv)
+	if util.IsBlank(keysValue) {
+		keysValue = conf.GetString("xconfwebconfig.xconf.login_token_keys")
+	}
+	for _, pair := range strings.Split(keysValue, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kidAndKey := strings.SplitN(pair, ":", 2)
+		if len(kidAndKey) != 2 || strings.TrimSpace(kidAndKey[0]) == "" {
+			return nil, errors.New("login token keys must be kid:secret pairs")
+		}
+		keys[strings.TrimSpace(kidAndKey[0])] = strings.TrimSpace(kidAndKey[1])
+	}
+
+	if keyFile := conf.GetString("xconfwebconfig.xconf.login_token_key_file"); keyFile != "" {
+		fileBytes, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read login token key file %s with error: %+v", keyFile, err)
+		}
+		fileKeys := map[string]string{}
+		if err := json.Unmarshal(fileBytes, &fileKeys); err != nil {
+			return nil, fmt.Errorf("login token key file %s is not a json object of kid to key: %+v", keyFile, err)
+		}
+		for kid, key := range fileKeys {
+			keys[kid] = key
+		}
+	}
+	return newLoginTokenKeys(algorithm, signingKid, keys)
+}
+
+// ValidateLoginTokenConfig checks the login token keys with the acl provider, xconfadmin issues the login tokens
+func ValidateLoginTokenConfig(conf *configuration.Config) error {
+	if conf.GetString("xconfwebconfig.xconf.authprovider", "acl") != "acl" {
+		return nil
+	}
+	_, err := NewLoginTokenKeys(conf)
+	return err
+}
+
+func newLoginTokenKeys(algorithm string, signingKid string, keys map[string]string) (*LoginTokenKeys, error) {
+	if !util.Contains(loginTokenAlgorithms, algorithm) {
+		return nil, fmt.Errorf("login token algorithm %s is not supported, one of %s is expected", algorithm, strings.Join(loginTokenAlgorithms, ","))
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no login token keys are configured, set login_token_keys, the LOGIN_TOKEN_KEYS env or login_token_key_file, or use another authprovider than acl")
+	}
+	if signingKid == "" {
+		if len(keys) > 1 {
+			return nil, errors.New("login_token_signing_kid is required when several login token keys are configured")
+		}
+		for kid := range keys {
+			signingKid = kid
+		}
+	}
+
+	loginTokenKeys := &LoginTokenKeys{
+		Algorithm:   algorithm,
+		SigningKid:  signingKid,
+		signingKeys: map[string]interface{}{},
+		verifyKeys:  map[string]interface{}{},
+	}
+	for kid, key := range keys {
+		if strings.HasPrefix(algorithm, "HS") {
+			if len(key) < minLoginTokenSecretLength {
+				return nil, fmt.Errorf("login token key %s must be at least %d bytes long", kid, minLoginTokenSecretLength)
+			}
+			loginTokenKeys.signingKeys[kid] = []byte(key)
+			loginTokenKeys.verifyKeys[kid] = []byte(key)
+		} else if privateKey, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(key)); err == nil {
+			loginTokenKeys.signingKeys[kid] = privateKey
+			loginTokenKeys.verifyKeys[kid] = &privateKey.PublicKey
+		} else if publicKey, err := jwt.ParseRSAPublicKeyFromPEM([]byte(key)); err == nil {
+			// the public key alone still verifies the tokens of a retired key
+			loginTokenKeys.verifyKeys[kid] = publicKey
+		} else {
+			return nil, fmt.Errorf("login token key %s is not a PEM encoded RSA key", kid)
+		}
+	}
+	if _, ok := loginTokenKeys.signingKeys[signingKid]; !ok {
+		return nil, fmt.Errorf("login token signing key %s is not configured", signingKid)
+	}
+	return loginTokenKeys, nil
+}
+
+// Sign signs the claims with the active key, its kid is set in the header of the token
+func (k *LoginTokenKeys) Sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(k.Algorithm), claims)
+	token.Header["kid"] = k.SigningKid
+	return token.SignedString(k.signingKeys[k.SigningKid])
+}
+
+// Parse verifies the token with the key of its kid, tokens of any other algorithm are rejected
+func (k *LoginTokenKeys) Parse(authToken string) (*jwt.Token, error) {
+	return jwt.Parse(authToken, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("kid attribute not found")
+		}
+		key, ok := k.verifyKeys[kid]
+		if !ok {
+			return nil, fmt.Errorf("kid=%s is not a known login token key", kid)
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{k.Algorithm}))
+}
+
+// SignLoginToken signs the claims of a login token issued with the acl auth provider
+func SignLoginToken(claims jwt.MapClaims) (string, error) {
+	if WebConfServer == nil || WebConfServer.LoginTokenKeys == nil {
+		return "", errors.New("login token keys are not configured")
+	}
+	return WebConfServer.LoginTokenKeys.Sign(claims)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/go-akka/configuration"
+	"github.com/golang-jwt/jwt/v4"
+	"gotest.tools/assert"
+)
+
+const (
+	testLoginTokenSecret    = "0123456789abcdef0123456789abcdef"
+	testOldLoginTokenSecret = "fedcba9876543210fedcba9876543210"
+)
+
+func withLoginTokenKeys(t *testing.T, keys *LoginTokenKeys) {
+	server, authProvider := WebConfServer, common.AuthProvider
+	WebConfServer = &WebconfigServer{LoginTokenKeys: keys}
+	common.AuthProvider = "acl"
+	t.Cleanup(func() {
+		WebConfServer, common.AuthProvider = server, authProvider
+	})
+}
+
+func loginTokenClaims(exp time.Time) jwt.MapClaims {
+	return jwt.MapClaims{"sub": "admin", "exp": exp.Unix()}
+}
+
+func TestNewLoginTokenKeysErrors(t *testing.T) {
+	_, err := newLoginTokenKeys("none", "", map[string]string{"k1": testLoginTokenSecret})
+	assert.ErrorContains(t, err, "not supported")
+
+	_, err = newLoginTokenKeys("HS256", "", map[string]string{})
+	assert.ErrorContains(t, err, "no login token keys")
+
+	_, err = newLoginTokenKeys("HS256", "", map[string]string{"k1": "xconf"})
+	assert.ErrorContains(t, err, "at least 32 bytes")
+
+	_, err = newLoginTokenKeys("HS256", "", map[string]string{"k1": testLoginTokenSecret, "k2": testOldLoginTokenSecret})
+	assert.ErrorContains(t, err, "login_token_signing_kid is required")
+
+	_, err = newLoginTokenKeys("HS256", "k3", map[string]string{"k1": testLoginTokenSecret})
+	assert.ErrorContains(t, err, "k3 is not configured")
+
+	keys, err := newLoginTokenKeys("HS256", "", map[string]string{"k1": testLoginTokenSecret})
+	assert.NilError(t, err)
+	assert.Equal(t, "k1", keys.SigningKid)
+}
+
+func TestValidateLoginTokenConfig(t *testing.T) {
+	t.Setenv(LoginTokenKeysEnv, "")
+
+	conf := configuration.ParseString(`xconfwebconfig.xconf.authprovider = "acl"`)
+	assert.ErrorContains(t, ValidateLoginTokenConfig(conf), "no login token keys")
+
+	conf = configuration.ParseString(`xconfwebconfig.xconf.login_token_keys = "k1:` + testLoginTokenSecret + `"`)
+	assert.NilError(t, ValidateLoginTokenConfig(conf))
+
+	conf = configuration.ParseString(`xconfwebconfig.xconf.authprovider = "oidc"`)
+	assert.NilError(t, ValidateLoginTokenConfig(conf))
+}
+
+func TestLoginTokenKeysRotation(t *testing.T) {
+	oldKeys, err := newLoginTokenKeys("HS256", "old", map[string]string{"old": testOldLoginTokenSecret})
+	assert.NilError(t, err)
+	oldToken, err := oldKeys.Sign(loginTokenClaims(time.Now().Add(time.Hour)))
+	assert.NilError(t, err)
+
+	keys, err := newLoginTokenKeys("HS256", "new", map[string]string{"old": testOldLoginTokenSecret, "new": testLoginTokenSecret})
+	assert.NilError(t, err)
+	newToken, err := keys.Sign(loginTokenClaims(time.Now().Add(time.Hour)))
+	assert.NilError(t, err)
+
+	token, err := keys.Parse(newToken)
+	assert.NilError(t, err)
+	assert.Equal(t, "new", token.Header["kid"])
+	_, err = keys.Parse(oldToken)
+	assert.NilError(t, err)
+
+	// once the old key is removed its tokens are rejected
+	_, err = oldKeys.Parse(newToken)
+	assert.ErrorContains(t, err, "kid=new is not a known login token key")
+}
+
+func TestLoginTokenKeysRejectUnexpectedAlgorithm(t *testing.T) {
+	keys, err := newLoginTokenKeys("HS256", "k1", map[string]string{"k1": testLoginTokenSecret})
+	assert.NilError(t, err)
+
+	noneToken := jwt.NewWithClaims(jwt.SigningMethodNone, loginTokenClaims(time.Now().Add(time.Hour)))
+	noneToken.Header["kid"] = "k1"
+	signed, err := noneToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NilError(t, err)
+	_, err = keys.Parse(signed)
+	assert.ErrorContains(t, err, "signing method none is invalid")
+
+	hs512Token := jwt.NewWithClaims(jwt.SigningMethodHS512, loginTokenClaims(time.Now().Add(time.Hour)))
+	hs512Token.Header["kid"] = "k1"
+	signed, err = hs512Token.SignedString([]byte(testLoginTokenSecret))
+	assert.NilError(t, err)
+	_, err = keys.Parse(signed)
+	assert.ErrorContains(t, err, "signing method HS512 is invalid")
+}
+
+func TestLoginTokenKeysRSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	privatePem := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	publicBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NilError(t, err)
+	publicPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicBytes})
+
+	keys, err := newLoginTokenKeys("RS256", "k1", map[string]string{"k1": string(privatePem)})
+	assert.NilError(t, err)
+	signed, err := keys.Sign(loginTokenClaims(time.Now().Add(time.Hour)))
+	assert.NilError(t, err)
+
+	// a public key only verifies
+	_, err = newLoginTokenKeys("RS256", "k1", map[string]string{"k1": string(publicPem)})
+	assert.ErrorContains(t, err, "k1 is not configured")
+	verifyKeys, err := newLoginTokenKeys("RS256", "k2", map[string]string{"k1": string(publicPem), "k2": string(privatePem)})
+	assert.NilError(t, err)
+	_, err = verifyKeys.Parse(signed)
+	assert.NilError(t, err)
+
+	_, err = newLoginTokenKeys("RS256", "k1", map[string]string{"k1": testLoginTokenSecret})
+	assert.ErrorContains(t, err, "not a PEM encoded RSA key")
+}
+
+func TestValidateAndGetLoginTokenRequiresExpiration(t *testing.T) {
+	keys, err := newLoginTokenKeys("HS256", "k1", map[string]string{"k1": testLoginTokenSecret})
+	assert.NilError(t, err)
+	withLoginTokenKeys(t, keys)
+
+	signed, err := keys.Sign(loginTokenClaims(time.Now().Add(time.Hour)))
+	assert.NilError(t, err)
+	loginToken, err := ValidateAndGetLoginToken(signed)
+	assert.NilError(t, err)
+	assert.Equal(t, "admin", loginToken.Subject)
+
+	signed, err = keys.Sign(jwt.MapClaims{"sub": "admin"})
+	assert.NilError(t, err)
+	_, err = ValidateAndGetLoginToken(signed)
+	assert.ErrorContains(t, err, "no expiration time")
+
+	signed, err = keys.Sign(loginTokenClaims(time.Now().Add(-time.Hour)))
+	assert.NilError(t, err)
+	_, err = ValidateAndGetLoginToken(signed)
+	assert.ErrorContains(t, err, "expired")
+
+	// the former hard coded secret no longer signs valid tokens
+	legacyToken := jwt.NewWithClaims(jwt.SigningMethodHS256, loginTokenClaims(time.Now().Add(time.Hour)))
+	signed, err = legacyToken.SignedString([]byte("xconf"))
+	assert.NilError(t, err)
+	_, err = ValidateAndGetLoginToken(signed)
+	assert.ErrorContains(t, err, "kid attribute not found")
+}
+
+func TestAuthValidationMiddlewareRejectsInvalidLoginToken(t *testing.T) {
+	sc := loadSampleServerConfig(t)
+	ws := NewWebconfigServer(sc, true, nil, nil)
+	keys, err := newLoginTokenKeys("HS256", "k1", map[string]string{"k1": testLoginTokenSecret})
+	assert.NilError(t, err)
+	ws.LoginTokenKeys = keys
+	authProvider := common.AuthProvider
+	common.AuthProvider = "acl"
+	t.Cleanup(func() {
+		common.AuthProvider = authProvider
+	})
+
+	called := false
+	handler := ws.AuthValidationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		assert.Equal(t, "admin", GetLoginTokenFromContext(r).Subject)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/auth/info", nil)
+	r.Header.Set(AUTH_TOKEN, "not-a-jwt")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	assert.Assert(t, !called)
+
+	signed, err := keys.Sign(loginTokenClaims(time.Now().Add(time.Hour)))
+	assert.NilError(t, err)
+	r = httptest.NewRequest(http.MethodGet, "/xconfAdminService/auth/info", nil)
+	r.Header.Set(AUTH_TOKEN, signed)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Assert(t, called)
+}
@@ -0,0 +1,463 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/common"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	"github.com/go-akka/configuration"
+	"github.com/golang-jwt/jwt/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	OidcAuthProvider       = "oidc"
+	OidcDiscoveryPath      = "/.well-known/openid-configuration"
+	IdpLoginCookieName     = "idp-login"
+	defaultOidcScopes      = "openid profile email"
+	defaultUsernameClaim   = "sub"
+	defaultGroupsClaim     = "groups"
+	idpLoginCookieMaxAge   = 600
+	oidcRandomValueLength  = 32
+	oidcApplicationId      = "oidc"
+	formUrlEncodedMimeType = "application/x-www-form-urlencoded"
+	// a failed discovery is returned again for this delay instead of calling the issuer on every request
+	oidcDiscoveryFailureTtl = 30 * time.Second
+)
+
+// IdpLogin binds a login started at the idp to the browser which comes back with the code,
+// it is kept in the idp-login cookie between the login url and the code
+type IdpLogin struct {
+	State        string `json:"state"`
+	Nonce        string `json:"nonce"`
+	CodeVerifier string `json:"codeVerifier"`
+	RedirectUri  string `json:"redirectUri"`
+}
+
+// IdpLoginFlow is implemented by the idp services which need the login state to exchange the code
+type IdpLoginFlow interface {
+	StartLogin(continueUrl string) (string, *IdpLogin, error)
+	FinishLogin(code string, state string, login *IdpLogin) (string, error)
+}
+
+// IdpTokenValidator is implemented by the idp services which validate their own tokens
+type IdpTokenValidator interface {
+	ValidateToken(token string) (*LoginToken, error)
+}
+
+// OidcConfiguration is the part of the openid provider metadata which is used
+type OidcConfiguration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+	EndSessionEndpoint    string `json:"end_session_endpoint"`
+}
+
+type oidcTokenResponse struct {
+	IdToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+}
+
+// OidcIdpService logs in with the authorization code flow and PKCE of any openid connect provider.
+// The endpoints are discovered from the issuer, and the id tokens are verified with the keys of its jwks.
+type OidcIdpService struct {
+	issuer string
+	*HttpClient
+	*IdpServiceConfig
+	scopes           string
+	usernameClaim    string
+	permissionsClaim string
+	groupsClaim      string
+	discoveryLock    sync.Mutex
+	discovery        *OidcConfiguration
+	discoveryErr     error
+	discoveryErrAt   time.Time
+}
+
+func NewOidcIdpService(conf *configuration.Config) *OidcIdpService {
+	idpServiceName = conf.GetString("xconfwebconfig.xconf.idp_service_name")
+	confKey := fmt.Sprintf("xconfwebconfig.%v.issuer", idpServiceName)
+	issuer := strings.TrimSuffix(conf.GetString(confKey), "/")
+	if util.IsBlank(issuer) {
+		panic(fmt.Errorf("%s is required", confKey))
+	}
+	return &OidcIdpService{
+		issuer:           issuer,
+		HttpClient:       NewHttpClient(conf, idpServiceName, nil),
+		IdpServiceConfig: newIdpServiceConfig(conf),
+		scopes:           conf.GetString(fmt.Sprintf("xconfwebconfig.%v.scopes", idpServiceName), defaultOidcScopes),
+		usernameClaim:    conf.GetString(fmt.Sprintf("xconfwebconfig.%v.username_claim", idpServiceName), defaultUsernameClaim),
+		permissionsClaim: conf.GetString(fmt.Sprintf("xconfwebconfig.%v.permissions_claim", idpServiceName)),
+		groupsClaim:      conf.GetString(fmt.Sprintf("xconfwebconfig.%v.groups_claim", idpServiceName), defaultGroupsClaim),
+	}
+}
+
+func (xc *OidcIdpService) IdpServiceHost() string {
+	return xc.issuer
+}
+
+// SetIdpServiceHost changes the issuer, the endpoints are discovered again
+func (xc *OidcIdpService) SetIdpServiceHost(host string) {
+	xc.discoveryLock.Lock()
+	defer xc.discoveryLock.Unlock()
+	xc.issuer = strings.TrimSuffix(host, "/")
+	xc.discovery = nil
+	xc.discoveryErr = nil
+}
+
+func (xc *OidcIdpService) GetIdpServiceConfig() *IdpServiceConfig {
+	return xc.IdpServiceConfig
+}
+
+// GetDiscovery fetches the openid configuration of the issuer once it is needed and keeps it. The issuer is
+// called without holding the lock, and a failure is kept for a short delay.
+func (xc *OidcIdpService) GetDiscovery() (*OidcConfiguration, error) {
+	xc.discoveryLock.Lock()
+	issuer, discovery, discoveryErr := xc.issuer, xc.discovery, xc.discoveryErr
+	if discoveryErr != nil && time.Since(xc.discoveryErrAt) >= oidcDiscoveryFailureTtl {
+		discoveryErr = nil
+	}
+	xc.discoveryLock.Unlock()
+	if discovery != nil || discoveryErr != nil {
+		return discovery, discoveryErr
+	}
+
+	discovery, err := xc.fetchDiscovery(issuer)
+
+	xc.discoveryLock.Lock()
+	defer xc.discoveryLock.Unlock()
+	// the issuer may have been changed meanwhile, then the result is not kept
+	if xc.issuer == issuer {
+		if err != nil {
+			xc.discoveryErr, xc.discoveryErrAt = err, time.Now()
+		} else {
+			xc.discovery, xc.discoveryErr = discovery, nil
+		}
+	}
+	return discovery, err
+}
+
+func (xc *OidcIdpService) fetchDiscovery(issuer string) (*OidcConfiguration, error) {
+	rbytes, err := xc.DoWithRetries("GET", issuer+OidcDiscoveryPath, nil, nil, nil, idpServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting openid configuration: %s", err.Error())
+	}
+	discovery := &OidcConfiguration{}
+	if err := json.Unmarshal(rbytes, discovery); err != nil {
+		return nil, fmt.Errorf("error parsing openid configuration: %s", err.Error())
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("openid configuration issuer %s does not match %s", discovery.Issuer, issuer)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JwksUri == "" {
+		return nil, errors.New("openid configuration has no authorization_endpoint, token_endpoint or jwks_uri")
+	}
+	return discovery, nil
+}
+
+// StartLogin returns the authorization url and the login state the code is exchanged with
+func (xc *OidcIdpService) StartLogin(continueUrl string) (string, *IdpLogin, error) {
+	discovery, err := xc.GetDiscovery()
+	if err != nil {
+		return "", nil, err
+	}
+	login := &IdpLogin{RedirectUri: continueUrl}
+	if login.State, err = newOidcRandomValue(); err != nil {
+		return "", nil, err
+	}
+	if login.Nonce, err = newOidcRandomValue(); err != nil {
+		return "", nil, err
+	}
+	if login.CodeVerifier, err = newOidcRandomValue(); err != nil {
+		return "", nil, err
+	}
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", xc.ClientId)
+	params.Set("redirect_uri", continueUrl)
+	params.Set("scope", xc.scopes)
+	params.Set("state", login.State)
+	params.Set("nonce", login.Nonce)
+	params.Set("code_challenge", NewPkceChallenge(login.CodeVerifier))
+	params.Set("code_challenge_method", "S256")
+	return appendQuery(discovery.AuthorizationEndpoint, params), login, nil
+}
+
+// FinishLogin checks the state returned by the idp, exchanges the code for the id token with the code verifier
+// and checks the nonce of the id token
+func (xc *OidcIdpService) FinishLogin(code string, state string, login *IdpLogin) (string, error) {
+	if login == nil || login.State == "" || subtle.ConstantTimeCompare([]byte(state), []byte(login.State)) != 1 {
+		return "", errors.New("login state does not match")
+	}
+	discovery, err := xc.GetDiscovery()
+	if err != nil {
+		return "", err
+	}
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", login.RedirectUri)
+	form.Set("client_id", xc.ClientId)
+	form.Set("code_verifier", login.CodeVerifier)
+	headers := map[string]string{
+		common.HeaderAuthorization: xc.AuthHeaderValue,
+		"Content-Type":             formUrlEncodedMimeType,
+	}
+	rbytes, err := xc.DoWithRetries("POST", discovery.TokenEndpoint, headers, []byte(form.Encode()), nil, idpServiceName)
+	if err != nil {
+		return "", fmt.Errorf("error getting token from IdpService: %s", err.Error())
+	}
+	tokenResponse := oidcTokenResponse{}
+	if err := json.Unmarshal(rbytes, &tokenResponse); err != nil {
+		return "", fmt.Errorf("error parsing token response: %s", err.Error())
+	}
+	if tokenResponse.IdToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+	claims, err := xc.parseIdToken(tokenResponse.IdToken)
+	if err != nil {
+		return "", err
+	}
+	if nonce, _ := claims["nonce"].(string); subtle.ConstantTimeCompare([]byte(nonce), []byte(login.Nonce)) != 1 {
+		return "", errors.New("id token nonce does not match")
+	}
+	return tokenResponse.IdToken, nil
+}
+
+// ValidateToken verifies the id token and maps its claims to the login token
+func (xc *OidcIdpService) ValidateToken(token string) (*LoginToken, error) {
+	claims, err := xc.parseIdToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return xc.newLoginToken(claims), nil
+}
+
+// parseIdToken verifies the signature with the jwks of the issuer, the issuer, the audience and the expiration
+func (xc *OidcIdpService) parseIdToken(token string) (jwt.MapClaims, error) {
+	discovery, err := xc.GetDiscovery()
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := jwt.Parse(token, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		if kid == "" {
+			return nil, errors.New("kid attribute not found")
+		}
+		return xc.getKeyCache(discovery.JwksUri).GetKey(kid)
+	}, jwt.WithValidMethods(idpTokenAlgorithms))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing id token: %s", err.Error())
+	}
+	claims, ok := parsed.Claims.(jwt.MapClaims)
+	if !ok || !parsed.Valid {
+		return nil, errors.New("error getting claims from id token")
+	}
+	if !claims.VerifyIssuer(discovery.Issuer, true) {
+		return nil, errors.New("id token issuer does not match")
+	}
+	if !claims.VerifyAudience(xc.ClientId, true) {
+		return nil, errors.New("id token audience does not match")
+	}
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.New("id token has no expiration time or is expired")
+	}
+	return claims, nil
+}
+
+// getKeyCache returns the cache of the signing keys of the issuer, refreshed from its jwks
+func (xc *OidcIdpService) getKeyCache(jwksUri string) *JwksCache {
+	return GetJwksCache(OidcAuthProvider, jwksUri, func(string) (map[string]interface{}, error) {
+		jsonWebKeyResponse := xc.GetJsonWebKeyResponse(jwksUri)
+		if jsonWebKeyResponse == nil {
+			return nil, fmt.Errorf("error getting jwks from %s", jwksUri)
+		}
+		return getRsaKeys(jsonWebKeyResponse), nil
+	})
+}
+
+// newLoginToken maps the username claim to the subject, the permissions claim to the rights
+// and the groups claim to the roles, which are bound to xconf roles
+func (xc *OidcIdpService) newLoginToken(claims jwt.MapClaims) *LoginToken {
+	loginToken := NewLoginToken(claims)
+	if username := getClaimValues(claims, xc.usernameClaim); len(username) > 0 {
+		loginToken.Subject = username[0]
+	}
+	if firstName, ok := claims["given_name"].(string); ok && loginToken.FirstName == "" {
+		loginToken.FirstName = firstName
+	}
+	if lastName, ok := claims["family_name"].(string); ok && loginToken.LastName == "" {
+		loginToken.LastName = lastName
+	}
+	if displayName, ok := claims["name"].(string); ok && loginToken.DisplayName == "" {
+		loginToken.DisplayName = displayName
+	}
+	rights := []string{}
+	if xc.permissionsClaim != "" {
+		rights = getClaimValues(claims, xc.permissionsClaim)
+	}
+	groups := getClaimValues(claims, xc.groupsClaim)
+	if len(rights) > 0 || len(groups) == 0 {
+		loginToken.Application = append(loginToken.Application, Application{Id: oidcApplicationId, Rights: rights})
+	}
+	for _, group := range groups {
+		loginToken.Application = append(loginToken.Application, Application{Id: oidcApplicationId, Role: group})
+	}
+	return loginToken
+}
+
+// GetFullLoginUrl can not keep the login state the code is exchanged with, the login is started with StartLogin
+func (xc *OidcIdpService) GetFullLoginUrl(continueUrl string) string {
+	log.Error("oidc logins are started with the login state")
+	return ""
+}
+
+func (xc *OidcIdpService) GetFullLogoutUrl(continueUrl string) string {
+	discovery, err := xc.GetDiscovery()
+	if err != nil || discovery.EndSessionEndpoint == "" {
+		return ""
+	}
+	params := url.Values{}
+	params.Set("client_id", xc.ClientId)
+	params.Set("post_logout_redirect_uri", continueUrl)
+	return appendQuery(discovery.EndSessionEndpoint, params)
+}
+
+// GetToken can not exchange the code without the login state of FinishLogin
+func (xc *OidcIdpService) GetToken(code string) string {
+	log.Error("oidc codes are exchanged with the login state")
+	return ""
+}
+
+func (xc *OidcIdpService) GetJsonWebKeyResponse(url string) *JsonWebKeyResponse {
+	rrbytes, err := xc.DoWithRetries("GET", url, nil, nil, nil, idpServiceName)
+	if err != nil {
+		log.Errorf("error getting jwks from %s: %s", url, err.Error())
+		return nil
+	}
+	var jsonWebKeyResponse JsonWebKeyResponse
+	if err := json.Unmarshal(rrbytes, &jsonWebKeyResponse); err != nil {
+		log.Errorf("error parsing jwks from %s: %s", url, err.Error())
+		return nil
+	}
+	return &jsonWebKeyResponse
+}
+
+// Logout has nothing to do without an end session endpoint, the login token cookie is erased anyway
+func (xc *OidcIdpService) Logout(url string) error {
+	if url == "" {
+		return nil
+	}
+	_, err := xc.DoWithRetries("GET", url, nil, nil, nil, idpServiceName)
+	return err
+}
+
+// NewPkceChallenge is the S256 code challenge of the code verifier
+func NewPkceChallenge(codeVerifier string) string {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func newOidcRandomValue() (string, error) {
+	value := make([]byte, oidcRandomValueLength)
+	if _, err := rand.Read(value); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(value), nil
+}
+
+func appendQuery(endpoint string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(endpoint, "?") {
+		separator = "&"
+	}
+	return endpoint + separator + params.Encode()
+}
+
+// getClaimValues reads a claim which is a string, a space separated string or a list of strings
+func getClaimValues(claims jwt.MapClaims, name string) []string {
+	values := []string{}
+	switch claim := claims[name].(type) {
+	case string:
+		values = append(values, strings.Fields(claim)...)
+	case []interface{}:
+		for _, value := range claim {
+			if s, ok := value.(string); ok && s != "" {
+				values = append(values, s)
+			}
+		}
+	}
+	return values
+}
+
+func NewIdpLoginCookie(login *IdpLogin) (*http.Cookie, error) {
+	loginBytes, err := json.Marshal(login)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Cookie{
+		Name:     IdpLoginCookieName,
+		Value:    base64.RawURLEncoding.EncodeToString(loginBytes),
+		Path:     "/",
+		MaxAge:   idpLoginCookieMaxAge,
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteLaxMode,
+	}, nil
+}
+
+func NewErasedIdpLoginCookie() *http.Cookie {
+	return &http.Cookie{
+		Name:     IdpLoginCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   true,
+	}
+}
+
+func GetIdpLoginFromCookie(r *http.Request) (*IdpLogin, error) {
+	cookie, err := r.Cookie(IdpLoginCookieName)
+	if err != nil {
+		return nil, err
+	}
+	loginBytes, err := base64.RawURLEncoding.DecodeString(cookie.Value)
+	if err != nil {
+		return nil, err
+	}
+	login := &IdpLogin{}
+	if err := json.Unmarshal(loginBytes, login); err != nil {
+		return nil, err
+	}
+	return login, nil
+}
@@ -0,0 +1,290 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-akka/configuration"
+	"github.com/golang-jwt/jwt/v4"
+	"gotest.tools/assert"
+)
+
+const (
+	testOidcClientId = "xconf"
+	testOidcKid      = "idp-key-1"
+	testOidcCode     = "test-code"
+	testRedirectUri  = "http://localhost:8081/oidc/code"
+)
+
+// stubIdp is a local openid provider: discovery, jwks and a token endpoint checking the PKCE code verifier
+type stubIdp struct {
+	*httptest.Server
+	key       *rsa.PrivateKey
+	lock      sync.Mutex
+	challenge string
+	nonce     string
+	claims    jwt.MapClaims
+}
+
+func newStubIdp(t *testing.T) *stubIdp {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	idp := &stubIdp{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc(OidcDiscoveryPath, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(OidcConfiguration{
+			Issuer:                idp.URL,
+			AuthorizationEndpoint: idp.URL + "/authorize",
+			TokenEndpoint:         idp.URL + "/token",
+			JwksUri:               idp.URL + "/jwks",
+			EndSessionEndpoint:    idp.URL + "/logout",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(JsonWebKeyResponse{Keys: []JsonWebKey{{
+			KeyType: "RSA",
+			Use:     "sig",
+			Kid:     testOidcKid,
+			Alg:     "RS256",
+			N:       base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+			E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		idp.lock.Lock()
+		defer idp.lock.Unlock()
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != testOidcCode ||
+			r.Form.Get("redirect_uri") != testRedirectUri || NewPkceChallenge(r.Form.Get("code_verifier")) != idp.challenge {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_grant"}`))
+			return
+		}
+		claims := jwt.MapClaims{"nonce": idp.nonce}
+		for k, v := range idp.claims {
+			claims[k] = v
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": idp.sign(t, claims), "token_type": "Bearer"})
+	})
+	idp.Server = httptest.NewServer(mux)
+	t.Cleanup(idp.Close)
+	return idp
+}
+
+func (idp *stubIdp) sign(t *testing.T, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testOidcKid
+	signed, err := token.SignedString(idp.key)
+	assert.NilError(t, err)
+	return signed
+}
+
+func (idp *stubIdp) idTokenClaims(exp time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":               idp.URL,
+		"aud":               testOidcClientId,
+		"sub":               "user-1",
+		"email":             "user1@example.com",
+		"exp":               exp.Unix(),
+		"xconf_permissions": []interface{}{"read-firmware-stb", "write-firmware-stb"},
+		"groups":            []interface{}{"firmware-admins"},
+	}
+}
+
+// authorize is what the idp does when the user logs in: it remembers the challenge and nonce of the login url
+func (idp *stubIdp) authorize(t *testing.T, loginUrl string) url.Values {
+	parsed, err := url.Parse(loginUrl)
+	assert.NilError(t, err)
+	params := parsed.Query()
+	idp.lock.Lock()
+	defer idp.lock.Unlock()
+	idp.challenge = params.Get("code_challenge")
+	idp.nonce = params.Get("nonce")
+	return params
+}
+
+func newTestOidcIdpService(t *testing.T, idp *stubIdp) *OidcIdpService {
+	conf := configuration.ParseString(fmt.Sprintf(`
+xconfwebconfig {
+	xconf {
+		authprovider = "oidc"
+		idp_service_name = "oidc_idp"
+	}
+	oidc_idp {
+		issuer = "%s/"
+		client_id = "%s"
+		client_secret = "secret"
+		permissions_claim = "xconf_permissions"
+		retries = 0
+	}
+}`, idp.URL, testOidcClientId))
+	connector := NewIdpServiceConnector(conf, nil)
+	oidc, ok := connector.(*OidcIdpService)
+	assert.Assert(t, ok)
+	return oidc
+}
+
+func TestOidcIdpServiceLogin(t *testing.T) {
+	idp := newStubIdp(t)
+	idp.claims = idp.idTokenClaims(time.Now().Add(time.Hour))
+	oidc := newTestOidcIdpService(t, idp)
+
+	loginUrl, login, err := oidc.StartLogin(testRedirectUri)
+	assert.NilError(t, err)
+	params := idp.authorize(t, loginUrl)
+	assert.Equal(t, idp.URL+"/authorize", loginUrl[:len(idp.URL)+len("/authorize")])
+	assert.Equal(t, "code", params.Get("response_type"))
+	assert.Equal(t, testOidcClientId, params.Get("client_id"))
+	assert.Equal(t, testRedirectUri, params.Get("redirect_uri"))
+	assert.Equal(t, "S256", params.Get("code_challenge_method"))
+	assert.Equal(t, login.State, params.Get("state"))
+	assert.Equal(t, NewPkceChallenge(login.CodeVerifier), params.Get("code_challenge"))
+
+	token, err := oidc.FinishLogin(testOidcCode, login.State, login)
+	assert.NilError(t, err)
+
+	loginToken, err := oidc.ValidateToken(token)
+	assert.NilError(t, err)
+	assert.Equal(t, "user-1", loginToken.Subject)
+	assert.Equal(t, "user1@example.com", loginToken.Email)
+	assert.DeepEqual(t, []string{"read-firmware-stb", "write-firmware-stb"}, getPermissionsFromLoginToken(loginToken))
+	groups := []string{}
+	for _, application := range loginToken.Application {
+		if application.Role != "" {
+			groups = append(groups, application.Role)
+		}
+	}
+	assert.DeepEqual(t, []string{"firmware-admins"}, groups)
+
+	assert.Equal(t, idp.URL+"/logout?client_id=xconf&post_logout_redirect_uri=http%3A%2F%2Flocalhost%3A8081", oidc.GetFullLogoutUrl("http://localhost:8081"))
+}
+
+func TestOidcIdpServiceRejectsLoginState(t *testing.T) {
+	idp := newStubIdp(t)
+	idp.claims = idp.idTokenClaims(time.Now().Add(time.Hour))
+	oidc := newTestOidcIdpService(t, idp)
+
+	loginUrl, login, err := oidc.StartLogin(testRedirectUri)
+	assert.NilError(t, err)
+	idp.authorize(t, loginUrl)
+
+	_, err = oidc.FinishLogin(testOidcCode, "other-state", login)
+	assert.ErrorContains(t, err, "login state does not match")
+
+	_, err = oidc.FinishLogin(testOidcCode, login.State, nil)
+	assert.ErrorContains(t, err, "login state does not match")
+
+	// the code verifier of another login does not match the challenge
+	other := *login
+	other.CodeVerifier = "other-verifier"
+	_, err = oidc.FinishLogin(testOidcCode, login.State, &other)
+	assert.ErrorContains(t, err, "error getting token")
+
+	// the id token of another login has another nonce
+	other = *login
+	other.Nonce = "other-nonce"
+	_, err = oidc.FinishLogin(testOidcCode, login.State, &other)
+	assert.ErrorContains(t, err, "nonce does not match")
+}
+
+func TestOidcIdpServiceValidateToken(t *testing.T) {
+	idp := newStubIdp(t)
+	oidc := newTestOidcIdpService(t, idp)
+
+	_, err := oidc.ValidateToken(idp.sign(t, idp.idTokenClaims(time.Now().Add(-time.Minute))))
+	assert.ErrorContains(t, err, "expired")
+
+	claims := idp.idTokenClaims(time.Now().Add(time.Hour))
+	claims["aud"] = "other-client"
+	_, err = oidc.ValidateToken(idp.sign(t, claims))
+	assert.ErrorContains(t, err, "audience does not match")
+
+	claims = idp.idTokenClaims(time.Now().Add(time.Hour))
+	claims["iss"] = "https://other-issuer.example.com"
+	_, err = oidc.ValidateToken(idp.sign(t, claims))
+	assert.ErrorContains(t, err, "issuer does not match")
+
+	// a token signed with another key under the same kid
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NilError(t, err)
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.idTokenClaims(time.Now().Add(time.Hour)))
+	token.Header["kid"] = testOidcKid
+	signed, err := token.SignedString(otherKey)
+	assert.NilError(t, err)
+	_, err = oidc.ValidateToken(signed)
+	assert.ErrorContains(t, err, "error parsing id token")
+
+	// the hmac algorithm is not accepted from the idp
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, idp.idTokenClaims(time.Now().Add(time.Hour)))
+	hmacToken.Header["kid"] = testOidcKid
+	signed, err = hmacToken.SignedString([]byte(testLoginTokenSecret))
+	assert.NilError(t, err)
+	_, err = oidc.ValidateToken(signed)
+	assert.ErrorContains(t, err, "error parsing id token")
+}
+
+func TestIdpLoginCookie(t *testing.T) {
+	login := &IdpLogin{State: "state", Nonce: "nonce", CodeVerifier: "verifier", RedirectUri: testRedirectUri}
+	cookie, err := NewIdpLoginCookie(login)
+	assert.NilError(t, err)
+	assert.Assert(t, cookie.HttpOnly)
+	assert.Assert(t, cookie.Secure)
+
+	r := httptest.NewRequest(http.MethodGet, "/oidc/code?code=x&state=state", nil)
+	_, err = GetIdpLoginFromCookie(r)
+	assert.Assert(t, err != nil)
+
+	r.AddCookie(cookie)
+	parsed, err := GetIdpLoginFromCookie(r)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, login, parsed)
+}
+
+func TestOidcDiscoveryFailureIsKept(t *testing.T) {
+	idp := newStubIdp(t)
+	oidc := newTestOidcIdpService(t, idp)
+	issuer := oidc.IdpServiceHost()
+
+	oidc.SetIdpServiceHost(idp.URL + "/unknown")
+	_, err := oidc.GetDiscovery()
+	assert.ErrorContains(t, err, "openid configuration")
+	failedAt := oidc.discoveryErrAt
+	_, err = oidc.GetDiscovery()
+	assert.ErrorContains(t, err, "openid configuration")
+	assert.Equal(t, failedAt, oidc.discoveryErrAt)
+
+	// changing the issuer discovers it again
+	oidc.SetIdpServiceHost(issuer)
+	discovery, err := oidc.GetDiscovery()
+	assert.NilError(t, err)
+	assert.Equal(t, idp.URL+"/token", discovery.TokenEndpoint)
+	assert.Equal(t, "", oidc.GetFullLoginUrl(testRedirectUri))
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+// ReplayRecorder collects the response of a replayed handler
+type ReplayRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *ReplayRecorder) Header() http.Header {
+	return rec.header
+}
+
+func (rec *ReplayRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.body.Write(b)
+}
+
+func (rec *ReplayRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+}
+
+func (rec *ReplayRecorder) Status() int {
+	return rec.status
+}
+
+func (rec *ReplayRecorder) Body() []byte {
+	return rec.body.Bytes()
+}
+
+// ReplayHandler calls an admin handler with a copy of the request, so an entity written on behalf of
+// the user goes through the same validation as a request of the user. The body is set the way the
+// middleware leaves it, because handlers read it from the response writer.
+func ReplayHandler(r *http.Request, handler http.HandlerFunc, method string, body []byte, vars map[string]string, query url.Values) *ReplayRecorder {
+	req := r.Clone(r.Context())
+	req.Method = method
+	req.URL = &url.URL{
+		Path:     r.URL.Path,
+		RawQuery: query.Encode(),
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.ContentLength = int64(len(body))
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	req = mux.SetURLVars(req, vars)
+	req, overriddenWrites := WithOverriddenWrites(req)
+
+	recorder := &ReplayRecorder{header: make(http.Header)}
+	xw := xwhttp.NewXResponseWriter(recorder)
+	xw.SetBody(string(body))
+	handler(xw, req)
+	overriddenWrites.Record(recorder.Status())
+	return recorder
+}
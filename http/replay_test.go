@@ -0,0 +1,55 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+	"gotest.tools/assert"
+)
+
+func TestReplayRecorder(t *testing.T) {
+	rec := &ReplayRecorder{header: make(http.Header)}
+	rec.Write([]byte("ok"))
+	rec.WriteHeader(http.StatusBadRequest)
+	assert.Equal(t, http.StatusOK, rec.Status())
+	assert.Equal(t, "ok", string(rec.Body()))
+
+	rec = &ReplayRecorder{header: make(http.Header)}
+	rec.WriteHeader(http.StatusConflict)
+	assert.Equal(t, http.StatusConflict, rec.Status())
+}
+
+func TestReplayHandler(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		xw, ok := w.(*xwhttp.XResponseWriter)
+		assert.Assert(t, ok)
+		assert.Equal(t, `{"id":"1"}`, xw.Body())
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "stb", r.URL.Query().Get("applicationType"))
+		assert.Equal(t, "1", mux.Vars(r)["id"])
+		w.WriteHeader(http.StatusCreated)
+	}
+	r := httptest.NewRequest(http.MethodGet, "/xconfAdminService/history/restore", nil)
+	rec := ReplayHandler(r, handler, http.MethodPut, []byte(`{"id":"1"}`), map[string]string{"id": "1"}, url.Values{"applicationType": []string{"stb"}})
+	assert.Equal(t, http.StatusCreated, rec.Status())
+}
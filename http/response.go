@@ -2,7 +2,9 @@ package http
 
 import (
 	"bytes"
+	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -125,6 +127,34 @@ func WriteAdminErrorResponse(w http.ResponseWriter, status int, errMsg string) {
 	writeByMarshal(w, status, resp)
 }
 
+// StatusError is an error answered with its own status code, the error itself is the json body of the response
+type StatusError interface {
+	error
+	StatusCode() int
+}
+
+// GetErrorStatusCode returns the status code of a StatusError or of an xconf error
+func GetErrorStatusCode(err error) int {
+	var statusErr StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode()
+	}
+	return xwcommon.GetXconfErrorStatusCode(err)
+}
+
+func writeStatusError(w http.ResponseWriter, err error) bool {
+	var statusErr StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	body, merr := json.Marshal(statusErr)
+	if merr != nil {
+		return false
+	}
+	WriteXconfResponse(w, statusErr.StatusCode(), body)
+	return true
+}
+
 func Error(w http.ResponseWriter, err error) {
 	status := xwcommon.GetXconfErrorStatusCode(err)
 	switch status {
@@ -137,6 +167,9 @@ func Error(w http.ResponseWriter, err error) {
 }
 
 func AdminError(w http.ResponseWriter, err error) {
+	if writeStatusError(w, err) {
+		return
+	}
 	status := xwcommon.GetXconfErrorStatusCode(err)
 	WriteAdminErrorResponse(w, status, err.Error())
 }
@@ -158,6 +191,9 @@ func WriteXconfResponse(w http.ResponseWriter, status int, data []byte) {
 }
 
 func WriteXconfErrorResponse(w http.ResponseWriter, err error) {
+	if writeStatusError(w, err) {
+		return
+	}
 	status := xwcommon.GetXconfErrorStatusCode(err)
 	w.Header().Set("Content-type", "application/json")
 	addMoracideTagsAsResponseHeaders(w)
@@ -2,6 +2,7 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -100,6 +101,31 @@ func TestWriteXconfErrorResponse(t *testing.T) {
 	assert.Assert(t, w.Body.Len() > 0)
 }
 
+type testStatusError struct {
+	Status  int    `json:"status"`
+	Message string `json:"message"`
+}
+
+func (e *testStatusError) Error() string {
+	return e.Message
+}
+
+func (e *testStatusError) StatusCode() int {
+	return e.Status
+}
+
+func TestStatusError(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &testStatusError{Status: http.StatusConflict, Message: "conflict"})
+	assert.Equal(t, http.StatusConflict, GetErrorStatusCode(err))
+
+	for _, write := range []func(http.ResponseWriter, error){AdminError, WriteXconfErrorResponse} {
+		w := httptest.NewRecorder()
+		write(w, err)
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `{"status":409,"message":"conflict"}`, w.Body.String())
+	}
+}
+
 func TestWriteXconfResponseAsText(t *testing.T) {
 	w := httptest.NewRecorder()
 
@@ -128,6 +128,9 @@ type WebValidator struct {
 
 	// storage for retrieved keys
 	Keys map[string]interface{}
+
+	// keys shared by all validators of the keys url, used instead of Keys when set
+	Cache *JwksCache
 }
 
 // ErrNoKIDParameter indicates that the provided JWT is missing the "kid"
@@ -145,11 +148,25 @@ func (v *WebValidator) fetchToken(token *jwt.Token) (interface{}, error) {
 		return nil, ErrNoKIDParameter
 	}
 
+	if v.Cache != nil {
+		return v.Cache.GetKey(kid)
+	}
+
 	// check in local storage
 	if key, ok := v.Keys[kid]; ok {
 		return key, nil
 	}
 
+	key, err := v.FetchKey(kid)
+	if err != nil {
+		return nil, err
+	}
+	v.Keys[kid] = key
+	return key, nil
+}
+
+// FetchKey retrieves the public key of the kid from the keys url
+func (v *WebValidator) FetchKey(kid string) (interface{}, error) {
 	var (
 		start  = time.Now()
 		status = "failure"
@@ -201,8 +218,6 @@ func (v *WebValidator) fetchToken(token *jwt.Token) (interface{}, error) {
 		return nil, fmt.Errorf("retrieve key is not a valid rsa pub key: %w", err)
 	}
 
-	v.Keys[kid] = key
-
 	status = "success"
 
 	return key, nil
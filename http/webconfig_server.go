@@ -7,8 +7,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"mime"
 	"net/http"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -40,6 +42,12 @@ const (
 	DEV_PROFILE = "dev"
 )
 
+// the bodies of these content types are left unread for the import handler to stream them
+var (
+	streamedUploadContentTypes = []string{"text/csv", "application/x-ndjson"}
+	streamedUploadPath         = regexp.MustCompile(`^/taggingService/tags/[^/]+/import$`)
+)
+
 var WebConfServer *WebconfigServer
 
 // len(response) < lowerBound               ==> convert to json
@@ -60,6 +68,8 @@ type WebconfigServer struct {
 	*tracing.XpcTracer
 	tlsConfig             *tls.Config
 	DistributedLockConfig *DistributedLockConfig
+	LoginTokenKeys        *LoginTokenKeys
+	JwksCacheConfig       *JwksCacheConfig
 	notLoggedHeaders      []string
 	metricsEnabled        bool
 	testOnly              bool
@@ -185,6 +195,19 @@ func NewWebconfigServer(sc *common.ServerConfig, testOnly bool, dc db.DatabaseCl
 	}
 	xpcTracer := tracing.NewXpcTracer(sc.Config)
 
+	// login tokens are issued and verified by xconfadmin itself with the acl provider
+	var loginTokenKeys *LoginTokenKeys
+	if idpAuthProvider == "acl" {
+		loginTokenKeys, err = NewLoginTokenKeys(conf)
+		if err != nil {
+			if !testOnly {
+				// main validates the config first, see ValidateLoginTokenConfig
+				panic(fmt.Errorf("invalid login token configuration: %w", err))
+			}
+			log.Warn(err.Error())
+		}
+	}
+
 	WebConfServer = &WebconfigServer{
 		tlsConfig:                 tlsConfig,
 		notLoggedHeaders:          notLoggedHeaders,
@@ -198,6 +221,8 @@ func NewWebconfigServer(sc *common.ServerConfig, testOnly bool, dc db.DatabaseCl
 		GroupServiceSyncConnector: NewGroupServiceSyncConnector(conf, tlsConfig),
 		TaggingApiConfig:          taggingapi_config.NewTaggingApiConfig(conf),
 		DistributedLockConfig:     NewDistributedLockConfig(conf),
+		LoginTokenKeys:            loginTokenKeys,
+		JwksCacheConfig:           NewJwksCacheConfig(conf),
 		XconfConnector:            NewXconfConnector(conf, "xconf", tlsConfig),
 		XW_XconfServer:            xhttp.NewXconfServer(sc, testOnly, ec.xw_ect),
 		IdpLoginPath:              idpLoginPath,
@@ -253,18 +278,6 @@ func IsDevProfile() bool {
 	return DEV_PROFILE == defaultProfiles[0]
 }
 
-func getPermissions() (permissions []string) {
-	if IsDevProfile() {
-		permissions = []string{
-			xcommon.WRITE_COMMON, xcommon.READ_COMMON,
-			xcommon.WRITE_FIRMWARE_ALL, xcommon.READ_FIRMWARE_ALL,
-			xcommon.WRITE_DCM_ALL, xcommon.READ_DCM_ALL,
-			xcommon.WRITE_TELEMETRY_ALL, xcommon.READ_TELEMETRY_ALL,
-			xcommon.READ_CHANGES_ALL, xcommon.WRITE_CHANGES_ALL}
-	}
-	return permissions
-}
-
 func (s *WebconfigServer) AuthValidationMiddleware(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Server", AppName())
@@ -272,8 +285,20 @@ func (s *WebconfigServer) AuthValidationMiddleware(next http.Handler) http.Handl
 
 		ctx := r.Context()
 
-		// Check for SAT V2 token
-		if satToken := getSatTokenFromRequest(r); satToken != "" {
+		// Check for a service account api token, which is also sent in the Authorization header
+		if token := getApiTokenFromRequest(r); token != "" {
+			if apiToken, err := ValidateApiToken(token); err != nil {
+				log.Error(err.Error())
+				http.Error(w, "invalid api token", http.StatusUnauthorized)
+				return
+			} else {
+				r.Header.Set(AUTH_SUBJECT, apiToken.ServiceAccount)
+
+				// the permissions of the token are checked like the ones of a login token
+				ctx = context.WithValue(ctx, CTX_KEY_API_TOKEN, apiToken)
+				ctx = context.WithValue(ctx, CTX_KEY_PERMISSIONS, apiToken.Permissions)
+			}
+		} else if satToken := getSatTokenFromRequest(r); satToken != "" {
 			if subject, capabilities, err := getSubjectAndCapabilitiesFromSatToken(satToken, s.VerifyStageHost); err != nil {
 				log.Error(err.Error())
 				http.Error(w, "invalid SAT token", http.StatusUnauthorized)
@@ -287,12 +312,8 @@ func (s *WebconfigServer) AuthValidationMiddleware(next http.Handler) http.Handl
 		} else if authToken := getLoginTokenFromRequest(r); authToken != "" {
 			if LoginToken, err := ValidateAndGetLoginToken(authToken); err != nil {
 				log.Error(err.Error())
-				//http.Error(w, "invalid auth token", http.StatusUnauthorized)
-				//Cheking and Setting DEV PROFILES to allow and display ALL the TABS
-				//ctx = context.WithValue(ctx, CTX_KEY_TOKEN, LoginToken)
-				permissions := getPermissions()
-				ctx = context.WithValue(ctx, CTX_KEY_PERMISSIONS, permissions)
-				//return
+				http.Error(w, "invalid auth token", http.StatusUnauthorized)
+				return
 			} else {
 				//THIS IS LOGIN TOKEN SUCCESS CASE
 				r.Header.Set(AUTH_SUBJECT, LoginToken.Subject)
@@ -310,11 +331,12 @@ func (s *WebconfigServer) AuthValidationMiddleware(next http.Handler) http.Handl
 			return
 		}
 
-		newReq := r.WithContext(ctx)
+		newReq, overriddenWrites := WithOverriddenWrites(r.WithContext(ctx))
 		xw := s.logRequestStarts(w, newReq)
 		defer s.logRequestEnds(xw, newReq)
 
 		next.ServeHTTP(xw, newReq)
+		overriddenWrites.Record(xw.Status())
 	}
 	return http.HandlerFunc(fn)
 }
@@ -346,6 +368,24 @@ func getHeadersForLogAsMap(header http.Header, notLoggedHeaders []string) map[st
 	return loggedHeaders
 }
 
+// IsStreamedUpload is true for the file uploads to the import route, they are too large to be read and logged
+// as the request body
+func IsStreamedUpload(r *http.Request) bool {
+	if r.Method != http.MethodPost || !streamedUploadPath.MatchString(r.URL.Path) {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	for _, contentType := range streamedUploadContentTypes {
+		if mediaType == contentType {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *WebconfigServer) logRequestStarts(w http.ResponseWriter, r *http.Request) *xhttp.XResponseWriter {
 	// extract the token from the header
 	authorization := r.Header.Get("Authorization")
@@ -405,7 +445,7 @@ func (s *WebconfigServer) logRequestStarts(w http.ResponseWriter, r *http.Reques
 
 	xwriter := xhttp.NewXResponseWriter(w, time.Now(), token, fields)
 
-	if r.Method == "POST" || r.Method == "PUT" {
+	if (r.Method == "POST" || r.Method == "PUT") && !IsStreamedUpload(r) {
 		var body string
 		if r.Body != nil {
 			b, err := ioutil.ReadAll(r.Body)
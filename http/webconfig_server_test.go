@@ -1,6 +1,7 @@
 package http
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -123,6 +124,28 @@ func TestLogRequestStartsObfuscatesMsgpack(t *testing.T) {
 	}
 }
 
+func TestLogRequestStartsLeavesStreamedUploadUnread(t *testing.T) {
+	sc := loadSampleServerConfig(t)
+	ws := NewWebconfigServer(sc, true, nil, nil)
+	r := httptest.NewRequest(http.MethodPost, "/taggingService/tags/t1/import", strings.NewReader("AABBCCDDEEFF\n"))
+	r.Header.Set("Content-Type", "text/csv; charset=utf-8")
+	xw := ws.logRequestStarts(httptest.NewRecorder(), r)
+	if xw.Body() != "" {
+		t.Fatalf("expected the streamed body to be left unread")
+	}
+	if rbytes, _ := io.ReadAll(r.Body); string(rbytes) != "AABBCCDDEEFF\n" {
+		t.Fatalf("body mismatch")
+	}
+
+	// only the import route streams its body
+	r = httptest.NewRequest(http.MethodPut, "/taggingService/tags/t1/members", strings.NewReader("AABBCCDDEEFF\n"))
+	r.Header.Set("Content-Type", "text/csv")
+	xw = ws.logRequestStarts(httptest.NewRecorder(), r)
+	if xw.Body() != "AABBCCDDEEFF\n" {
+		t.Fatalf("expected the body of another route to be read")
+	}
+}
+
 // func TestLogRequestEndsPasswordMaskAndTruncate(t *testing.T) {
 // 	sc := loadSampleServerConfig(t)
 // 	ws := NewWebconfigServer(sc, true, nil, nil)
@@ -69,6 +69,12 @@ func main() {
 		os.Setenv("SAT_CLIENT_SECRET", "dGVzdFhwY0tleQo=")
 	}
 
+	// the login tokens of the acl provider can not be issued without keys
+	if err := xhttp.ValidateLoginTokenConfig(sc.Config); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration %s: %v\n", *configFile, err)
+		os.Exit(1)
+	}
+
 	server := xhttp.NewWebconfigServer(sc, false, nil, nil)
 	defer server.XW_XconfServer.StopXpcTracer()
 
@@ -0,0 +1,172 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// TokenPrefix marks api tokens, so they can be told from SAT tokens in the Authorization header
+const TokenPrefix = "xat_"
+
+// ApiToken is a token issued to a service account. Only the sha256 of the secret is stored,
+// the token itself is returned once when it is created. LastUsed is read from the ApiTokenUse of the token.
+type ApiToken struct {
+	ID             string   `json:"id"`
+	ServiceAccount string   `json:"serviceAccount"`
+	Description    string   `json:"description,omitempty"`
+	Permissions    []string `json:"permissions"`
+	TokenHash      string   `json:"tokenHash,omitempty"`
+	CreatedBy      string   `json:"createdBy"`
+	Created        int64    `json:"created"`
+	ExpiresAt      int64    `json:"expiresAt"`
+	LastUsed       int64    `json:"lastUsed,omitempty"`
+	RevokedBy      string   `json:"revokedBy,omitempty"`
+	Revoked        int64    `json:"revoked,omitempty"`
+}
+
+// ApiTokenUse is kept apart from the token, so recording a use never writes back a token which has been revoked meanwhile
+type ApiTokenUse struct {
+	ID       string `json:"id"`
+	LastUsed int64  `json:"lastUsed"`
+}
+
+func NewApiTokenInf() interface{} {
+	return &ApiToken{}
+}
+
+func NewApiTokenUseInf() interface{} {
+	return &ApiTokenUse{}
+}
+
+func (t *ApiToken) IsRevoked() bool {
+	return t.Revoked > 0
+}
+
+// IsExpired compares the expiry with now in epoch milliseconds
+func (t *ApiToken) IsExpired(now int64) bool {
+	return t.ExpiresAt <= now
+}
+
+// Matches compares the hash of the secret in constant time
+func (t *ApiToken) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(t.TokenHash), []byte(HashSecret(secret))) == 1
+}
+
+// WithoutHash is the token as it is returned by the api
+func (t *ApiToken) WithoutHash() *ApiToken {
+	token := *t
+	token.TokenHash = ""
+	return &token
+}
+
+func HashSecret(secret string) string {
+	hash := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(hash[:])
+}
+
+// GenerateToken returns a new id with its random secret, and the token made of both
+func GenerateToken() (id string, secret string, token string, err error) {
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", "", err
+	}
+	id = uuid.New().String()
+	secret = base64.RawURLEncoding.EncodeToString(secretBytes)
+	return id, secret, TokenPrefix + id + "." + secret, nil
+}
+
+// ParseToken splits the token into the id of the ApiToken and its secret
+func ParseToken(token string) (id string, secret string, ok bool) {
+	if !strings.HasPrefix(token, TokenPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(token, TokenPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+func GetOneApiToken(id string) *ApiToken {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_API_TOKEN, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no ApiToken found for Id: %s", id))
+		return nil
+	}
+	return inst.(*ApiToken)
+}
+
+func GetApiTokenList() []*ApiToken {
+	all := []*ApiToken{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_API_TOKEN, 0)
+	if err != nil {
+		log.Warn("no ApiToken found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*ApiToken))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created < all[j].Created
+	})
+	return all
+}
+
+func SetOneApiToken(token *ApiToken) error {
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_API_TOKEN, token.ID, tokenBytes)
+}
+
+func SetApiTokenLastUsed(id string, lastUsed int64) error {
+	useBytes, err := json.Marshal(&ApiTokenUse{ID: id, LastUsed: lastUsed})
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_API_TOKEN_USE, id, useBytes)
+}
+
+// GetApiTokenLastUsed returns the last use of every token which has been used, by token id
+func GetApiTokenLastUsed() map[string]int64 {
+	lastUsed := map[string]int64{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_API_TOKEN_USE, 0)
+	if err != nil {
+		return lastUsed
+	}
+	for _, inst := range list {
+		use := inst.(*ApiTokenUse)
+		lastUsed[use.ID] = use.LastUsed
+	}
+	return lastUsed
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package apitoken
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAndParseToken(t *testing.T) {
+	id, secret, token, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !strings.HasPrefix(token, TokenPrefix) {
+		t.Fatalf("expected prefix %s in %s", TokenPrefix, token)
+	}
+	parsedId, parsedSecret, ok := ParseToken(token)
+	if !ok || parsedId != id || parsedSecret != secret {
+		t.Fatalf("expected %s and %s got %s and %s", id, secret, parsedId, parsedSecret)
+	}
+
+	for _, invalid := range []string{"", "abc", TokenPrefix, TokenPrefix + id, TokenPrefix + "." + secret, TokenPrefix + id + "."} {
+		if _, _, ok := ParseToken(invalid); ok {
+			t.Fatalf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestApiTokenMatchesOnlyItsSecret(t *testing.T) {
+	_, secret, _, err := GenerateToken()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	apiToken := &ApiToken{TokenHash: HashSecret(secret), ExpiresAt: 1000}
+	if !apiToken.Matches(secret) {
+		t.Fatalf("expected the secret to match")
+	}
+	if apiToken.Matches(secret + "x") {
+		t.Fatalf("expected another secret not to match")
+	}
+	if apiToken.TokenHash == secret {
+		t.Fatalf("expected the secret not to be stored")
+	}
+	if apiToken.WithoutHash().TokenHash != "" || apiToken.TokenHash == "" {
+		t.Fatalf("expected the hash to be removed from the copy only")
+	}
+	if apiToken.IsExpired(999) || !apiToken.IsExpired(1000) {
+		t.Fatalf("expected the token to expire at 1000")
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Approval is a single sign-off on a pending change
+type Approval struct {
+	User      string `json:"user"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// ChangeApproval keeps every approval given to a Change or TelemetryTwoChange.
+// It shares its id with the change, so it also describes the resulting ApprovedChange.
+type ChangeApproval struct {
+	ID              string     `json:"id"`
+	EntityID        string     `json:"entityId"`
+	EntityType      string     `json:"entityType"`
+	ApplicationType string     `json:"applicationType"`
+	Author          string     `json:"author"`
+	Required        int        `json:"required"`
+	Approvals       []Approval `json:"approvals"`
+	Updated         int64      `json:"updated"`
+}
+
+func NewChangeApprovalInf() interface{} {
+	return &ChangeApproval{
+		Approvals: []Approval{},
+	}
+}
+
+func (a *ChangeApproval) HasApproved(user string) bool {
+	for _, approval := range a.Approvals {
+		if strings.EqualFold(approval.User, user) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ChangeApproval) IsSatisfied() bool {
+	return len(a.Approvals) >= a.Required
+}
+
+func (a *ChangeApproval) ApprovedUsers() []string {
+	users := make([]string, 0, len(a.Approvals))
+	for _, approval := range a.Approvals {
+		users = append(users, approval.User)
+	}
+	return users
+}
+
+func GetOneChangeApproval(id string) *ChangeApproval {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_CHANGE_APPROVAL, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no ChangeApproval found for Id: %s", id))
+		return nil
+	}
+	return inst.(*ChangeApproval)
+}
+
+func SetOneChangeApproval(approval *ChangeApproval) error {
+	approval.Updated = xwutil.GetTimestamp(time.Now().UTC())
+
+	approvalBytes, err := json.Marshal(approval)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_CHANGE_APPROVAL, approval.ID, approvalBytes)
+}
+
+func DeleteOneChangeApproval(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_CHANGE_APPROVAL, id)
+}
@@ -0,0 +1,135 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// review actions
+const (
+	Rejected         = "REJECTED"
+	ChangesRequested = "CHANGES_REQUESTED"
+	Reverted         = "REVERTED"
+	Canceled         = "CANCELED"
+)
+
+// ChangeComment is a comment on a change, replies point to the comment they answer with ParentID
+type ChangeComment struct {
+	ID       string `json:"id"`
+	ChangeID string `json:"changeId"`
+	ParentID string `json:"parentId,omitempty"`
+	Author   string `json:"author"`
+	Text     string `json:"text"`
+	Created  int64  `json:"created"`
+}
+
+// ChangeReview records a decision taken on a change other than approving it, with the reason
+// and a snapshot of the change, so it can be audited after the change itself is removed
+type ChangeReview struct {
+	ID              string          `json:"id"`
+	ChangeID        string          `json:"changeId"`
+	Kind            string          `json:"kind"`
+	EntityID        string          `json:"entityId"`
+	EntityType      string          `json:"entityType"`
+	ApplicationType string          `json:"applicationType"`
+	ChangeAuthor    string          `json:"changeAuthor"`
+	Reviewer        string          `json:"reviewer"`
+	Action          string          `json:"action"`
+	Reason          string          `json:"reason,omitempty"`
+	Change          json.RawMessage `json:"change,omitempty"`
+	Created         int64           `json:"created"`
+}
+
+func NewChangeCommentInf() interface{} {
+	return &ChangeComment{}
+}
+
+func NewChangeReviewInf() interface{} {
+	return &ChangeReview{}
+}
+
+func GetChangeCommentsByChangeId(changeId string) []*ChangeComment {
+	comments := []*ChangeComment{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_CHANGE_COMMENT, 0)
+	if err != nil {
+		log.Warn("no ChangeComment found")
+		return comments
+	}
+	for _, inst := range list {
+		if comment := inst.(*ChangeComment); comment.ChangeID == changeId {
+			comments = append(comments, comment)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool {
+		return comments[i].Created < comments[j].Created
+	})
+	return comments
+}
+
+func GetOneChangeComment(id string) *ChangeComment {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_CHANGE_COMMENT, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no ChangeComment found for Id: %s", id))
+		return nil
+	}
+	return inst.(*ChangeComment)
+}
+
+func CreateOneChangeComment(comment *ChangeComment) error {
+	comment.Created = xwutil.GetTimestamp(time.Now().UTC())
+
+	commentBytes, err := json.Marshal(comment)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_CHANGE_COMMENT, comment.ID, commentBytes)
+}
+
+func GetChangeReviewList() []*ChangeReview {
+	all := []*ChangeReview{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_CHANGE_REVIEW, 0)
+	if err != nil {
+		log.Warn("no ChangeReview found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*ChangeReview))
+	}
+	return all
+}
+
+func CreateOneChangeReview(review *ChangeReview) error {
+	review.Created = xwutil.GetTimestamp(time.Now().UTC())
+
+	reviewBytes, err := json.Marshal(review)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_CHANGE_REVIEW, review.ID, reviewBytes)
+}
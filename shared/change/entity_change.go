@@ -0,0 +1,164 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package change
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+	xwchange "github.com/rdkcentral/xconfwebconfig/shared/change"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	FirmwareRule   = "FIRMWARE_RULE"
+	PercentageBean = "PERCENTAGE_BEAN"
+	FeatureRule    = "FEATURE_RULE"
+	Feature        = "FEATURE"
+	DCMGenericRule = "DCM_GENERIC_RULE"
+	NamespacedList = "NAMESPACED_LIST"
+)
+
+// EntityChange is a pending change of an entity that is not a telemetry profile.
+// Entities are kept as raw json because each entity type has its own model.
+type EntityChange struct {
+	ID              string                   `json:"id"`
+	EntityID        string                   `json:"entityId"`
+	EntityType      string                   `json:"entityType"`
+	ApplicationType string                   `json:"applicationType"`
+	Author          string                   `json:"author"`
+	ApprovedUser    string                   `json:"approvedUser,omitempty"`
+	Operation       xwchange.ChangeOperation `json:"operation"`
+	OldEntity       json.RawMessage          `json:"oldEntity,omitempty"`
+	NewEntity       json.RawMessage          `json:"newEntity,omitempty"`
+	BaseVersion     string                   `json:"baseVersion,omitempty"`
+	Updated         int64                    `json:"updated"`
+}
+
+type ApprovedEntityChange EntityChange
+
+// UpdatedField is rewritten on every save and never counts as a change of the entity
+const UpdatedField = "updated"
+
+// EntityVersion identifies the content of an entity, it is the same for equal entities regardless of key order
+func EntityVersion(entity json.RawMessage) string {
+	if len(entity) == 0 {
+		return ""
+	}
+	var value interface{}
+	if err := json.Unmarshal(entity, &value); err != nil {
+		return ""
+	}
+	if fields, ok := value.(map[string]interface{}); ok {
+		delete(fields, UpdatedField)
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+func NewEntityChangeInf() interface{} {
+	return &EntityChange{}
+}
+
+func NewApprovedEntityChangeInf() interface{} {
+	return &ApprovedEntityChange{}
+}
+
+func GetEntityChangeList() []*EntityChange {
+	all := []*EntityChange{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_ENTITY_CHANGE, 0)
+	if err != nil {
+		log.Warn("no EntityChange found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*EntityChange))
+	}
+	return all
+}
+
+func GetOneEntityChange(id string) *EntityChange {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_ENTITY_CHANGE, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no EntityChange found for Id: %s", id))
+		return nil
+	}
+	return inst.(*EntityChange)
+}
+
+func CreateOneEntityChange(change *EntityChange) error {
+	change.Updated = xwutil.GetTimestamp(time.Now().UTC())
+
+	changeBytes, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_ENTITY_CHANGE, change.ID, changeBytes)
+}
+
+func DeleteOneEntityChange(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_ENTITY_CHANGE, id)
+}
+
+func GetApprovedEntityChangeList() []*ApprovedEntityChange {
+	all := []*ApprovedEntityChange{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_APPROVED_ENTITY_CHANGE, 0)
+	if err != nil {
+		log.Warn("no ApprovedEntityChange found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*ApprovedEntityChange))
+	}
+	return all
+}
+
+func GetOneApprovedEntityChange(id string) *ApprovedEntityChange {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_APPROVED_ENTITY_CHANGE, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no ApprovedEntityChange found for Id: %s", id))
+		return nil
+	}
+	return inst.(*ApprovedEntityChange)
+}
+
+func SetOneApprovedEntityChange(approvedChange *ApprovedEntityChange) error {
+	approvedChange.Updated = xwutil.GetTimestamp(time.Now().UTC())
+
+	approvedChangeBytes, err := json.Marshal(approvedChange)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_APPROVED_ENTITY_CHANGE, approvedChange.ID, approvedChangeBytes)
+}
+
+func DeleteOneApprovedEntityChange(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_APPROVED_ENTITY_CHANGE, id)
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+	xwutil "github.com/rdkcentral/xconfwebconfig/util"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// kinds of pending changes that can be scheduled
+const (
+	TelemetryChangeKind    = "CHANGE"
+	TelemetryTwoChangeKind = "TELEMETRY_TWO_CHANGE"
+	EntityChangeKind       = "ENTITY_CHANGE"
+)
+
+const (
+	ScheduledStatus = "SCHEDULED"
+	FailedStatus    = "FAILED"
+)
+
+// ScheduledChange is an approved change waiting for its apply time. It shares its id with the pending change.
+// The change is applied on the approver's behalf: the roles and the api token of the approver are resolved again,
+// the permissions of the approver's login token are kept only until the token expires.
+type ScheduledChange struct {
+	ID              string   `json:"id"`
+	Kind            string   `json:"kind"`
+	EntityID        string   `json:"entityId"`
+	EntityType      string   `json:"entityType"`
+	ApplicationType string   `json:"applicationType"`
+	Module          string   `json:"module"`
+	ApplyAt         int64    `json:"applyAt"`
+	ScheduledBy     string   `json:"scheduledBy"`
+	Permissions     []string `json:"permissions,omitempty"`
+	GrantsExpireAt  int64    `json:"grantsExpireAt,omitempty"`
+	Groups          []string `json:"groups,omitempty"`
+	ApiTokenID      string   `json:"apiTokenId,omitempty"`
+	Status          string   `json:"status"`
+	Error           string   `json:"error,omitempty"`
+	Updated         int64    `json:"updated"`
+}
+
+func NewScheduledChangeInf() interface{} {
+	return &ScheduledChange{}
+}
+
+// IsDue returns true when the change is still scheduled and its apply time has passed
+func (s *ScheduledChange) IsDue(now time.Time) bool {
+	return s.Status == ScheduledStatus && s.ApplyAt <= xwutil.GetTimestamp(now)
+}
+
+// IsExpiredFailure returns true when the change failed to apply before the retention
+func (s *ScheduledChange) IsExpiredFailure(now time.Time, retention time.Duration) bool {
+	return s.Status == FailedStatus && s.Updated <= xwutil.GetTimestamp(now.Add(-retention))
+}
+
+func GetScheduledChangeList() []*ScheduledChange {
+	all := []*ScheduledChange{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_SCHEDULED_CHANGE, 0)
+	if err != nil {
+		log.Warn("no ScheduledChange found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*ScheduledChange))
+	}
+	return all
+}
+
+func GetOneScheduledChange(id string) *ScheduledChange {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_SCHEDULED_CHANGE, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no ScheduledChange found for Id: %s", id))
+		return nil
+	}
+	return inst.(*ScheduledChange)
+}
+
+func SetOneScheduledChange(scheduledChange *ScheduledChange) error {
+	scheduledChange.Updated = xwutil.GetTimestamp(time.Now().UTC())
+
+	scheduledChangeBytes, err := json.Marshal(scheduledChange)
+	if err != nil {
+		return err
+	}
+
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_SCHEDULED_CHANGE, scheduledChange.ID, scheduledChangeBytes)
+}
+
+func DeleteOneScheduledChange(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_SCHEDULED_CHANGE, id)
+}
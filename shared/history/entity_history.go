@@ -0,0 +1,100 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package history
+
+import (
+	"encoding/json"
+	"strconv"
+
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+)
+
+// The versions of an entity are appended as the entity is written and never rewritten, only the user of
+// a version may be added after the write. A version without entity records a delete.
+// The table is created by db/migrations/0005_entity_versions.cql.
+const (
+	QueryAddEntityVersion     = `INSERT INTO "XconfEntityVersions" (entity_type, entity_id, written_at, user_name, entity) VALUES (?, ?, ?, ?, ?)`
+	QuerySetEntityVersionUser = `UPDATE "XconfEntityVersions" SET user_name = ? WHERE entity_type = ? AND entity_id = ? AND written_at = ?`
+	QueryGetEntityVersions    = `SELECT written_at, user_name, entity FROM "XconfEntityVersions" WHERE entity_type = ? AND entity_id = ?`
+)
+
+const (
+	CreateOperation = "CREATE"
+	UpdateOperation = "UPDATE"
+	DeleteOperation = "DELETE"
+)
+
+// EntityVersion is an entity as it has been written, the versions of an entity are numbered in the order of
+// the writes. The operation is derived from the previous version.
+type EntityVersion struct {
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Version    int             `json:"version"`
+	Operation  string          `json:"operation"`
+	UserName   string          `json:"userName"`
+	Timestamp  int64           `json:"timestamp"`
+	Entity     json.RawMessage `json:"entity,omitempty"`
+}
+
+func (v *EntityVersion) IsDeleted() bool {
+	return len(v.Entity) == 0
+}
+
+// AddEntityVersion appends a version written at writtenAt in microseconds, a nil entity records a delete
+func AddEntityVersion(entityType string, entityId string, writtenAt int64, userName string, entity []byte) error {
+	return ds.GetSimpleDao().Modify(QueryAddEntityVersion, entityType, entityId, strconv.FormatInt(writtenAt, 10), userName, string(entity))
+}
+
+func SetEntityVersionUser(entityType string, entityId string, writtenAt int64, userName string) error {
+	return ds.GetSimpleDao().Modify(QuerySetEntityVersionUser, userName, entityType, entityId, strconv.FormatInt(writtenAt, 10))
+}
+
+// GetEntityVersions returns the versions of the entity from the first to the last
+func GetEntityVersions(entityType string, entityId string) ([]*EntityVersion, error) {
+	rows, err := ds.GetSimpleDao().Query(QueryGetEntityVersions, entityType, entityId)
+	if err != nil {
+		return nil, err
+	}
+	versions := []*EntityVersion{}
+	for _, row := range rows {
+		writtenAt, _ := row["written_at"].(int64)
+		userName, _ := row["user_name"].(string)
+		entity, _ := row["entity"].(string)
+		version := &EntityVersion{
+			EntityType: entityType,
+			EntityID:   entityId,
+			Version:    len(versions) + 1,
+			UserName:   userName,
+			Timestamp:  writtenAt / 1000,
+		}
+		if entity != "" {
+			version.Entity = json.RawMessage(entity)
+		}
+		version.Operation = getOperation(versions, version)
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func getOperation(previous []*EntityVersion, version *EntityVersion) string {
+	if version.IsDeleted() {
+		return DeleteOperation
+	}
+	if len(previous) == 0 || previous[len(previous)-1].IsDeleted() {
+		return CreateOperation
+	}
+	return UpdateOperation
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package history
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOperation(t *testing.T) {
+	created := &EntityVersion{Entity: json.RawMessage(`{"id":"r1"}`)}
+	deleted := &EntityVersion{}
+	assert.Equal(t, CreateOperation, getOperation(nil, created))
+	assert.Equal(t, UpdateOperation, getOperation([]*EntityVersion{created}, created))
+	assert.Equal(t, DeleteOperation, getOperation([]*EntityVersion{created}, deleted))
+	assert.Equal(t, CreateOperation, getOperation([]*EntityVersion{created, deleted}, created))
+}
@@ -0,0 +1,163 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package lockdown
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// The writes let through by an override are appended to their own table, the override itself is only written
+// when it is created and revoked. The tables are created by db/migrations/0009_lockdown_override.cql.
+const (
+	QueryAddLockdownOverrideWrite  = `INSERT INTO "XconfLockdownOverrideWrites" (override_id, written_at, write_id, module, method, path) VALUES (?, ?, ?, ?, ?, ?)`
+	QueryGetLockdownOverrideWrites = `SELECT written_at, module, method, path FROM "XconfLockdownOverrideWrites" WHERE override_id = ?`
+)
+
+// LockdownOverride is a break-glass override: its user may write to the locked modules until it expires or is revoked.
+// The Writes are read from the writes of the override, they are not stored with it.
+type LockdownOverride struct {
+	ID            string                   `json:"id"`
+	Modules       []string                 `json:"modules"`
+	Justification string                   `json:"justification"`
+	RequestedBy   string                   `json:"requestedBy"`
+	Created       int64                    `json:"created"`
+	Expires       int64                    `json:"expires"`
+	Revoked       int64                    `json:"revoked,omitempty"`
+	RevokedBy     string                   `json:"revokedBy,omitempty"`
+	Writes        []*LockdownOverrideWrite `json:"writes,omitempty"`
+}
+
+// LockdownOverrideWrite is a write to a locked module let through by the override
+type LockdownOverrideWrite struct {
+	Timestamp int64  `json:"timestamp"`
+	Module    string `json:"module"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+}
+
+func NewLockdownOverrideInf() interface{} {
+	return &LockdownOverride{}
+}
+
+// End is when the override stopped or stops applying, the revoke time when it has been revoked earlier
+func (o *LockdownOverride) End() int64 {
+	if o.Revoked > 0 && o.Revoked < o.Expires {
+		return o.Revoked
+	}
+	return o.Expires
+}
+
+func (o *LockdownOverride) IsActive(now time.Time) bool {
+	millis := now.UnixMilli()
+	return o.Created <= millis && millis < o.End()
+}
+
+func (o *LockdownOverride) Covers(module string) bool {
+	window := LockdownWindow{Modules: o.Modules}
+	return window.LocksModule(module)
+}
+
+// GetActiveLockdownOverride returns the override of the user covering the module at the time, nil when there is none
+func GetActiveLockdownOverride(userName string, module string, now time.Time) *LockdownOverride {
+	for _, override := range GetLockdownOverrideList() {
+		if override.RequestedBy == userName && override.Covers(module) && override.IsActive(now) {
+			return override
+		}
+	}
+	return nil
+}
+
+// FindLockdownOverride returns the override of the user covering the module, active at any time from until,
+// nil when there is none
+func FindLockdownOverride(overrides []*LockdownOverride, userName string, module string, from time.Time, until time.Time) *LockdownOverride {
+	for _, override := range overrides {
+		if override.RequestedBy == userName && override.Covers(module) && override.Created < until.UnixMilli() && override.End() > from.UnixMilli() {
+			return override
+		}
+	}
+	return nil
+}
+
+func GetOneLockdownOverride(id string) *LockdownOverride {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_LOCKDOWN_OVERRIDE, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no LockdownOverride found for Id: %s", id))
+		return nil
+	}
+	return inst.(*LockdownOverride)
+}
+
+// GetLockdownOverrideList returns the overrides, the latest first
+func GetLockdownOverrideList() []*LockdownOverride {
+	all := []*LockdownOverride{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_LOCKDOWN_OVERRIDE, 0)
+	if err != nil {
+		log.Warn("no LockdownOverride found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*LockdownOverride))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Created == all[j].Created {
+			return all[i].ID < all[j].ID
+		}
+		return all[i].Created > all[j].Created
+	})
+	return all
+}
+
+func SetOneLockdownOverride(override *LockdownOverride) error {
+	overrideBytes, err := json.Marshal(override)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_LOCKDOWN_OVERRIDE, override.ID, overrideBytes)
+}
+
+// AddLockdownOverrideWrite appends a write let through by the override
+func AddLockdownOverrideWrite(overrideId string, write *LockdownOverrideWrite) error {
+	return db.GetSimpleDao().Modify(QueryAddLockdownOverrideWrite, overrideId, strconv.FormatInt(write.Timestamp, 10), uuid.New().String(), write.Module, write.Method, write.Path)
+}
+
+// GetLockdownOverrideWrites returns the writes let through by the override, the first one first
+func GetLockdownOverrideWrites(overrideId string) ([]*LockdownOverrideWrite, error) {
+	rows, err := db.GetSimpleDao().Query(QueryGetLockdownOverrideWrites, overrideId)
+	if err != nil {
+		return nil, err
+	}
+	writes := []*LockdownOverrideWrite{}
+	for _, row := range rows {
+		write := &LockdownOverrideWrite{}
+		write.Timestamp, _ = row["written_at"].(int64)
+		write.Module, _ = row["module"].(string)
+		write.Method, _ = row["method"].(string)
+		write.Path, _ = row["path"].(string)
+		writes = append(writes, write)
+	}
+	return writes, nil
+}
@@ -0,0 +1,328 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package lockdown
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	AllModules = "all"
+	// LockdownSettingsWindowId is the window of the lockdown settings, which locks daily in the default timezone
+	LockdownSettingsWindowId = "lockdownSettings"
+)
+
+var LockdownModules = []string{AllModules, "dcm", "rfc", "firmware", "changes", "tools", "common", "telemetry"}
+
+var lockdownWindowIdPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// DateRange is a one-off lockdown like a holiday freeze, from Start to End in the timezone of the window
+type DateRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// LockdownWindow locks its modules every day of DaysOfWeek, all days when empty, from StartTime to EndTime,
+// which ends the next day when it is not after StartTime, and during each of its DateRanges.
+// Times are in the Timezone of the window, "HH:mm" for the daily times and "yyyy-MM-dd HH:mm" for the date ranges.
+type LockdownWindow struct {
+	ID          string      `json:"id"`
+	Description string      `json:"description,omitempty"`
+	Enabled     bool        `json:"enabled"`
+	Timezone    string      `json:"timezone"`
+	Modules     []string    `json:"modules"`
+	DaysOfWeek  []string    `json:"daysOfWeek,omitempty"`
+	StartTime   string      `json:"startTime,omitempty"`
+	EndTime     string      `json:"endTime,omitempty"`
+	DateRanges  []DateRange `json:"dateRanges,omitempty"`
+	Updated     int64       `json:"updated,omitempty"`
+	UpdatedBy   string      `json:"updatedBy,omitempty"`
+}
+
+// LockdownPeriod is one occurrence of a window
+type LockdownPeriod struct {
+	WindowID string    `json:"windowId"`
+	Modules  []string  `json:"modules"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+}
+
+func NewLockdownWindowInf() interface{} {
+	return &LockdownWindow{}
+}
+
+func (w *LockdownWindow) Validate() error {
+	if !lockdownWindowIdPattern.MatchString(w.ID) {
+		return errors.New("id is required and may only contain letters, digits, '_', '.' and '-'")
+	}
+	if w.ID == LockdownSettingsWindowId {
+		return fmt.Errorf("id %s is reserved for the lockdown settings", LockdownSettingsWindowId)
+	}
+	if _, err := time.LoadLocation(w.Timezone); err != nil || w.Timezone == "" {
+		return fmt.Errorf("timezone %s is not valid", w.Timezone)
+	}
+	if len(w.Modules) == 0 {
+		return errors.New("modules are required")
+	}
+	for _, module := range w.Modules {
+		if !util.Contains(LockdownModules, strings.ToLower(module)) {
+			return fmt.Errorf("module must be one of: %s", strings.Join(LockdownModules, ", "))
+		}
+	}
+	recurring := w.StartTime != "" || w.EndTime != ""
+	if recurring {
+		if err := util.ValidateTimeFormat(w.StartTime); err != nil {
+			return fmt.Errorf("startTime: %s", err.Error())
+		}
+		if err := util.ValidateTimeFormat(w.EndTime); err != nil {
+			return fmt.Errorf("endTime: %s", err.Error())
+		}
+	} else if len(w.DaysOfWeek) > 0 {
+		return errors.New("startTime and endTime are required with daysOfWeek")
+	}
+	for _, day := range w.DaysOfWeek {
+		if _, ok := parseWeekday(day); !ok {
+			return fmt.Errorf("day of week %s is not valid", day)
+		}
+	}
+	location, _ := time.LoadLocation(w.Timezone)
+	for _, dateRange := range w.DateRanges {
+		start, end, err := dateRange.parse(location)
+		if err != nil {
+			return err
+		}
+		if !end.After(start) {
+			return fmt.Errorf("date range end %s must be after start %s", dateRange.End, dateRange.Start)
+		}
+	}
+	if !recurring && len(w.DateRanges) == 0 {
+		return errors.New("startTime and endTime or dateRanges are required")
+	}
+	return nil
+}
+
+// LocksModule is true when the window locks the module
+func (w *LockdownWindow) LocksModule(module string) bool {
+	return util.CaseInsensitiveContains(w.Modules, AllModules) || util.CaseInsensitiveContains(w.Modules, module)
+}
+
+// Periods returns the occurrences of the window overlapping from until
+func (w *LockdownWindow) Periods(from time.Time, until time.Time) []*LockdownPeriod {
+	location, err := time.LoadLocation(w.Timezone)
+	if err != nil {
+		log.Errorf("Error loading timezone %s of lockdown window %s", w.Timezone, w.ID)
+		return nil
+	}
+	periods := []*LockdownPeriod{}
+	addPeriod := func(start time.Time, end time.Time) {
+		if start.Before(until) && end.After(from) {
+			periods = append(periods, &LockdownPeriod{WindowID: w.ID, Modules: w.Modules, Start: start, End: end})
+		}
+	}
+
+	if w.StartTime != "" || w.EndTime != "" {
+		startTime, err1 := time.Parse(xcommon.DefaultTimeFormatLayout, w.StartTime)
+		endTime, err2 := time.Parse(xcommon.DefaultTimeFormatLayout, w.EndTime)
+		if err1 != nil || err2 != nil {
+			log.Errorf("Unable to parse the times %s-%s of lockdown window %s", w.StartTime, w.EndTime, w.ID)
+		} else {
+			// a period which started the day before may still be running
+			first := from.In(location)
+			day := time.Date(first.Year(), first.Month(), first.Day()-1, 0, 0, 0, 0, location)
+			for !day.After(until) {
+				if w.isLockdownDay(day.Weekday()) {
+					start := time.Date(day.Year(), day.Month(), day.Day(), startTime.Hour(), startTime.Minute(), 0, 0, location)
+					end := time.Date(day.Year(), day.Month(), day.Day(), endTime.Hour(), endTime.Minute(), 0, 0, location)
+					if !end.After(start) {
+						end = end.AddDate(0, 0, 1)
+					}
+					addPeriod(start, end)
+				}
+				day = day.AddDate(0, 0, 1)
+			}
+		}
+	}
+
+	for _, dateRange := range w.DateRanges {
+		start, end, err := dateRange.parse(location)
+		if err != nil {
+			log.Errorf("Lockdown window %s: %s", w.ID, err.Error())
+			continue
+		}
+		addPeriod(start, end)
+	}
+	sortPeriods(periods)
+	return periods
+}
+
+// IsActive is true when the window locks at the time
+func (w *LockdownWindow) IsActive(now time.Time) bool {
+	return len(w.Periods(now, now.Add(time.Nanosecond))) > 0
+}
+
+func (w *LockdownWindow) isLockdownDay(weekday time.Weekday) bool {
+	if len(w.DaysOfWeek) == 0 {
+		return true
+	}
+	for _, day := range w.DaysOfWeek {
+		if d, ok := parseWeekday(day); ok && d == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+func (r DateRange) parse(location *time.Location) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(xcommon.DefaultTimeDateFormatLayout, r.Start, location)
+	if err != nil {
+		return start, start, fmt.Errorf("date range start %s is not in the format yyyy-MM-dd HH:mm", r.Start)
+	}
+	end, err := time.ParseInLocation(xcommon.DefaultTimeDateFormatLayout, r.End, location)
+	if err != nil {
+		return start, end, fmt.Errorf("date range end %s is not in the format yyyy-MM-dd HH:mm", r.End)
+	}
+	return start, end, nil
+}
+
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := weekday.String()
+		if strings.EqualFold(day, name) || strings.EqualFold(day, name[:3]) {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+func sortPeriods(periods []*LockdownPeriod) {
+	sort.SliceStable(periods, func(i, j int) bool {
+		if periods[i].Start.Equal(periods[j].Start) {
+			return periods[i].WindowID < periods[j].WindowID
+		}
+		return periods[i].Start.Before(periods[j].Start)
+	})
+}
+
+// GetLockdownSettingsWindow is the window of the lockdown settings, nil when they are not enabled
+func GetLockdownSettingsWindow() *LockdownWindow {
+	if !xcommon.GetBooleanAppSetting(xcommon.PROP_LOCKDOWN_ENABLED, false) {
+		return nil
+	}
+	modules := []string{}
+	for _, module := range strings.Split(xcommon.GetStringAppSetting(xcommon.PROP_LOCKDOWN_MODULES), ",") {
+		if module = strings.TrimSpace(module); module != "" {
+			modules = append(modules, module)
+		}
+	}
+	return &LockdownWindow{
+		ID:        LockdownSettingsWindowId,
+		Enabled:   true,
+		Timezone:  xcommon.DefaultLockdownTimezone,
+		Modules:   modules,
+		StartTime: xcommon.GetStringAppSetting(xcommon.PROP_LOCKDOWN_STARTTIME),
+		EndTime:   xcommon.GetStringAppSetting(xcommon.PROP_LOCKDOWN_ENDTIME),
+	}
+}
+
+// GetEnabledLockdownWindows returns the lockdown settings window and the enabled windows
+func GetEnabledLockdownWindows() []*LockdownWindow {
+	windows := []*LockdownWindow{}
+	if settingsWindow := GetLockdownSettingsWindow(); settingsWindow != nil {
+		windows = append(windows, settingsWindow)
+	}
+	for _, window := range GetLockdownWindowList() {
+		if window.Enabled {
+			windows = append(windows, window)
+		}
+	}
+	return windows
+}
+
+// GetActivePeriods returns the periods locking at the time
+func GetActivePeriods(windows []*LockdownWindow, now time.Time) []*LockdownPeriod {
+	return GetPeriods(windows, now, now.Add(time.Nanosecond))
+}
+
+// GetPeriods returns the periods of all windows overlapping from until, by start
+func GetPeriods(windows []*LockdownWindow, from time.Time, until time.Time) []*LockdownPeriod {
+	periods := []*LockdownPeriod{}
+	for _, window := range windows {
+		periods = append(periods, window.Periods(from, until)...)
+	}
+	sortPeriods(periods)
+	return periods
+}
+
+// IsModuleLocked is true when an enabled window locks the module at the time
+func IsModuleLocked(module string, now time.Time) bool {
+	for _, window := range GetEnabledLockdownWindows() {
+		if window.LocksModule(module) && window.IsActive(now) {
+			log.Infof("Lockdown window %s locks module %s now", window.ID, module)
+			return true
+		}
+	}
+	return false
+}
+
+func GetOneLockdownWindow(id string) *LockdownWindow {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_LOCKDOWN_WINDOW, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no LockdownWindow found for Id: %s", id))
+		return nil
+	}
+	return inst.(*LockdownWindow)
+}
+
+func GetLockdownWindowList() []*LockdownWindow {
+	all := []*LockdownWindow{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_LOCKDOWN_WINDOW, 0)
+	if err != nil {
+		log.Warn("no LockdownWindow found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*LockdownWindow))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+func SetOneLockdownWindow(window *LockdownWindow) error {
+	windowBytes, err := json.Marshal(window)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_LOCKDOWN_WINDOW, window.ID, windowBytes)
+}
+
+func DeleteOneLockdownWindow(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_LOCKDOWN_WINDOW, id)
+}
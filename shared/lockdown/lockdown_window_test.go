@@ -0,0 +1,173 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package lockdown
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWindow() *LockdownWindow {
+	return &LockdownWindow{
+		ID:         "weekend-nights",
+		Enabled:    true,
+		Timezone:   "America/New_York",
+		Modules:    []string{"firmware", "rfc"},
+		DaysOfWeek: []string{"Fri", "saturday"},
+		StartTime:  "22:00",
+		EndTime:    "06:00",
+	}
+}
+
+func TestLockdownWindowValidate(t *testing.T) {
+	assert.NoError(t, newTestWindow().Validate())
+
+	window := newTestWindow()
+	window.ID = ""
+	assert.ErrorContains(t, window.Validate(), "id is required")
+
+	window = newTestWindow()
+	window.ID = LockdownSettingsWindowId
+	assert.ErrorContains(t, window.Validate(), "reserved")
+
+	window = newTestWindow()
+	window.Timezone = "Mars/Olympus"
+	assert.ErrorContains(t, window.Validate(), "timezone")
+
+	window = newTestWindow()
+	window.Modules = []string{"unknown"}
+	assert.ErrorContains(t, window.Validate(), "module must be one of")
+
+	window = newTestWindow()
+	window.DaysOfWeek = []string{"Funday"}
+	assert.ErrorContains(t, window.Validate(), "day of week")
+
+	window = newTestWindow()
+	window.EndTime = "25:00"
+	assert.ErrorContains(t, window.Validate(), "endTime")
+
+	window = newTestWindow()
+	window.StartTime, window.EndTime = "", ""
+	assert.ErrorContains(t, window.Validate(), "required with daysOfWeek")
+
+	window.DaysOfWeek = nil
+	assert.ErrorContains(t, window.Validate(), "dateRanges are required")
+
+	window.DateRanges = []DateRange{{Start: "2026-12-24 00:00", End: "2026-12-23 00:00"}}
+	assert.ErrorContains(t, window.Validate(), "must be after start")
+
+	window.DateRanges = []DateRange{{Start: "2026-12-24", End: "2026-12-27 00:00"}}
+	assert.ErrorContains(t, window.Validate(), "yyyy-MM-dd HH:mm")
+
+	window.DateRanges = []DateRange{{Start: "2026-12-24 00:00", End: "2026-12-27 00:00"}}
+	assert.NoError(t, window.Validate())
+}
+
+func TestLockdownWindowPeriods(t *testing.T) {
+	location, _ := time.LoadLocation("America/New_York")
+	window := newTestWindow()
+
+	// Thursday 2026-10-15 until Monday 2026-10-19
+	from := time.Date(2026, 10, 15, 0, 0, 0, 0, location)
+	periods := window.Periods(from, from.AddDate(0, 0, 4))
+	assert.Len(t, periods, 2)
+	assert.Equal(t, time.Date(2026, 10, 16, 22, 0, 0, 0, location), periods[0].Start)
+	assert.Equal(t, time.Date(2026, 10, 17, 6, 0, 0, 0, location), periods[0].End)
+	assert.Equal(t, time.Date(2026, 10, 17, 22, 0, 0, 0, location), periods[1].Start)
+	assert.Equal(t, time.Date(2026, 10, 18, 6, 0, 0, 0, location), periods[1].End)
+
+	// the period of saturday night is still active on sunday morning
+	assert.True(t, window.IsActive(time.Date(2026, 10, 18, 5, 59, 0, 0, location)))
+	assert.False(t, window.IsActive(time.Date(2026, 10, 18, 6, 0, 0, 0, location)))
+	assert.False(t, window.IsActive(time.Date(2026, 10, 15, 23, 0, 0, 0, location)))
+
+	// the times are in the timezone of the window
+	assert.True(t, window.IsActive(time.Date(2026, 10, 17, 3, 0, 0, 0, time.UTC)))
+	assert.False(t, window.IsActive(time.Date(2026, 10, 17, 1, 0, 0, 0, time.UTC)))
+}
+
+func TestLockdownWindowDateRanges(t *testing.T) {
+	location, _ := time.LoadLocation("Europe/London")
+	window := &LockdownWindow{
+		ID:         "holidays",
+		Enabled:    true,
+		Timezone:   "Europe/London",
+		Modules:    []string{AllModules},
+		DateRanges: []DateRange{{Start: "2026-12-24 00:00", End: "2027-01-02 00:00"}},
+	}
+	assert.True(t, window.IsActive(time.Date(2026, 12, 31, 12, 0, 0, 0, location)))
+	assert.False(t, window.IsActive(time.Date(2027, 1, 2, 0, 0, 0, 0, location)))
+	assert.True(t, window.LocksModule("telemetry"))
+
+	from := time.Date(2026, 12, 1, 0, 0, 0, 0, location)
+	assert.Len(t, window.Periods(from, from.AddDate(0, 0, 7)), 0)
+	assert.Len(t, window.Periods(from, from.AddDate(0, 1, 0)), 1)
+}
+
+func TestGetPeriodsOrdersWindows(t *testing.T) {
+	location, _ := time.LoadLocation("America/New_York")
+	daily := &LockdownWindow{
+		ID:        "daily",
+		Enabled:   true,
+		Timezone:  "America/New_York",
+		Modules:   []string{"dcm"},
+		StartTime: "12:00",
+		EndTime:   "13:00",
+	}
+	weekend := newTestWindow()
+
+	from := time.Date(2026, 10, 16, 0, 0, 0, 0, location)
+	periods := GetPeriods([]*LockdownWindow{weekend, daily}, from, from.AddDate(0, 0, 2))
+	ids := []string{}
+	for _, period := range periods {
+		ids = append(ids, period.WindowID)
+	}
+	assert.Equal(t, []string{"daily", "weekend-nights", "daily", "weekend-nights"}, ids)
+
+	active := GetActivePeriods([]*LockdownWindow{weekend, daily}, time.Date(2026, 10, 16, 12, 30, 0, 0, location))
+	assert.Len(t, active, 1)
+	assert.Equal(t, "daily", active[0].WindowID)
+	assert.True(t, daily.LocksModule("DCM"))
+	assert.False(t, daily.LocksModule("firmware"))
+}
+
+func TestLockdownOverride(t *testing.T) {
+	created := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	override := &LockdownOverride{
+		ID:          "override1",
+		Modules:     []string{"firmware"},
+		RequestedBy: "alice",
+		Created:     created.UnixMilli(),
+		Expires:     created.Add(time.Hour).UnixMilli(),
+	}
+	assert.True(t, override.Covers("FIRMWARE"))
+	assert.False(t, override.Covers("rfc"))
+	assert.True(t, override.IsActive(created.Add(59*time.Minute)))
+	assert.False(t, override.IsActive(created.Add(time.Hour)))
+	assert.False(t, override.IsActive(created.Add(-time.Minute)))
+
+	overrides := []*LockdownOverride{override}
+	assert.NotNil(t, FindLockdownOverride(overrides, "alice", "firmware", created.Add(-time.Hour), created.Add(time.Minute)))
+	assert.Nil(t, FindLockdownOverride(overrides, "alice", "dcm", created.Add(-time.Hour), created.Add(time.Minute)))
+	assert.Nil(t, FindLockdownOverride(overrides, "bob", "firmware", created, created.Add(time.Minute)))
+
+	// a revoked override ends at once
+	override.Revoked = created.Add(10 * time.Minute).UnixMilli()
+	assert.False(t, override.IsActive(created.Add(20*time.Minute)))
+	assert.Nil(t, FindLockdownOverride(overrides, "alice", "firmware", created.Add(20*time.Minute), created.Add(30*time.Minute)))
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package role
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// kinds of subjects a role is bound to
+const (
+	UserSubject  = "user"
+	GroupSubject = "group"
+)
+
+// AllApplicationTypes grants the application permissions of a role for every application type
+const AllApplicationTypes = "*"
+
+// Role is a named set of permissions. Application permissions like read-firmware are granted for each of the
+// application types of the role, the other permissions like view-tools are granted as they are.
+type Role struct {
+	ID               string   `json:"id"`
+	Description      string   `json:"description,omitempty"`
+	Permissions      []string `json:"permissions"`
+	ApplicationTypes []string `json:"applicationTypes,omitempty"`
+	Updated          int64    `json:"updated,omitempty"`
+	UpdatedBy        string   `json:"updatedBy,omitempty"`
+}
+
+// RoleBinding grants roles to a user, identified by the auth subject, or to a group of the login token
+type RoleBinding struct {
+	ID        string   `json:"id"`
+	Kind      string   `json:"kind"`
+	Subject   string   `json:"subject"`
+	Roles     []string `json:"roles"`
+	Updated   int64    `json:"updated,omitempty"`
+	UpdatedBy string   `json:"updatedBy,omitempty"`
+}
+
+func NewRoleInf() interface{} {
+	return &Role{}
+}
+
+func NewRoleBindingInf() interface{} {
+	return &RoleBinding{}
+}
+
+func RoleBindingId(kind string, subject string) string {
+	return kind + ":" + subject
+}
+
+func GetOneRole(id string) *Role {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_ROLE, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no Role found for Id: %s", id))
+		return nil
+	}
+	return inst.(*Role)
+}
+
+func GetRoleList() []*Role {
+	all := []*Role{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_ROLE, 0)
+	if err != nil {
+		log.Warn("no Role found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*Role))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+func SetOneRole(role *Role) error {
+	roleBytes, err := json.Marshal(role)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_ROLE, role.ID, roleBytes)
+}
+
+func DeleteOneRole(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_ROLE, id)
+}
+
+func GetOneRoleBinding(id string) *RoleBinding {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_ROLE_BINDING, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no RoleBinding found for Id: %s", id))
+		return nil
+	}
+	return inst.(*RoleBinding)
+}
+
+func GetRoleBindingList() []*RoleBinding {
+	all := []*RoleBinding{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_ROLE_BINDING, 0)
+	if err != nil {
+		log.Warn("no RoleBinding found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*RoleBinding))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+func SetOneRoleBinding(binding *RoleBinding) error {
+	bindingBytes, err := json.Marshal(binding)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_ROLE_BINDING, binding.ID, bindingBytes)
+}
+
+func DeleteOneRoleBinding(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_ROLE_BINDING, id)
+}
@@ -0,0 +1,170 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package tagging
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// the state of a tag job
+const (
+	TagJobUploading = "UPLOADING"
+	TagJobRunning   = "RUNNING"
+	TagJobCompleted = "COMPLETED"
+	TagJobFailed    = "FAILED"
+)
+
+// MaxTagJobRejects is the number of rejected members kept in a job, the others are only counted
+const MaxTagJobRejects = 1000
+
+// TagJob is a long running operation on the members of tags. Its progress is counted in the units of
+// the job type, buckets for the set operations, the copies and the renames and chunks for the imports.
+// The owner is the instance running the job, a resumed job is taken over by the instance resuming it.
+// A job which goes through the buckets in order keeps the last bucket it completed to be resumed after it.
+type TagJob struct {
+	ID          string              `json:"id"`
+	Type        string              `json:"type"`
+	Sources     []string            `json:"sources,omitempty"`
+	Target      string              `json:"target,omitempty"`
+	List        string              `json:"list,omitempty"`
+	PercentFrom float64             `json:"percentFrom,omitempty"`
+	PercentTo   float64             `json:"percentTo,omitempty"`
+	Value       string              `json:"value,omitempty"`
+	DryRun      bool                `json:"dryRun,omitempty"`
+	Status      string              `json:"status"`
+	Total       int                 `json:"total"`
+	Processed   int                 `json:"processed"`
+	LastBucket  *int                `json:"lastBucket,omitempty"`
+	ResultCount int                 `json:"resultCount"`
+	Stored      int                 `json:"stored"`
+	Retries     int                 `json:"retries,omitempty"`
+	Rejected    int                 `json:"rejected,omitempty"`
+	Rejects     []*TagJobReject     `json:"rejects,omitempty"`
+	Reconcile   *TagReconcileReport `json:"reconcile,omitempty"`
+	References  []*TagRuleReference `json:"references,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	Owner       string              `json:"owner,omitempty"`
+	CreatedBy   string              `json:"createdBy,omitempty"`
+	Created     int64               `json:"created"`
+	Updated     int64               `json:"updated"`
+}
+
+// TagJobReject is a member which has not been stored, the line is the one of the uploaded file
+type TagJobReject struct {
+	Line   int    `json:"line,omitempty"`
+	Member string `json:"member,omitempty"`
+	Reason string `json:"reason"`
+}
+
+// TagRuleReference is a rule with a condition on a tag
+type TagRuleReference struct {
+	Type            string `json:"type"`
+	ID              string `json:"id"`
+	Name            string `json:"name,omitempty"`
+	ApplicationType string `json:"applicationType,omitempty"`
+}
+
+// TagReconcileReport lists the members which are only in one of Cassandra and XDAS. The counts are complete,
+// the first MaxTagJobRejects members of each side are kept.
+type TagReconcileReport struct {
+	Repair                  string   `json:"repair,omitempty"`
+	Checked                 int      `json:"checked"`
+	Failed                  int      `json:"failed"`
+	MissingInXdasCount      int      `json:"missingInXdasCount"`
+	MissingInCassandraCount int      `json:"missingInCassandraCount"`
+	MissingInXdas           []string `json:"missingInXdas,omitempty"`
+	MissingInCassandra      []string `json:"missingInCassandra,omitempty"`
+	Repaired                int      `json:"repaired"`
+}
+
+func (r *TagReconcileReport) AddMissingInXdas(members []string) {
+	r.MissingInXdasCount += len(members)
+	r.MissingInXdas = appendCapped(r.MissingInXdas, members)
+}
+
+func (r *TagReconcileReport) AddMissingInCassandra(members []string) {
+	r.MissingInCassandraCount += len(members)
+	r.MissingInCassandra = appendCapped(r.MissingInCassandra, members)
+}
+
+func appendCapped(list []string, members []string) []string {
+	if room := MaxTagJobRejects - len(list); room < len(members) {
+		members = members[:max(room, 0)]
+	}
+	return append(list, members...)
+}
+
+func NewTagJobInf() interface{} {
+	return &TagJob{}
+}
+
+func (j *TagJob) IsDone() bool {
+	return j.Status == TagJobCompleted || j.Status == TagJobFailed
+}
+
+// AddReject counts the rejected member, the first MaxTagJobRejects ones are kept
+func (j *TagJob) AddReject(line int, member string, reason string) {
+	j.Rejected++
+	if len(j.Rejects) < MaxTagJobRejects {
+		j.Rejects = append(j.Rejects, &TagJobReject{Line: line, Member: member, Reason: reason})
+	}
+}
+
+func GetOneTagJob(id string) *TagJob {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_TAG_JOB, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no TagJob found for Id: %s", id))
+		return nil
+	}
+	return inst.(*TagJob)
+}
+
+// GetTagJobList returns the latest jobs first
+func GetTagJobList() []*TagJob {
+	all := []*TagJob{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_TAG_JOB, 0)
+	if err != nil {
+		log.Warn("no TagJob found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*TagJob))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Created > all[j].Created
+	})
+	return all
+}
+
+func SetOneTagJob(job *TagJob) error {
+	jobBytes, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_TAG_JOB, job.ID, jobBytes)
+}
+
+func DeleteOneTagJob(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_TAG_JOB, id)
+}
@@ -0,0 +1,117 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package tagging
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// how a tag has been created
+const (
+	ApiCreationSource      = "api"
+	MetadataCreationSource = "metadata"
+	LegacyCreationSource   = "legacy"
+)
+
+// TagMetadata describes a tag. The member count is refreshed in the background after the members changed and
+// recounted periodically, a tag with an expiry is deleted once it has expired.
+type TagMetadata struct {
+	ID                  string `json:"id"`
+	Description         string `json:"description,omitempty"`
+	Owner               string `json:"owner,omitempty"`
+	CreatedBy           string `json:"createdBy,omitempty"`
+	CreationSource      string `json:"creationSource,omitempty"`
+	Created             int64  `json:"created,omitempty"`
+	Updated             int64  `json:"updated,omitempty"`
+	UpdatedBy           string `json:"updatedBy,omitempty"`
+	MemberCount         int    `json:"memberCount"`
+	MemberCountUpdated  int64  `json:"memberCountUpdated,omitempty"`
+	MemberCountRepaired int64  `json:"memberCountRepaired,omitempty"`
+	ExpiresAt           int64  `json:"expiresAt,omitempty"`
+}
+
+func NewTagMetadataInf() interface{} {
+	return &TagMetadata{}
+}
+
+// IsExpired is true when the tag has an expiry which has passed
+func (m *TagMetadata) IsExpired(nowMillis int64) bool {
+	return m.ExpiresAt > 0 && m.ExpiresAt <= nowMillis
+}
+
+// IsMemberCountStale is true when the members changed after they have been counted
+func (m *TagMetadata) IsMemberCountStale() bool {
+	return m.MemberCountUpdated < m.Updated
+}
+
+// TagMetadataFilter selects the tags by owner and by the prefix of the id, an empty field matches all
+type TagMetadataFilter struct {
+	Owner  string
+	Prefix string
+}
+
+func (f *TagMetadataFilter) Matches(metadata *TagMetadata) bool {
+	if f.Owner != "" && !strings.EqualFold(f.Owner, metadata.Owner) {
+		return false
+	}
+	return strings.HasPrefix(metadata.ID, f.Prefix)
+}
+
+func GetOneTagMetadata(id string) *TagMetadata {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_TAG_METADATA, id)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no TagMetadata found for Id: %s", id))
+		return nil
+	}
+	return inst.(*TagMetadata)
+}
+
+func GetTagMetadataList() []*TagMetadata {
+	all := []*TagMetadata{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_TAG_METADATA, 0)
+	if err != nil {
+		log.Warn("no TagMetadata found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*TagMetadata))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].ID < all[j].ID
+	})
+	return all
+}
+
+func SetOneTagMetadata(metadata *TagMetadata) error {
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_TAG_METADATA, metadata.ID, metadataBytes)
+}
+
+func DeleteOneTagMetadata(id string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_TAG_METADATA, id)
+}
@@ -0,0 +1,52 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package tagging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagMetadata(t *testing.T) {
+	metadata := &TagMetadata{ID: "team-a.rollout", Owner: "Team-A", Updated: 2000, MemberCountUpdated: 1000}
+	assert.False(t, metadata.IsExpired(5000))
+	assert.True(t, metadata.IsMemberCountStale())
+
+	metadata.ExpiresAt = 5000
+	metadata.MemberCountUpdated = 2000
+	assert.False(t, metadata.IsExpired(4999))
+	assert.True(t, metadata.IsExpired(5000))
+	assert.False(t, metadata.IsMemberCountStale())
+
+	assert.True(t, (&TagMetadataFilter{}).Matches(metadata))
+	assert.True(t, (&TagMetadataFilter{Owner: "team-a", Prefix: "team-a."}).Matches(metadata))
+	assert.False(t, (&TagMetadataFilter{Owner: "team-b"}).Matches(metadata))
+	assert.False(t, (&TagMetadataFilter{Prefix: "team-b."}).Matches(metadata))
+}
+
+func TestTagJobRejects(t *testing.T) {
+	job := &TagJob{Status: TagJobRunning}
+	assert.False(t, job.IsDone())
+	for i := 0; i < MaxTagJobRejects+5; i++ {
+		job.AddReject(i+1, "member", "reason")
+	}
+	assert.Equal(t, MaxTagJobRejects+5, job.Rejected)
+	assert.Equal(t, MaxTagJobRejects, len(job.Rejects))
+
+	job.Status = TagJobFailed
+	assert.True(t, job.IsDone())
+}
@@ -0,0 +1,113 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package tagging
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+
+	"github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TagNamespace gives a team the ownership of the tags starting with the prefix: only its users and the
+// members of its groups may change or delete them. The longest prefix owns a tag when namespaces are nested.
+type TagNamespace struct {
+	Prefix      string   `json:"prefix"`
+	Description string   `json:"description,omitempty"`
+	OwnerUsers  []string `json:"ownerUsers,omitempty"`
+	OwnerGroups []string `json:"ownerGroups,omitempty"`
+	Updated     int64    `json:"updated,omitempty"`
+	UpdatedBy   string   `json:"updatedBy,omitempty"`
+}
+
+func NewTagNamespaceInf() interface{} {
+	return &TagNamespace{}
+}
+
+// IsOwner is true when the user, or one of the groups, owns the namespace
+func (n *TagNamespace) IsOwner(userName string, groups []string) bool {
+	for _, owner := range n.OwnerUsers {
+		if owner == userName {
+			return true
+		}
+	}
+	for _, owner := range n.OwnerGroups {
+		for _, group := range groups {
+			if owner == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// FindTagNamespace returns the namespace with the longest prefix of the tag, nil when no namespace owns it
+func FindTagNamespace(namespaces []*TagNamespace, tagId string) *TagNamespace {
+	var found *TagNamespace
+	for _, namespace := range namespaces {
+		if strings.HasPrefix(tagId, namespace.Prefix) && (found == nil || len(namespace.Prefix) > len(found.Prefix)) {
+			found = namespace
+		}
+	}
+	return found
+}
+
+func GetTagNamespaceOf(tagId string) *TagNamespace {
+	return FindTagNamespace(GetTagNamespaceList(), tagId)
+}
+
+func GetOneTagNamespace(prefix string) *TagNamespace {
+	inst, err := db.GetSimpleDao().GetOne(xcommon.TABLE_XCONF_TAG_NAMESPACE, prefix)
+	if err != nil {
+		log.Debug(fmt.Sprintf("no TagNamespace found for Prefix: %s", prefix))
+		return nil
+	}
+	return inst.(*TagNamespace)
+}
+
+func GetTagNamespaceList() []*TagNamespace {
+	all := []*TagNamespace{}
+	list, err := db.GetSimpleDao().GetAllAsList(xcommon.TABLE_XCONF_TAG_NAMESPACE, 0)
+	if err != nil {
+		log.Warn("no TagNamespace found")
+		return all
+	}
+	for _, inst := range list {
+		all = append(all, inst.(*TagNamespace))
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Prefix < all[j].Prefix
+	})
+	return all
+}
+
+func SetOneTagNamespace(namespace *TagNamespace) error {
+	namespaceBytes, err := json.Marshal(namespace)
+	if err != nil {
+		return err
+	}
+	return db.GetSimpleDao().SetOne(xcommon.TABLE_XCONF_TAG_NAMESPACE, namespace.Prefix, namespaceBytes)
+}
+
+func DeleteOneTagNamespace(prefix string) error {
+	return db.GetSimpleDao().DeleteOne(xcommon.TABLE_XCONF_TAG_NAMESPACE, prefix)
+}
@@ -0,0 +1,37 @@
+// Copyright 2025 Comcast Cable Communications Management, LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// SPDX-License-Identifier: Apache-2.0
+package tagging
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindTagNamespace(t *testing.T) {
+	namespaces := []*TagNamespace{
+		{Prefix: "team-a.", OwnerUsers: []string{"alice"}},
+		{Prefix: "team-a.canary.", OwnerGroups: []string{"canary-admins"}},
+	}
+	assert.Equal(t, "team-a.", FindTagNamespace(namespaces, "team-a.rollout").Prefix)
+	// the longest prefix owns the tag
+	assert.Equal(t, "team-a.canary.", FindTagNamespace(namespaces, "team-a.canary.wave1").Prefix)
+	assert.Nil(t, FindTagNamespace(namespaces, "team-b.rollout"))
+
+	assert.True(t, namespaces[0].IsOwner("alice", nil))
+	assert.False(t, namespaces[1].IsOwner("alice", []string{"viewers"}))
+	assert.True(t, namespaces[1].IsOwner("bob", []string{"viewers", "canary-admins"}))
+}
@@ -3,13 +3,21 @@ package taggingapi_config
 import "github.com/go-akka/configuration"
 
 type TaggingApiConfig struct {
-	BatchLimit  int
-	WorkerCount int
+	BatchLimit                   int
+	WorkerCount                  int
+	MaintenanceIntervalInSeconds int
+	ReconcileIntervalInSeconds   int
+	ReconcileRepair              string
+	MemberValueEnabled           bool
 }
 
 func NewTaggingApiConfig(conf *configuration.Config) *TaggingApiConfig {
 	return &TaggingApiConfig{
-		BatchLimit:  int(conf.GetInt32("webconfig.xconf.tag_members_batch_limit", 2000)),
-		WorkerCount: int(conf.GetInt32("webconfig.xconf.tag_update_worker_count", 20)),
+		BatchLimit:                   int(conf.GetInt32("webconfig.xconf.tag_members_batch_limit", 2000)),
+		WorkerCount:                  int(conf.GetInt32("webconfig.xconf.tag_update_worker_count", 20)),
+		MaintenanceIntervalInSeconds: int(conf.GetInt32("webconfig.xconf.tag_maintenance_interval_in_secs", 300)),
+		ReconcileIntervalInSeconds:   int(conf.GetInt32("webconfig.xconf.tag_reconcile_interval_in_secs", 0)),
+		ReconcileRepair:              conf.GetString("webconfig.xconf.tag_reconcile_repair", ""),
+		MemberValueEnabled:           conf.GetBoolean("webconfig.xconf.tag_member_value_enabled", false),
 	}
 }
@@ -160,6 +160,23 @@ func TestNewTaggingApiConfig_DefaultValues(t *testing.T) {
 
 	assert.Equal(t, expectedBatchLimit, result.BatchLimit, "Default BatchLimit should be 2000")
 	assert.Equal(t, expectedWorkerCount, result.WorkerCount, "Default WorkerCount should be 20")
+	assert.Equal(t, 300, result.MaintenanceIntervalInSeconds, "Default MaintenanceIntervalInSeconds should be 300")
+}
+
+func TestNewTaggingApiConfig_MaintenanceInterval(t *testing.T) {
+	// Test that the tag maintenance can be disabled from the config
+	configStr := `
+		webconfig {
+			xconf {
+				tag_maintenance_interval_in_secs = 0
+			}
+		}
+	`
+
+	conf := configuration.ParseString(configStr)
+	result := NewTaggingApiConfig(conf)
+
+	assert.Equal(t, 0, result.MaintenanceIntervalInSeconds, "MaintenanceIntervalInSeconds should be read from config")
 }
 
 func TestTaggingApiConfig_FieldTypes(t *testing.T) {
@@ -222,3 +239,17 @@ func TestNewTaggingApiConfig_ConfigKeys(t *testing.T) {
 	assert.Equal(t, 5678, result.WorkerCount, "Should read tag_update_worker_count")
 	// other_unrelated_key should be ignored
 }
+
+func TestNewTaggingApiConfig_MemberValueEnabled(t *testing.T) {
+	// The values of the members are only stored once the value column is migrated
+	assert.False(t, NewTaggingApiConfig(configuration.ParseString("{}")).MemberValueEnabled)
+
+	configStr := `
+		webconfig {
+			xconf {
+				tag_member_value_enabled = true
+			}
+		}
+	`
+	assert.True(t, NewTaggingApiConfig(configuration.ParseString(configStr)).MemberValueEnabled)
+}
@@ -1,6 +1,8 @@
 package taggingapi
 
 import (
+	"time"
+
 	xhttp "github.com/rdkcentral/xconfadmin/http"
 	"github.com/rdkcentral/xconfadmin/taggingapi/tag"
 
@@ -18,6 +20,8 @@ func WebServerInjection(ws *xhttp.WebconfigServer) {
 func XconfTaggingServiceSetup(server *xhttp.WebconfigServer, r *mux.Router) {
 	WebServerInjection(server)
 	routeTaggingServiceApis(r, server)
+	tag.StartTagMaintenance(time.Duration(server.TaggingApiConfig.MaintenanceIntervalInSeconds) * time.Second)
+	tag.StartTagReconciler(time.Duration(server.TaggingApiConfig.ReconcileIntervalInSeconds)*time.Second, server.TaggingApiConfig.ReconcileRepair)
 }
 
 func routeTaggingServiceApis(r *mux.Router, s *xhttp.WebconfigServer) {
@@ -28,17 +32,46 @@ func routeTaggingServiceApis(r *mux.Router, s *xhttp.WebconfigServer) {
 	taggingPath.HandleFunc("", tag.GetAllTagsHandler).Methods("GET").Name("Get-all-tags")
 	taggingPath.HandleFunc("/{tag}", tag.GetTagByIdHandler).Methods("GET").Name("Get-tag-by-id")
 	taggingPath.HandleFunc("/{tag}/members", tag.AddMembersToTagHandler).Methods("PUT").Name("Add-members-to-tag")
+	taggingPath.HandleFunc("/{tag}/import", tag.ImportTagMembersHandler).Methods("POST").Name("Import-tag-members")
+	taggingPath.HandleFunc("/{tag}/reconcile", tag.ReconcileTagHandler).Methods("POST").Name("Reconcile-tag")
+	taggingPath.HandleFunc("/{tag}/copy", tag.CopyTagHandler).Methods("POST").Name("Copy-tag")
+	taggingPath.HandleFunc("/{tag}/rename", tag.RenameTagHandler).Methods("POST").Name("Rename-tag")
 	taggingPath.HandleFunc("/{tag}", tag.DeleteTagHandler).Methods("DELETE").Name("Delete-tag-v2")
 	taggingPath.HandleFunc("/{tag}/members", tag.RemoveMembersFromTagHandler).Methods("DELETE").Name("Remove-members-from-tag")
 	taggingPath.HandleFunc("/{tag}/members/{member}", tag.RemoveMemberFromTagHandler).Methods("DELETE").Name("Remove-member-from-tag")
 
 	taggingPath.HandleFunc("/{tag}/members", tag.GetTagMembersHandler).Methods("GET").Name("Get-tag-members")
+	taggingPath.HandleFunc("/{tag}/audit", tag.GetTagAuditHandler).Methods("GET").Name("Get-tag-audit")
+	taggingPath.HandleFunc("/{tag}/metadata", tag.GetTagMetadataHandler).Methods("GET").Name("Get-tag-metadata")
+	taggingPath.HandleFunc("/{tag}/metadata", tag.SetTagMetadataHandler).Methods("PUT").Name("Set-tag-metadata")
+	taggingPath.HandleFunc("/{tag}/stats", tag.GetTagStatsHandler).Methods("GET").Name("Get-tag-stats")
 
+	taggingPath.HandleFunc("/members/check", tag.CheckTagMembershipHandler).Methods("POST").Name("Check-tag-membership")
 	taggingPath.HandleFunc("/members/{member}", tag.GetTagsByMemberHandler).Methods("GET").Name("Get-tags-by-member")
 	taggingPath.HandleFunc("/members/{member}/values", tag.GetTagsWithValuesByMemberHandler).Methods("GET").Name("Get-tags-with-values-by-member")
 
 	paths = append(paths, taggingPath)
 
+	namespacePath := r.PathPrefix("/taggingService/namespaces").Subrouter()
+	namespacePath.HandleFunc("", tag.GetTagNamespacesHandler).Methods("GET").Name("Get-tag-namespaces")
+	namespacePath.HandleFunc("", tag.SetTagNamespaceHandler).Methods("PUT").Name("Set-tag-namespace")
+	namespacePath.HandleFunc("/{prefix}", tag.DeleteTagNamespaceHandler).Methods("DELETE").Name("Delete-tag-namespace")
+	paths = append(paths, namespacePath)
+
+	operationPath := r.PathPrefix("/taggingService/operations").Subrouter()
+	operationPath.HandleFunc("", tag.StartTagSetOperationHandler).Methods("POST").Name("Start-tag-set-operation")
+	operationPath.HandleFunc("/cohort", tag.StartTagCohortHandler).Methods("POST").Name("Start-tag-cohort")
+	paths = append(paths, operationPath)
+
+	jobPath := r.PathPrefix("/taggingService/jobs").Subrouter()
+	jobPath.HandleFunc("", tag.GetTagJobsHandler).Methods("GET").Name("Get-tag-jobs")
+	jobPath.HandleFunc("/{id}", tag.GetTagJobHandler).Methods("GET").Name("Get-tag-job")
+	paths = append(paths, jobPath)
+
+	statsPath := r.PathPrefix("/taggingService/stats").Subrouter()
+	statsPath.HandleFunc("", tag.GetLargestTagsHandler).Methods("GET").Name("Get-largest-tags")
+	paths = append(paths, statsPath)
+
 	for _, p := range paths {
 		if s.TestOnly() {
 			p.Use(s.NoAuthMiddleware)
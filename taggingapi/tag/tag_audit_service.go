@@ -0,0 +1,138 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// membership mutations recorded in the tag audit log
+const (
+	AuditAddMembers    = "ADD_MEMBERS"
+	AuditRemoveMembers = "REMOVE_MEMBERS"
+	AuditDeleteTag     = "DELETE_TAG"
+	AuditReconcile     = "RECONCILE"
+)
+
+const (
+	DefaultTagAuditLimit = 100
+	MaxTagAuditLimit     = 1000
+	// MaxTagAuditMembers caps the members stored with an entry, the counts are kept whatever the size of the change
+	MaxTagAuditMembers = 100
+
+	// the TagAuditLog table is created by db/migrations/0010_tag_namespace.cql
+	QueryAddTagAuditEntry   = `INSERT INTO "TagAuditLog" (tag_id, created, audit_id, user_name, operation, requested, affected, members, error) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	QueryGetTagAuditEntries = `SELECT created, audit_id, user_name, operation, requested, affected, members, error FROM "TagAuditLog" WHERE tag_id = ? LIMIT ?`
+)
+
+// TagAuditEntry is one change of the members of a tag, the stored members are a sample of at most
+// MaxTagAuditMembers of the ones of the request
+type TagAuditEntry struct {
+	Tag       string   `json:"tag"`
+	Created   int64    `json:"created"`
+	AuditId   string   `json:"auditId"`
+	UserName  string   `json:"userName"`
+	Operation string   `json:"operation"`
+	Requested int      `json:"requested"`
+	Affected  int      `json:"affected"`
+	Members   []string `json:"members,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+func NewTagAuditEntry(w http.ResponseWriter, r *http.Request, tagId string, operation string, members []string, affected int, err error) *TagAuditEntry {
+	entry := &TagAuditEntry{
+		Tag:       tagId,
+		Created:   time.Now().UnixMilli(),
+		UserName:  auth.GetUserNameOrUnknown(r),
+		Operation: operation,
+		Requested: len(members),
+		Affected:  affected,
+		Members:   members,
+	}
+	if xw, ok := w.(*xwhttp.XResponseWriter); ok {
+		entry.AuditId = xw.AuditId()
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	return entry
+}
+
+// RecordTagAudit logs the membership mutation and stores it in the audit log of the tag
+func RecordTagAudit(entry *TagAuditEntry) {
+	log.WithFields(log.Fields{
+		"audit_id":     entry.AuditId,
+		"auth_subject": entry.UserName,
+		"tag":          entry.Tag,
+		"operation":    entry.Operation,
+		"requested":    entry.Requested,
+		"affected":     entry.Affected,
+	}).Info("tag membership changed")
+
+	members := entry.Members
+	if len(members) > MaxTagAuditMembers {
+		members = members[:MaxTagAuditMembers]
+	}
+	membersJson, err := json.Marshal(members)
+	if err != nil {
+		log.Errorf("Unable to marshal the audited members of tag %s: %v", entry.Tag, err)
+		return
+	}
+	err = ds.GetSimpleDao().Modify(QueryAddTagAuditEntry, entry.Tag, strconv.FormatInt(entry.Created, 10), entry.AuditId, entry.UserName,
+		entry.Operation, strconv.Itoa(entry.Requested), strconv.Itoa(entry.Affected), string(membersJson), entry.Error)
+	if err != nil {
+		log.Errorf("Unable to store the audit entry of tag %s: %v", entry.Tag, err)
+	}
+}
+
+// GetTagAuditEntries returns the latest audit entries of the tag first
+func GetTagAuditEntries(tagId string, limit int) ([]*TagAuditEntry, error) {
+	rows, err := ds.GetSimpleDao().Query(QueryGetTagAuditEntries, tagId, strconv.Itoa(limit))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*TagAuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := &TagAuditEntry{Tag: tagId}
+		entry.Created, _ = row["created"].(int64)
+		entry.AuditId, _ = row["audit_id"].(string)
+		entry.UserName, _ = row["user_name"].(string)
+		entry.Operation, _ = row["operation"].(string)
+		entry.Requested, _ = row["requested"].(int)
+		entry.Affected, _ = row["affected"].(int)
+		entry.Error, _ = row["error"].(string)
+		if members, ok := row["members"].(string); ok && members != "" {
+			if err := json.Unmarshal([]byte(members), &entry.Members); err != nil {
+				log.Warnf("Unable to read the audited members of tag %s: %v", tagId, err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,233 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	"github.com/rdkcentral/xconfadmin/taggingapi/percentage"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/rdkcentral/xconfwebconfig/shared"
+
+	"github.com/google/uuid"
+)
+
+// A cohort is the part of a tag or of a mac list whose percent falls in [percentFrom, percentTo). The percent is
+// hashed from the quoted mac as the percent filter does, so that the cohort is the one the devices select.
+const TagJobCohort = "COHORT"
+
+// TagCohortRequest stores the cohort of the tag or of the mac list in the target, a dry run only counts it.
+// The target is a new tag unless append is set, the cohort is then added to its members.
+type TagCohortRequest struct {
+	Tag         string  `json:"tag"`
+	List        string  `json:"list"`
+	PercentFrom float64 `json:"percentFrom"`
+	PercentTo   float64 `json:"percentTo"`
+	Target      string  `json:"target"`
+	Value       string  `json:"value"`
+	Append      bool    `json:"append"`
+	DryRun      bool    `json:"dryRun"`
+}
+
+func validateTagCohortRequest(request *TagCohortRequest) error {
+	if util.IsBlank(request.Tag) == util.IsBlank(request.List) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "either tag or list is required")
+	}
+	if request.PercentFrom < 0 || request.PercentTo > 100 || request.PercentFrom >= request.PercentTo {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "percent range must be within 0 and 100 with percentFrom lower than percentTo")
+	}
+	if request.DryRun {
+		return nil
+	}
+	if util.IsBlank(request.Target) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target is required")
+	}
+	if request.Target == request.Tag {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target must not be the tag of the cohort")
+	}
+	return nil
+}
+
+// getMemberPercent returns the percent of the member in the percent filter, macs are hashed with colons
+func getMemberPercent(member string) float64 {
+	if util.IsValidMacAddress(member) {
+		member = util.NormalizeMacAddress(member)
+	}
+	_, percent := percentage.CalculateHashAndPercent(fmt.Sprintf(`"%v"`, member))
+	return percent
+}
+
+func selectCohortMembers(members []string, percentFrom float64, percentTo float64) []string {
+	cohort := []string{}
+	for _, member := range members {
+		if percent := getMemberPercent(member); percent >= percentFrom && percent < percentTo {
+			cohort = append(cohort, member)
+		}
+	}
+	return cohort
+}
+
+// StartTagCohort stores a job for the cohort and runs it in the background
+func StartTagCohort(r *http.Request, request *TagCohortRequest, now time.Time) (*xtagging.TagJob, error) {
+	if err := validateTagCohortRequest(request); err != nil {
+		return nil, err
+	}
+	var listMembers []string
+	if request.List != "" {
+		list, err := shared.GetGenericNamedListOneDB(request.List)
+		if err != nil || list == nil || list.TypeName != shared.MAC_LIST {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("mac list %s not found", request.List))
+		}
+		listMembers = list.Data
+	}
+	if !request.DryRun && !request.Append {
+		targetBuckets, err := getPopulatedBuckets(request.Target)
+		if err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		if len(targetBuckets) > 0 || xtagging.GetOneTagMetadata(request.Target) != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("tag %s already exists, set append to add the cohort to it", request.Target))
+		}
+	}
+	job := &xtagging.TagJob{
+		ID:          uuid.New().String(),
+		Type:        TagJobCohort,
+		List:        request.List,
+		PercentFrom: request.PercentFrom,
+		PercentTo:   request.PercentTo,
+		Value:       request.Value,
+		DryRun:      request.DryRun,
+		Status:      xtagging.TagJobRunning,
+		CreatedBy:   auth.GetUserNameOrUnknown(r),
+		Created:     now.UnixMilli(),
+		Updated:     now.UnixMilli(),
+	}
+	if request.Tag != "" {
+		job.Sources = []string{request.Tag}
+	}
+	if !request.DryRun {
+		job.Target = request.Target
+	}
+	if err := xtagging.SetOneTagJob(job); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	go runTagCohort(job, listMembers)
+	return job, nil
+}
+
+// the cohort jobs read the source buckets and store the cohort through these functions, the tests replace them
+var (
+	getCohortBucketsFunc       = getPopulatedBuckets
+	fetchCohortBucketFunc      = fetchBucketMembersWithLimit
+	storeCohortMembersFunc     = AddMembersWithXdas
+	recordCohortAuditFunc      = RecordTagAudit
+	touchCohortTagMetadataFunc = touchTagMetadata
+)
+
+// runTagCohort selects the cohort of the list members, or of the tag bucket by bucket.
+// The list members are normalized as the imported members and the invalid ones are rejected.
+func runTagCohort(job *xtagging.TagJob, listMembers []string) {
+	var jobErr error
+	pending := []string{}
+	flush := func(all bool) {
+		for jobErr == nil && len(pending) > 0 && (all || len(pending) >= MaxBatchSizeV2) {
+			chunk := pending[:min(MaxBatchSizeV2, len(pending))]
+			stored, err := storeCohortMembersFunc(job.Target, chunk, job.Value)
+			job.Stored += stored
+			if err != nil {
+				jobErr = fmt.Errorf("failed to store members in tag %s: %w", job.Target, err)
+			}
+			pending = pending[len(chunk):]
+		}
+	}
+	addCohort := func(members []string) {
+		cohort := selectCohortMembers(members, job.PercentFrom, job.PercentTo)
+		job.ResultCount += len(cohort)
+		if !job.DryRun {
+			pending = append(pending, cohort...)
+			flush(false)
+		}
+	}
+
+	if job.List != "" {
+		job.Total = len(listMembers)
+		for start := 0; start < len(listMembers) && jobErr == nil; start += MaxBatchSizeV2 {
+			chunk := listMembers[start:min(start+MaxBatchSizeV2, len(listMembers))]
+			members := make([]string, 0, len(chunk))
+			for i, member := range chunk {
+				normalized, reason := normalizeImportedMember(member, true)
+				if reason != "" {
+					job.AddReject(start+i+1, member, reason)
+				} else if normalized != "" {
+					members = append(members, normalized)
+				}
+			}
+			addCohort(members)
+			job.Processed += len(chunk)
+			if isTagJobHeartbeatDue(job) {
+				saveTagJobProgress(job)
+			}
+		}
+	} else {
+		tagId := job.Sources[0]
+		buckets, err := getCohortBucketsFunc(tagId)
+		if err != nil {
+			finishTagJob(job, fmt.Errorf("failed to get populated buckets of tag %s: %w", tagId, err))
+			return
+		}
+		job.Total = len(buckets)
+		saveTagJobProgress(job)
+		for _, bucketId := range buckets {
+			members, err := fetchCohortBucketFunc(tagId, bucketId, "", math.MaxInt32)
+			if err != nil {
+				jobErr = fmt.Errorf("failed to fetch bucket %d of tag %s: %w", bucketId, tagId, err)
+				break
+			}
+			addCohort(members)
+			if jobErr != nil {
+				break
+			}
+			job.Processed++
+			if job.Processed%TagJobProgressInterval == 0 || isTagJobHeartbeatDue(job) {
+				saveTagJobProgress(job)
+			}
+		}
+	}
+	flush(true)
+
+	if !job.DryRun {
+		auditEntry := &TagAuditEntry{Tag: job.Target, Created: time.Now().UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+			Operation: AuditAddMembers, Requested: job.ResultCount, Affected: job.Stored}
+		if jobErr != nil {
+			auditEntry.Error = jobErr.Error()
+		}
+		recordCohortAuditFunc(auditEntry)
+		if job.Stored > 0 {
+			touchCohortTagMetadataFunc(job.Target, job.CreatedBy, time.Now())
+		}
+	}
+	finishTagJob(job, jobErr)
+}
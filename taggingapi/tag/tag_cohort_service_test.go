@@ -0,0 +1,150 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	"github.com/rdkcentral/xconfadmin/taggingapi/percentage"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTagCohortRequest(t *testing.T) {
+	assert.Nil(t, validateTagCohortRequest(&TagCohortRequest{Tag: "a", PercentFrom: 0, PercentTo: 10, Target: "b"}))
+	assert.Nil(t, validateTagCohortRequest(&TagCohortRequest{List: "macs", PercentFrom: 90, PercentTo: 100, DryRun: true}))
+
+	invalid := []*TagCohortRequest{
+		{PercentTo: 10, Target: "b"},
+		{Tag: "a", List: "macs", PercentTo: 10, Target: "b"},
+		{Tag: "a", PercentFrom: 10, PercentTo: 10, Target: "b"},
+		{Tag: "a", PercentFrom: -1, PercentTo: 10, Target: "b"},
+		{Tag: "a", PercentTo: 101, Target: "b"},
+		{Tag: "a", PercentTo: 10},
+		{Tag: "a", PercentTo: 10, Target: "a"},
+	}
+	for _, request := range invalid {
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagCohortRequest(request)), request)
+	}
+}
+
+func TestGetMemberPercentMatchesPercentFilter(t *testing.T) {
+	_, expected := percentage.CalculateHashAndPercent(fmt.Sprintf(`"%v"`, "AA:BB:CC:DD:EE:FF"))
+	assert.Equal(t, expected, getMemberPercent("AA:BB:CC:DD:EE:FF"))
+	assert.Equal(t, expected, getMemberPercent("AABBCCDDEEFF"))
+	assert.Equal(t, expected, getMemberPercent("aa-bb-cc-dd-ee-ff"))
+}
+
+func TestSelectCohortMembers(t *testing.T) {
+	members := []string{}
+	for i := 0; i < 1000; i++ {
+		members = append(members, fmt.Sprintf("AA:BB:CC:DD:%02X:%02X", i/256, i%256))
+	}
+	lower := selectCohortMembers(members, 0, 10)
+	upper := selectCohortMembers(members, 10, 100)
+	assert.Equal(t, len(members), len(lower)+len(upper))
+	assert.InDelta(t, 100, len(lower), 40)
+	for _, member := range lower {
+		assert.Less(t, getMemberPercent(member), 10.0)
+	}
+	assert.Equal(t, lower, selectCohortMembers(members, 0, 10))
+}
+
+// withCohortStore serves the source buckets of the cohort jobs and keeps the members they store and the audit entries
+func withCohortStore(t *testing.T, sourceBuckets map[int][]string) (map[string][]string, *[]*TagAuditEntry) {
+	withTagJobStore(t)
+	stored := map[string][]string{}
+	audits := []*TagAuditEntry{}
+	originalGet, originalFetch, originalStore := getCohortBucketsFunc, fetchCohortBucketFunc, storeCohortMembersFunc
+	originalAudit, originalTouch := recordCohortAuditFunc, touchCohortTagMetadataFunc
+	getCohortBucketsFunc = func(tagId string) ([]int, error) {
+		buckets := []int{}
+		for bucketId := range sourceBuckets {
+			buckets = append(buckets, bucketId)
+		}
+		sort.Ints(buckets)
+		return buckets, nil
+	}
+	fetchCohortBucketFunc = func(tagId string, bucketId int, lastMember string, limit int) ([]string, error) {
+		return sourceBuckets[bucketId], nil
+	}
+	storeCohortMembersFunc = func(tagId string, members []string, tagValue string) (int, error) {
+		stored[tagId] = append(stored[tagId], members...)
+		return len(members), nil
+	}
+	recordCohortAuditFunc = func(entry *TagAuditEntry) { audits = append(audits, entry) }
+	touchCohortTagMetadataFunc = func(tagId string, userName string, now time.Time) {}
+	t.Cleanup(func() {
+		getCohortBucketsFunc, fetchCohortBucketFunc, storeCohortMembersFunc = originalGet, originalFetch, originalStore
+		recordCohortAuditFunc, touchCohortTagMetadataFunc = originalAudit, originalTouch
+	})
+	return stored, &audits
+}
+
+func TestRunTagCohortStoresTheCohortOfTheTag(t *testing.T) {
+	sourceBuckets := map[int][]string{}
+	members := []string{}
+	for i := 0; i < 600; i++ {
+		member := fmt.Sprintf("AA:BB:CC:DD:%02X:%02X", i/256, i%256)
+		sourceBuckets[i%3] = append(sourceBuckets[i%3], member)
+		members = append(members, member)
+	}
+	stored, audits := withCohortStore(t, sourceBuckets)
+
+	job := &xtagging.TagJob{ID: "cohort", Type: TagJobCohort, Sources: []string{"source"}, Target: "target", PercentTo: 30}
+	runTagCohort(job, nil)
+
+	expected := selectCohortMembers(members, 0, 30)
+	assert.NotEmpty(t, expected)
+	assert.ElementsMatch(t, expected, stored["target"])
+	assert.Equal(t, xtagging.TagJobCompleted, job.Status)
+	assert.Equal(t, 3, job.Total)
+	assert.Equal(t, 3, job.Processed)
+	assert.Equal(t, len(expected), job.ResultCount)
+	assert.Equal(t, len(expected), job.Stored)
+	assert.Len(t, *audits, 1)
+	assert.Equal(t, len(expected), (*audits)[0].Affected)
+
+	dryRun := &xtagging.TagJob{ID: "dry-run", Type: TagJobCohort, Sources: []string{"source"}, PercentTo: 30, DryRun: true}
+	runTagCohort(dryRun, nil)
+	assert.Equal(t, len(expected), dryRun.ResultCount)
+	assert.Equal(t, 0, dryRun.Stored)
+	assert.Len(t, *audits, 1)
+}
+
+func TestRunTagCohortNormalizesTheListMembers(t *testing.T) {
+	stored, _ := withCohortStore(t, nil)
+
+	list := []string{" aabbccddeeff ", "aa:bb:cc:dd:ee:f0", "", "aa bb"}
+	job := &xtagging.TagJob{ID: "cohort", Type: TagJobCohort, List: "macs", Target: "target", PercentTo: 100}
+	runTagCohort(job, list)
+
+	assert.ElementsMatch(t, []string{"AABBCCDDEF01", "AA:BB:CC:DD:EE:F0"}, stored["target"])
+	assert.Equal(t, xtagging.TagJobCompleted, job.Status)
+	assert.Equal(t, len(list), job.Total)
+	assert.Equal(t, len(list), job.Processed)
+	assert.Equal(t, 1, job.Rejected)
+	assert.Equal(t, 4, job.Rejects[0].Line)
+}
@@ -0,0 +1,313 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ru "github.com/rdkcentral/xconfwebconfig/rulesengine"
+	"github.com/rdkcentral/xconfwebconfig/shared/firmware"
+	xwrfc "github.com/rdkcentral/xconfwebconfig/shared/rfc"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// A copy adds the members of a tag to the target bucket by bucket. A rename moves them chunk by chunk, each
+// chunk is stored in the target before it is removed from the source, so that after a crash every member is
+// still in one of the tags and the rename is resumed by the maintenance. The rules with a condition on the
+// renamed tag are reported, they are changed by their owners.
+const (
+	TagJobCopy   = "COPY"
+	TagJobRename = "RENAME"
+
+	// the types of the rules which reference a tag
+	FirmwareRuleReference = "FIRMWARE_RULE"
+	FeatureRuleReference  = "FEATURE_RULE"
+
+	// TagCopyStallTimeout is the time without progress after which a copy or a rename is resumed by the maintenance
+	TagCopyStallTimeout = 5 * time.Minute
+)
+
+// TagCopyRequest copies or renames a tag to the target, the members keep their own value in XDAS unless a value is set
+type TagCopyRequest struct {
+	Target string `json:"target"`
+	Value  string `json:"value"`
+}
+
+func validateTagCopyRequest(tagId string, request *TagCopyRequest) error {
+	if util.IsBlank(request.Target) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target is required")
+	}
+	if request.Target == tagId {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target must not be the tag itself")
+	}
+	return nil
+}
+
+// StartTagCopy stores a job which copies the tag to the target, or renames it, and runs it in the background.
+// A tag is only renamed to a tag which does not exist.
+func StartTagCopy(r *http.Request, tagId string, request *TagCopyRequest, rename bool, now time.Time) (*xtagging.TagJob, error) {
+	if err := validateTagCopyRequest(tagId, request); err != nil {
+		return nil, err
+	}
+	buckets, err := getPopulatedBuckets(tagId)
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	if len(buckets) == 0 {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("tag %s not found", tagId))
+	}
+	job := &xtagging.TagJob{
+		ID:        uuid.New().String(),
+		Type:      TagJobCopy,
+		Sources:   []string{tagId},
+		Target:    request.Target,
+		Value:     request.Value,
+		Status:    xtagging.TagJobRunning,
+		Total:     len(buckets),
+		Owner:     tagJobOwner,
+		CreatedBy: auth.GetUserNameOrUnknown(r),
+		Created:   now.UnixMilli(),
+		Updated:   now.UnixMilli(),
+	}
+	if rename {
+		targetBuckets, err := getPopulatedBuckets(request.Target)
+		if err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		if len(targetBuckets) > 0 || xtagging.GetOneTagMetadata(request.Target) != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusConflict, fmt.Sprintf("tag %s already exists", request.Target))
+		}
+		job.Type = TagJobRename
+		job.References = findTagRuleReferences(tagId)
+	}
+	if err := xtagging.SetOneTagJob(job); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	go runTagCopy(job)
+	return job, nil
+}
+
+// findTagRuleReferences returns the firmware and feature rules with a condition on the tag
+func findTagRuleReferences(tagId string) []*xtagging.TagRuleReference {
+	references := []*xtagging.TagRuleReference{}
+	firmwareRules, err := firmware.GetFirmwareRuleAllAsListDBForAdmin()
+	if err != nil {
+		log.Warnf("Unable to get the firmware rules which reference tag %s: %v", tagId, err)
+	}
+	for _, rule := range firmwareRules {
+		if isTagInRule(&rule.Rule, tagId) {
+			references = append(references, &xtagging.TagRuleReference{Type: FirmwareRuleReference, ID: rule.ID, Name: rule.Name, ApplicationType: rule.ApplicationType})
+		}
+	}
+	for _, rule := range xwrfc.GetFeatureRuleList() {
+		if rule.Rule != nil && isTagInRule(rule.Rule, tagId) {
+			references = append(references, &xtagging.TagRuleReference{Type: FeatureRuleReference, ID: rule.Id, Name: rule.Name, ApplicationType: rule.ApplicationType})
+		}
+	}
+	return references
+}
+
+// isTagInRule is true when a condition has the tag as free arg, with or without the prefix of the XDAS groups
+func isTagInRule(rule *ru.Rule, tagId string) bool {
+	for _, condition := range ru.ToConditions(rule) {
+		if condition.GetFreeArg() == nil {
+			continue
+		}
+		if name := condition.GetFreeArg().Name; name == tagId || name == Prefix+tagId {
+			return true
+		}
+	}
+	return false
+}
+
+// runTagCopy copies the buckets of the tag to the target and records the audit of the copied members.
+// The copy stops once another instance has taken the job over.
+func runTagCopy(job *xtagging.TagJob) {
+	tagId := job.Sources[0]
+	rename := job.Type == TagJobRename
+	stored := job.Stored
+	populatedBuckets, err := getPopulatedBuckets(tagId)
+	if err != nil {
+		finishTagJob(job, fmt.Errorf("failed to get populated buckets of tag %s: %w", tagId, err))
+		return
+	}
+	owned, jobErr := copyTagBuckets(job, populatedBuckets, func(bucketId int) error {
+		return copyTagBucket(job, tagId, bucketId, rename)
+	})
+	if !owned {
+		log.Warnf("Tag job %s has been taken over by another instance, stopping", job.ID)
+		return
+	}
+	if jobErr == nil && job.Rejected > 0 {
+		jobErr = fmt.Errorf("%d members have not been copied", job.Rejected)
+	}
+
+	now := time.Now()
+	auditEntry := &TagAuditEntry{Tag: job.Target, Created: now.UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+		Operation: AuditAddMembers, Requested: job.ResultCount, Affected: job.Stored}
+	if jobErr != nil {
+		auditEntry.Error = jobErr.Error()
+	}
+	RecordTagAudit(auditEntry)
+	if rename {
+		auditEntry := &TagAuditEntry{Tag: tagId, Created: now.UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+			Operation: AuditRemoveMembers, Requested: job.ResultCount, Affected: job.Stored}
+		if jobErr != nil {
+			auditEntry.Error = jobErr.Error()
+		}
+		RecordTagAudit(auditEntry)
+		if jobErr == nil {
+			moveTagMetadata(tagId, job.Target, job.CreatedBy, now)
+		}
+	}
+	if job.Stored > stored {
+		touchTagMetadata(job.Target, job.CreatedBy, now)
+	}
+	finishTagJob(job, jobErr)
+}
+
+// copyTagBuckets copies the buckets in order from the one after the last completed bucket, so that a resumed job
+// neither skips nor copies again the buckets which have been added or drained since. The progress is saved after
+// each bucket, false is returned once the job is no longer owned by this instance.
+func copyTagBuckets(job *xtagging.TagJob, populatedBuckets []int, copyBucket func(bucketId int) error) (bool, error) {
+	sort.Ints(populatedBuckets)
+	buckets := []int{}
+	for _, bucketId := range populatedBuckets {
+		if job.LastBucket == nil || bucketId > *job.LastBucket {
+			buckets = append(buckets, bucketId)
+		}
+	}
+	job.Total = job.Processed + len(buckets)
+
+	for _, bucketId := range buckets {
+		if !ownsTagJob(job) {
+			return false, nil
+		}
+		if err := copyBucket(bucketId); err != nil {
+			return true, err
+		}
+		job.Processed++
+		lastBucket := bucketId
+		job.LastBucket = &lastBucket
+		saveTagJobProgress(job)
+	}
+	return true, nil
+}
+
+// copyTagBucket stores the members of the bucket in the target with their value, a rename then removes them from
+// the tag. The members which are not stored in the target are kept in the tag and rejected.
+func copyTagBucket(job *xtagging.TagJob, tagId string, bucketId int, rename bool) error {
+	lastMember := ""
+	for {
+		chunk, values, err := getMemberValuesFromBucket(tagId, bucketId, lastMember, MaxBatchSizeV2)
+		if err != nil {
+			return fmt.Errorf("failed to fetch bucket %d of tag %s: %w", bucketId, tagId, err)
+		}
+		if len(chunk) == 0 {
+			return nil
+		}
+		job.ResultCount += len(chunk)
+		if job.Value != "" {
+			for member := range values {
+				values[member] = job.Value
+			}
+		}
+		saved := []string{}
+		for value, members := range groupMembersByValue(chunk, values) {
+			savedWithValue, err := addMembersToXdas(job.Target, members, value)
+			if err != nil {
+				return fmt.Errorf("failed to store members in tag %s: %w", job.Target, err)
+			}
+			if len(savedWithValue) > 0 {
+				if err := AddMembersWithValue(job.Target, savedWithValue, value, 0); err != nil {
+					return fmt.Errorf("failed to store members in tag %s: %w", job.Target, err)
+				}
+				job.Stored += len(savedWithValue)
+			}
+			saved = append(saved, savedWithValue...)
+		}
+		for _, member := range subtractMembers(chunk, saved) {
+			job.AddReject(0, member, fmt.Sprintf("not stored in tag %s", job.Target))
+		}
+		if rename && len(saved) > 0 {
+			removed, err := removeMembersFromXDAS(tagId, saved)
+			if err != nil {
+				return fmt.Errorf("failed to remove members from tag %s: %w", tagId, err)
+			}
+			if len(removed) > 0 {
+				if err := RemoveMembers(tagId, removed); err != nil {
+					return fmt.Errorf("failed to remove members from tag %s: %w", tagId, err)
+				}
+			}
+			for _, member := range subtractMembers(saved, removed) {
+				job.AddReject(0, member, fmt.Sprintf("not removed from tag %s", tagId))
+			}
+		}
+		saveTagJobProgress(job)
+		if len(chunk) < MaxBatchSizeV2 {
+			return nil
+		}
+		lastMember = chunk[len(chunk)-1]
+	}
+}
+
+// moveTagMetadata keeps the description, the owner and the expiry of a renamed tag
+func moveTagMetadata(tagId string, target string, userName string, now time.Time) {
+	metadata := xtagging.GetOneTagMetadata(tagId)
+	if metadata == nil {
+		return
+	}
+	metadata.ID = target
+	metadata.Updated = now.UnixMilli()
+	metadata.UpdatedBy = userName
+	if err := xtagging.SetOneTagMetadata(metadata); err != nil {
+		log.Errorf("Unable to move the metadata of tag %s to %s: %v", tagId, target, err)
+		return
+	}
+	if err := xtagging.DeleteOneTagMetadata(tagId); err != nil {
+		log.Errorf("Unable to delete the metadata of renamed tag %s: %v", tagId, err)
+	}
+}
+
+// resumeStalledTagCopies takes over the copies and renames whose instance stopped, it runs under the lock of
+// the job maintenance so a stalled job is claimed by one instance
+func resumeStalledTagCopies(now time.Time) {
+	stalledSince := now.Add(-TagCopyStallTimeout).UnixMilli()
+	for _, job := range xtagging.GetTagJobList() {
+		if (job.Type != TagJobCopy && job.Type != TagJobRename) || job.IsDone() || job.Updated > stalledSince {
+			continue
+		}
+		if err := claimTagJob(job); err != nil {
+			log.Errorf("Unable to claim tag job %s: %v", job.ID, err)
+			continue
+		}
+		log.Infof("Resuming tag job %s (%s) of tag %s to %s from bucket %d/%d", job.ID, job.Type, job.Sources[0], job.Target, job.Processed, job.Total)
+		go runTagCopy(job)
+	}
+}
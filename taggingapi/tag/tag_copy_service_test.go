@@ -0,0 +1,143 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ru "github.com/rdkcentral/xconfwebconfig/rulesengine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTagCopyRequest(t *testing.T) {
+	assert.Nil(t, validateTagCopyRequest("a", &TagCopyRequest{Target: "b"}))
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagCopyRequest("a", &TagCopyRequest{Target: " "})))
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagCopyRequest("a", &TagCopyRequest{Target: "a"})))
+}
+
+func TestIsTagInRule(t *testing.T) {
+	newRule := func(names ...string) *ru.Rule {
+		rule := &ru.Rule{}
+		for _, name := range names {
+			freeArg := ru.FreeArg{Name: name}
+			part := ru.Rule{}
+			part.SetCondition(ru.NewCondition(&freeArg, "IS", ru.NewFixedArg("true")))
+			rule.CompoundParts = append(rule.CompoundParts, part)
+		}
+		return rule
+	}
+	assert.True(t, isTagInRule(newRule("model", "tag1"), "tag1"))
+	assert.True(t, isTagInRule(newRule(Prefix+"tag1"), "tag1"))
+	assert.False(t, isTagInRule(newRule("model", "tag10"), "tag1"))
+	assert.False(t, isTagInRule(&ru.Rule{}, "tag1"))
+}
+
+// withTagJobStore keeps the saved jobs in memory, a saved job is stored as a copy
+func withTagJobStore(t *testing.T) map[string]*xtagging.TagJob {
+	jobs := map[string]*xtagging.TagJob{}
+	var mutex sync.Mutex
+	originalGet, originalSet := getTagJobFunc, setTagJobFunc
+	getTagJobFunc = func(id string) *xtagging.TagJob {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if job, ok := jobs[id]; ok {
+			stored := *job
+			return &stored
+		}
+		return nil
+	}
+	setTagJobFunc = func(job *xtagging.TagJob) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		stored := *job
+		if job.LastBucket != nil {
+			lastBucket := *job.LastBucket
+			stored.LastBucket = &lastBucket
+		}
+		jobs[job.ID] = &stored
+		return nil
+	}
+	t.Cleanup(func() { getTagJobFunc, setTagJobFunc = originalGet, originalSet })
+	return jobs
+}
+
+func TestCopyTagBucketsResumesAfterLastCompletedBucket(t *testing.T) {
+	jobs := withTagJobStore(t)
+	job := &xtagging.TagJob{ID: "job-1", Type: TagJobCopy, Sources: []string{"a"}, Target: "b", Status: xtagging.TagJobRunning, Owner: tagJobOwner}
+	assert.Nil(t, setTagJobFunc(job))
+
+	// the instance crashes while it copies bucket 7, buckets 1, 3 and 5 are completed
+	copied := []int{}
+	owned, err := copyTagBuckets(job, []int{7, 3, 1, 9, 5}, func(bucketId int) error {
+		if bucketId == 7 {
+			return errors.New("instance stopped")
+		}
+		copied = append(copied, bucketId)
+		return nil
+	})
+	assert.True(t, owned)
+	assert.EqualError(t, err, "instance stopped")
+	assert.Equal(t, []int{1, 3, 5}, copied)
+	assert.Equal(t, 5, *jobs["job-1"].LastBucket)
+	assert.Equal(t, 3, jobs["job-1"].Processed)
+
+	// the job is resumed from its saved progress, bucket 2 has been populated and bucket 9 drained in the
+	// meantime, the completed buckets are not copied again
+	resumed := getTagJobFunc("job-1")
+	assert.Nil(t, claimTagJob(resumed))
+	copied = []int{}
+	owned, err = copyTagBuckets(resumed, []int{1, 2, 3, 5, 7}, func(bucketId int) error {
+		copied = append(copied, bucketId)
+		return nil
+	})
+	assert.True(t, owned)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{7}, copied)
+	assert.Equal(t, 4, resumed.Total)
+	assert.Equal(t, 7, *jobs["job-1"].LastBucket)
+	assert.Equal(t, 4, jobs["job-1"].Processed)
+}
+
+func TestCopyTagBucketsStopsWhenTakenOver(t *testing.T) {
+	jobs := withTagJobStore(t)
+	jobs["job-2"] = &xtagging.TagJob{ID: "job-2", Type: TagJobCopy, Sources: []string{"a"}, Target: "b", Status: xtagging.TagJobRunning, Owner: tagJobOwner}
+	// another instance claims the stalled job once the progress of the first bucket is saved
+	saveTagJob := setTagJobFunc
+	setTagJobFunc = func(job *xtagging.TagJob) error {
+		err := saveTagJob(job)
+		jobs[job.ID].Owner = "other-instance"
+		return err
+	}
+
+	copied := []int{}
+	owned, err := copyTagBuckets(getTagJobFunc("job-2"), []int{1, 2, 3}, func(bucketId int) error {
+		copied = append(copied, bucketId)
+		return nil
+	})
+	assert.False(t, owned)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, copied)
+	assert.Equal(t, 1, *jobs["job-2"].LastBucket)
+}
@@ -6,8 +6,10 @@ import (
 	"net/http"
 
 	"github.com/gorilla/mux"
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
 	"github.com/rdkcentral/xconfadmin/common"
 	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
 )
 
 const (
@@ -27,6 +29,10 @@ func GetTagsByMemberHandler(w http.ResponseWriter, r *http.Request) {
 		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Member)))
 		return
 	}
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
 
 	tags, err := GetTagsByMember(member)
 	if err != nil {
@@ -42,12 +48,53 @@ func GetTagsByMemberHandler(w http.ResponseWriter, r *http.Request) {
 	xhttp.WriteXconfResponse(w, http.StatusOK, respBytes)
 }
 
+// CheckTagMembershipHandler returns the tags each of the members belongs to, out of the requested tags
+func CheckTagMembershipHandler(w http.ResponseWriter, r *http.Request) {
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	request := TagMembershipRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	if err := validateTagMembershipRequest(&request); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	for _, tagId := range request.Tags {
+		if err := auth.CanReadTag(r, tagId); err != nil {
+			xhttp.WriteXconfErrorResponse(w, err)
+			return
+		}
+	}
+
+	membership, err := CheckTagMembership(request.Members, request.Tags)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
+	respBytes, err := json.Marshal(membership)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, http.StatusOK, respBytes)
+}
+
 func GetTagsWithValuesByMemberHandler(w http.ResponseWriter, r *http.Request) {
 	member, found := mux.Vars(r)[common.Member]
 	if !found {
 		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Member)))
 		return
 	}
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
 
 	tags, err := GetTagsWithValuesByMember(member)
 	if err != nil {
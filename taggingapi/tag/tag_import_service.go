@@ -0,0 +1,345 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// The uploaded members are staged in chunks of TagImportChunk so that an import is resumed after a restart,
+// the table is created by db/migrations/0014_tag_import_chunk.cql.
+const (
+	TagJobImport = "IMPORT"
+
+	// the formats of the uploaded files, a csv has the member in its first column
+	TagImportCsv    = "csv"
+	TagImportNdjson = "ndjson"
+
+	MaxImportedMemberLength = 256
+	MaxNdjsonLineLength     = 64 * 1024
+	TagImportMaxAttempts    = 3
+	TagImportRetryDelay     = time.Second
+	// TagImportStallTimeout is the time without progress after which an import is resumed by the maintenance
+	TagImportStallTimeout = 5 * time.Minute
+
+	QueryAddTagImportChunk    = `INSERT INTO "TagImportChunk" (job_id, chunk_id, members) VALUES (?, ?, ?) USING TTL ?`
+	QueryGetTagImportChunk    = `SELECT members FROM "TagImportChunk" WHERE job_id = ? AND chunk_id = ?`
+	QueryDeleteTagImportChunk = `DELETE FROM "TagImportChunk" WHERE job_id = ? AND chunk_id = ?`
+)
+
+// TagImportRequest is an upload of the members of a tag, toEstb converts the ecm macs to estb macs
+type TagImportRequest struct {
+	Tag    string
+	Format string
+	Value  string
+	ToEstb bool
+}
+
+func GetTagImportFormat(format string, contentType string) (string, error) {
+	switch {
+	case strings.EqualFold(format, TagImportCsv) || (format == "" && strings.HasPrefix(contentType, "text/csv")):
+		return TagImportCsv, nil
+	case strings.EqualFold(format, TagImportNdjson) || (format == "" && strings.HasPrefix(contentType, "application/x-ndjson")):
+		return TagImportNdjson, nil
+	}
+	return "", xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("format must be %s or %s", TagImportCsv, TagImportNdjson))
+}
+
+// normalizeImportedMember returns the stored member or the reason it is rejected, a blank member is skipped
+func normalizeImportedMember(member string, toEstb bool) (string, string) {
+	member = ToNormalized(member)
+	if toEstb {
+		member = ToEstbIfMac(member)
+	}
+	if len(member) > MaxImportedMemberLength {
+		return "", fmt.Sprintf("member is longer than %d characters", MaxImportedMemberLength)
+	}
+	if strings.ContainsAny(member, " \t,") {
+		return "", "member contains a separator"
+	}
+	return member, ""
+}
+
+// StartTagImport stages the uploaded members and imports them in the background.
+// The rows which cannot be read or normalized are rejected, an unreadable upload fails the job.
+func StartTagImport(r *http.Request, request *TagImportRequest, body io.Reader, now time.Time) (*xtagging.TagJob, error) {
+	job := &xtagging.TagJob{
+		ID:        uuid.New().String(),
+		Type:      TagJobImport,
+		Target:    request.Tag,
+		Value:     request.Value,
+		Status:    xtagging.TagJobUploading,
+		Owner:     tagJobOwner,
+		CreatedBy: auth.GetUserNameOrUnknown(r),
+		Created:   now.UnixMilli(),
+		Updated:   now.UnixMilli(),
+	}
+	if err := xtagging.SetOneTagJob(job); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+
+	chunk := make([]string, 0, MaxBatchSizeV2)
+	addMember := func(line int, member string) error {
+		normalized, reason := normalizeImportedMember(member, request.ToEstb)
+		if reason != "" {
+			job.AddReject(line, member, reason)
+			return nil
+		}
+		if normalized == "" {
+			return nil
+		}
+		job.ResultCount++
+		chunk = append(chunk, normalized)
+		if len(chunk) < MaxBatchSizeV2 {
+			return nil
+		}
+		err := stageTagImportChunk(job, chunk)
+		chunk = chunk[:0]
+		return err
+	}
+
+	var err error
+	if request.Format == TagImportCsv {
+		err = readCsvMembers(body, job, addMember)
+	} else {
+		err = readNdjsonMembers(body, job, addMember)
+	}
+	if err == nil && len(chunk) > 0 {
+		err = stageTagImportChunk(job, chunk)
+	}
+	if err != nil {
+		finishTagJob(job, fmt.Errorf("upload failed: %w", err))
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, job.Error)
+	}
+
+	job.Status = xtagging.TagJobRunning
+	saveTagJobProgress(job)
+	go runTagImport(job)
+	return job, nil
+}
+
+func readCsvMembers(body io.Reader, job *xtagging.TagJob, addMember func(int, string) error) error {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	first := true
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			job.AddReject(parseErr.Line, "", parseErr.Err.Error())
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		line, _ := reader.FieldPos(0)
+		// an optional header names the member column
+		if first && strings.EqualFold(strings.TrimSpace(record[0]), "member") {
+			first = false
+			continue
+		}
+		first = false
+		if err := addMember(line, record[0]); err != nil {
+			return err
+		}
+	}
+}
+
+// readNdjsonMembers reads a member per line, either as a json string or as an object with a member field
+func readNdjsonMembers(body io.Reader, job *xtagging.TagJob, addMember func(int, string) error) error {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 4096), MaxNdjsonLineLength)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var member string
+		if strings.HasPrefix(text, "{") {
+			object := struct {
+				Member string `json:"member"`
+			}{}
+			if err := json.Unmarshal([]byte(text), &object); err != nil {
+				job.AddReject(line, "", fmt.Sprintf("invalid json: %v", err))
+				continue
+			}
+			member = object.Member
+		} else if err := json.Unmarshal([]byte(text), &member); err != nil {
+			job.AddReject(line, "", fmt.Sprintf("invalid json: %v", err))
+			continue
+		}
+		if err := addMember(line, member); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+func stageTagImportChunk(job *xtagging.TagJob, members []string) error {
+	membersJson, err := json.Marshal(members)
+	if err != nil {
+		return err
+	}
+	ttl := strconv.Itoa(int(TagJobRetention.Seconds()))
+	if err := ds.GetSimpleDao().Modify(QueryAddTagImportChunk, job.ID, strconv.Itoa(job.Total), string(membersJson), ttl); err != nil {
+		return err
+	}
+	job.Total++
+	if job.Total%TagJobProgressInterval == 0 || isTagJobHeartbeatDue(job) {
+		saveTagJobProgress(job)
+	}
+	return nil
+}
+
+func getTagImportChunk(jobId string, chunkId int) ([]string, error) {
+	rows, err := ds.GetSimpleDao().Query(QueryGetTagImportChunk, jobId, strconv.Itoa(chunkId))
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("chunk %d of tag import %s not found", chunkId, jobId)
+	}
+	members := []string{}
+	membersJson, _ := rows[0]["members"].(string)
+	if err := json.Unmarshal([]byte(membersJson), &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// runTagImport imports the staged chunks from the first one which has not been processed. The progress is saved
+// before the chunk is deleted, and the import stops once another instance has taken it over.
+func runTagImport(job *xtagging.TagJob) {
+	stored := job.Stored
+	var jobErr error
+	for job.Processed < job.Total {
+		if !ownsTagJob(job) {
+			log.Warnf("Tag import %s has been taken over by another instance, stopping", job.ID)
+			return
+		}
+		chunkId := job.Processed
+		members, err := getTagImportChunk(job.ID, chunkId)
+		if err != nil {
+			jobErr = err
+			break
+		}
+		importTagMembers(job, members)
+		job.Processed++
+		saveTagJobProgress(job)
+		if err := ds.GetSimpleDao().Modify(QueryDeleteTagImportChunk, job.ID, strconv.Itoa(chunkId)); err != nil {
+			log.Warnf("Unable to delete chunk %d of tag import %s: %v", chunkId, job.ID, err)
+		}
+	}
+
+	now := time.Now()
+	auditEntry := &TagAuditEntry{Tag: job.Target, Created: now.UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+		Operation: AuditAddMembers, Requested: job.ResultCount, Affected: job.Stored}
+	if jobErr != nil {
+		auditEntry.Error = jobErr.Error()
+	}
+	RecordTagAudit(auditEntry)
+	if job.Stored > stored {
+		touchTagMetadata(job.Target, job.CreatedBy, now)
+	}
+	finishTagJob(job, jobErr)
+}
+
+// importTagMembers stores the members in XDAS and Cassandra, the members which failed are retried
+func importTagMembers(job *xtagging.TagJob, members []string) {
+	remaining := members
+	for attempt := 1; attempt <= TagImportMaxAttempts && len(remaining) > 0; attempt++ {
+		if attempt > 1 {
+			job.Retries++
+			time.Sleep(time.Duration(attempt-1) * TagImportRetryDelay)
+		}
+		saved, err := addMembersToXdas(job.Target, remaining, job.Value)
+		if err != nil || len(saved) == 0 {
+			continue
+		}
+		if err := AddMembersWithValue(job.Target, saved, job.Value, 0); err != nil {
+			// the members are added to XDAS again on the next attempt, which is harmless
+			log.Errorf("Cassandra failed to store %d members of tag import %s: %v", len(saved), job.ID, err)
+			continue
+		}
+		job.Stored += len(saved)
+		remaining = subtractMembers(remaining, saved)
+	}
+	for _, member := range remaining {
+		job.AddReject(0, member, fmt.Sprintf("not stored after %d attempts", TagImportMaxAttempts))
+	}
+}
+
+func subtractMembers(members []string, removed []string) []string {
+	removedSet := make(map[string]bool, len(removed))
+	for _, member := range removed {
+		removedSet[member] = true
+	}
+	result := []string{}
+	for _, member := range members {
+		if !removedSet[member] {
+			result = append(result, member)
+		}
+	}
+	return result
+}
+
+// resumeStalledTagImports takes over the imports whose instance stopped, an interrupted upload cannot be resumed.
+// It runs under the lock of the job maintenance, so a stalled import is claimed by one instance.
+func resumeStalledTagImports(now time.Time) {
+	stalledSince := now.Add(-TagImportStallTimeout).UnixMilli()
+	for _, job := range xtagging.GetTagJobList() {
+		if job.Type != TagJobImport || job.IsDone() || job.Updated > stalledSince {
+			continue
+		}
+		if job.Status == xtagging.TagJobUploading {
+			finishTagJob(job, fmt.Errorf("upload interrupted"))
+			continue
+		}
+		if err := claimTagJob(job); err != nil {
+			log.Errorf("Unable to claim tag import %s: %v", job.ID, err)
+			continue
+		}
+		log.Infof("Resuming tag import %s of tag %s from chunk %d/%d", job.ID, job.Target, job.Processed, job.Total)
+		go runTagImport(job)
+	}
+}
@@ -0,0 +1,81 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTagImportFormat(t *testing.T) {
+	format, err := GetTagImportFormat("", "text/csv; charset=utf-8")
+	assert.Nil(t, err)
+	assert.Equal(t, TagImportCsv, format)
+	format, err = GetTagImportFormat("NDJSON", "text/csv")
+	assert.Nil(t, err)
+	assert.Equal(t, TagImportNdjson, format)
+	_, err = GetTagImportFormat("", "application/json")
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
+
+func TestNormalizeImportedMember(t *testing.T) {
+	member, reason := normalizeImportedMember(" aabbccddeeff ", false)
+	assert.Equal(t, "AABBCCDDEEFF", member)
+	assert.Equal(t, "", reason)
+	member, _ = normalizeImportedMember("aabbccddeeff", true)
+	assert.Equal(t, "AABBCCDDEF01", member)
+	member, reason = normalizeImportedMember("  ", false)
+	assert.Equal(t, "", member)
+	assert.Equal(t, "", reason)
+	_, reason = normalizeImportedMember("device 1", false)
+	assert.NotEmpty(t, reason)
+	_, reason = normalizeImportedMember(strings.Repeat("a", MaxImportedMemberLength+1), false)
+	assert.NotEmpty(t, reason)
+}
+
+func TestReadImportedMembers(t *testing.T) {
+	job := &xtagging.TagJob{}
+	members := map[int]string{}
+	addMember := func(line int, member string) error {
+		members[line] = member
+		return nil
+	}
+	csvBody := "member,comment\nAABBCCDDEEFF,first\n\"bad\"quote\nAABBCCDDEE01\n"
+	assert.Nil(t, readCsvMembers(strings.NewReader(csvBody), job, addMember))
+	assert.Equal(t, map[int]string{2: "AABBCCDDEEFF", 4: "AABBCCDDEE01"}, members)
+	assert.Equal(t, 1, job.Rejected)
+	assert.Equal(t, 3, job.Rejects[0].Line)
+
+	job, members = &xtagging.TagJob{}, map[int]string{}
+	ndjsonBody := "\"AABBCCDDEEFF\"\n\n{\"member\":\"AABBCCDDEE01\"}\nnot json\n"
+	assert.Nil(t, readNdjsonMembers(strings.NewReader(ndjsonBody), job, addMember))
+	assert.Equal(t, map[int]string{1: "AABBCCDDEEFF", 3: "AABBCCDDEE01"}, members)
+	assert.Equal(t, 1, job.Rejected)
+	assert.Equal(t, 4, job.Rejects[0].Line)
+}
+
+func TestSubtractMembers(t *testing.T) {
+	assert.Equal(t, []string{"m1", "m3"}, subtractMembers([]string{"m1", "m2", "m3"}, []string{"m2", "m4"}))
+	assert.Equal(t, []string{}, subtractMembers([]string{"m1"}, []string{"m1"}))
+}
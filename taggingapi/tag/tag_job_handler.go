@@ -0,0 +1,255 @@
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+// StartTagSetOperationHandler starts a set operation over the tags, the job is returned to follow its progress
+func StartTagSetOperationHandler(w http.ResponseWriter, r *http.Request) {
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	request := TagSetRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	if err := validateTagSetRequest(&request); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	for _, tagId := range request.Tags {
+		if err := auth.CanReadTag(r, tagId); err != nil {
+			xhttp.WriteXconfErrorResponse(w, err)
+			return
+		}
+	}
+	if !request.DryRun {
+		if err := auth.CanWriteTag(r, request.Target); err != nil {
+			xhttp.WriteXconfErrorResponse(w, err)
+			return
+		}
+	}
+	job, err := StartTagSetOperation(r, &request, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusAccepted)
+}
+
+// StartTagCohortHandler stores the members of a tag or of a mac list in a percent range into the target tag
+func StartTagCohortHandler(w http.ResponseWriter, r *http.Request) {
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	request := TagCohortRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	if err := validateTagCohortRequest(&request); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	var err error
+	if request.Tag != "" {
+		err = auth.CanReadTag(r, request.Tag)
+	} else {
+		_, err = auth.CanRead(r, auth.COMMON_ENTITY)
+	}
+	if err == nil && !request.DryRun {
+		err = auth.CanWriteTag(r, request.Target)
+	}
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	job, err := StartTagCohort(r, &request, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusAccepted)
+}
+
+// ImportTagMembersHandler streams a csv or ndjson upload into the tag, the job reports the progress and the rejects
+func ImportTagMembersHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	if err := auth.CanWriteTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	queryParams := r.URL.Query()
+	format, err := GetTagImportFormat(queryParams.Get(common.TagImportFormat), r.Header.Get("Content-Type"))
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	toEstb := false
+	if value := queryParams.Get(common.TagToEstb); value != "" {
+		if toEstb, err = strconv.ParseBool(value); err != nil {
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("%s must be true or false", common.TagToEstb)))
+			return
+		}
+	}
+	if r.Body == nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(EmptyListErrorMsg, common.Member)))
+		return
+	}
+	request := &TagImportRequest{Tag: id, Format: format, Value: getTagValueFromRequest(r), ToEstb: toEstb}
+	job, err := StartTagImport(r, request, r.Body, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusAccepted)
+}
+
+// ReconcileTagHandler compares the tag in Cassandra and XDAS, with repair=xdas or repair=cassandra that side is repaired
+func ReconcileTagHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	repair, err := ValidateReconcileRepair(r.URL.Query().Get(common.TagRepair))
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	if repair == "" {
+		err = auth.CanReadTag(r, id)
+	} else {
+		err = auth.CanWriteTag(r, id)
+	}
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	job, err := StartTagReconcile(r, id, repair, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusAccepted)
+}
+
+// CopyTagHandler copies the members of the tag to the target tag
+func CopyTagHandler(w http.ResponseWriter, r *http.Request) {
+	startTagCopy(w, r, false)
+}
+
+// RenameTagHandler moves the members of the tag to a new tag, the job reports the rules which reference the tag
+func RenameTagHandler(w http.ResponseWriter, r *http.Request) {
+	startTagCopy(w, r, true)
+}
+
+func startTagCopy(w http.ResponseWriter, r *http.Request, rename bool) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	request := TagCopyRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	if err := validateTagCopyRequest(id, &request); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	var err error
+	if rename {
+		err = auth.CanDeleteTag(r, id)
+	} else {
+		err = auth.CanReadTag(r, id)
+	}
+	if err == nil {
+		err = auth.CanWriteTag(r, request.Target)
+	}
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	job, err := StartTagCopy(r, id, &request, rename, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusAccepted)
+}
+
+// GetTagJobsHandler lists the jobs of the tags the caller may read
+func GetTagJobsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	jobs := []*xtagging.TagJob{}
+	for _, job := range xtagging.GetTagJobList() {
+		if canReadTagJob(r, job) == nil {
+			jobs = append(jobs, job)
+		}
+	}
+	writeTagJsonResponse(w, jobs, http.StatusOK)
+}
+
+func GetTagJobHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.ID]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.ID)))
+		return
+	}
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	job, err := GetTagJob(id)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	if err := canReadTagJob(r, job); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, job, http.StatusOK)
+}
+
+// canReadTagJob checks the read permission of the source tags and the target tag of the job
+func canReadTagJob(r *http.Request, job *xtagging.TagJob) error {
+	for _, tagId := range job.Sources {
+		if err := auth.CanReadTag(r, tagId); err != nil {
+			return err
+		}
+	}
+	return auth.CanReadTagJob(r, job.Target)
+}
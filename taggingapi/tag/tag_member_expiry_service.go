@@ -0,0 +1,339 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// The members added with a TTL expire natively from TagMembersBucketed, XDAS only supports a TTL for
+// all tags of a member so the expiring members are indexed by the hour of their expiry. A partition holds
+// the members of one bucket of a tag, the partitions of an hour are listed in TagMemberExpiryBuckets.
+// The tables are created by db/migrations/0012_tag_member_expiry.cql.
+const (
+	// MaxMemberTtlInSeconds is the TTL of the members in XDAS
+	MaxMemberTtlInSeconds          = 31536000
+	MemberExpirySweepLookbackHours = 72
+	// MemberExpiryGracePeriod lets Cassandra expire the member before it is swept
+	MemberExpiryGracePeriod = time.Minute
+	MemberExpiryPageSize    = 1000
+	// MemberExpiryCheckSize is the number of members checked in TagMembersBucketed with one query
+	MemberExpiryCheckSize = 100
+
+	QueryAddMemberExpiry          = `INSERT INTO "TagMemberExpiry" (expires_hour, tag_id, bucket_id, member, expires_at) VALUES (?, ?, ?, ?, ?) USING TTL ?`
+	QueryAddMemberExpiryBucket    = `INSERT INTO "TagMemberExpiryBuckets" (expires_hour, tag_id, bucket_id) VALUES (?, ?, ?) USING TTL ?`
+	QueryGetMemberExpiryBuckets   = `SELECT tag_id, bucket_id FROM "TagMemberExpiryBuckets" WHERE expires_hour = ?`
+	QueryGetMemberExpiryFirst     = `SELECT member, expires_at FROM "TagMemberExpiry" WHERE expires_hour = ? AND tag_id = ? AND bucket_id = ? LIMIT ?`
+	QueryGetMemberExpiryAfter     = `SELECT member, expires_at FROM "TagMemberExpiry" WHERE expires_hour = ? AND tag_id = ? AND bucket_id = ? AND member > ? LIMIT ?`
+	QueryDeleteMemberExpiry       = `DELETE FROM "TagMemberExpiry" WHERE expires_hour = ? AND tag_id = ? AND bucket_id = ? AND member = ?`
+	QueryDeleteMemberExpiryBucket = `DELETE FROM "TagMemberExpiryBuckets" WHERE expires_hour = ? AND tag_id = ? AND bucket_id = ?`
+)
+
+// memberExpiry is a member of a TagMemberExpiry partition
+type memberExpiry struct {
+	member    string
+	expiresAt int64
+}
+
+// tagExpirySweep counts the members swept from the partitions of a tag in an hour
+type tagExpirySweep struct {
+	expired int
+	removed int
+	failed  int
+}
+
+func ValidateMemberTtl(ttlSeconds int) error {
+	if ttlSeconds < 1 || ttlSeconds > MaxMemberTtlInSeconds {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("ttl must be from 1 to %d seconds", MaxMemberTtlInSeconds))
+	}
+	return nil
+}
+
+func getMemberExpiryHour(expiresAt int64) int64 {
+	return expiresAt / time.Hour.Milliseconds()
+}
+
+// SweepExpiredTagMembers removes the expired members from XDAS and the bucket metadata of the drained buckets.
+// A member which has been added again since is still stored and is kept. The members XDAS failed to remove are
+// moved to the current hour before their hour leaves the lookback, they are retried until they are removed.
+func SweepExpiredTagMembers(now time.Time) {
+	sweptUntil := now.Add(-MemberExpiryGracePeriod).UnixMilli()
+	firstHour := getMemberExpiryHour(now.Add(-MemberExpirySweepLookbackHours * time.Hour).UnixMilli())
+	lastHour := getMemberExpiryHour(sweptUntil)
+	for hour := firstHour; hour <= lastHour; hour++ {
+		rows, err := ds.GetSimpleDao().Query(QueryGetMemberExpiryBuckets, strconv.FormatInt(hour, 10))
+		if err != nil {
+			log.Errorf("Unable to get the members expired in hour %d: %v", hour, err)
+			continue
+		}
+		sweeps := map[string]*tagExpirySweep{}
+		for _, row := range rows {
+			tagId, _ := row["tag_id"].(string)
+			bucketId, _ := row["bucket_id"].(int)
+			if tagId == "" {
+				continue
+			}
+			if sweeps[tagId] == nil {
+				sweeps[tagId] = &tagExpirySweep{}
+			}
+			requeueHour := int64(0)
+			if hour == firstHour && hour < lastHour {
+				requeueHour = lastHour
+			}
+			sweepMemberExpiryBucket(hour, tagId, bucketId, sweptUntil, requeueHour, sweeps[tagId])
+		}
+		for tagId, sweep := range sweeps {
+			reportTagExpirySweep(tagId, sweep, now)
+		}
+	}
+}
+
+// sweepMemberExpiryBucket sweeps a partition page by page, the members which failed are moved to the requeue hour
+// when it is set. The partition is unlisted once all its members are swept.
+func sweepMemberExpiryBucket(hour int64, tagId string, bucketId int, sweptUntil int64, requeueHour int64, sweep *tagExpirySweep) {
+	drained := true
+	lastMember := ""
+	for {
+		page, err := getMemberExpiryPage(hour, tagId, bucketId, lastMember)
+		if err != nil {
+			log.Errorf("Unable to get the members of bucket %d of tag %s expired in hour %d: %v", bucketId, tagId, hour, err)
+			return
+		}
+		if len(page) == 0 {
+			break
+		}
+		lastMember = page[len(page)-1].member
+
+		expiries := []*memberExpiry{}
+		for _, expiry := range page {
+			if expiry.expiresAt > sweptUntil {
+				drained = false
+			} else {
+				expiries = append(expiries, expiry)
+			}
+		}
+		failed, err := sweepMemberExpiries(hour, tagId, bucketId, expiries, sweep)
+		if err != nil {
+			log.Errorf("Unable to check %d expired members of tag %s: %v", len(expiries), tagId, err)
+			drained = false
+		} else if len(failed) > 0 {
+			if requeueHour == 0 || requeueMemberExpiries(hour, requeueHour, tagId, bucketId, failed) != nil {
+				drained = false
+			}
+		}
+		if len(page) < MemberExpiryPageSize {
+			break
+		}
+	}
+	if drained {
+		err := ds.GetSimpleDao().Modify(QueryDeleteMemberExpiryBucket, strconv.FormatInt(hour, 10), tagId, strconv.Itoa(bucketId))
+		if err != nil {
+			log.Errorf("Unable to unlist bucket %d of tag %s expired in hour %d: %v", bucketId, tagId, hour, err)
+		}
+	}
+}
+
+func getMemberExpiryPage(hour int64, tagId string, bucketId int, lastMember string) ([]*memberExpiry, error) {
+	var rows []map[string]interface{}
+	var err error
+	if lastMember == "" {
+		rows, err = ds.GetSimpleDao().Query(QueryGetMemberExpiryFirst, strconv.FormatInt(hour, 10), tagId, strconv.Itoa(bucketId),
+			strconv.Itoa(MemberExpiryPageSize))
+	} else {
+		rows, err = ds.GetSimpleDao().Query(QueryGetMemberExpiryAfter, strconv.FormatInt(hour, 10), tagId, strconv.Itoa(bucketId),
+			lastMember, strconv.Itoa(MemberExpiryPageSize))
+	}
+	if err != nil {
+		return nil, err
+	}
+	page := make([]*memberExpiry, 0, len(rows))
+	for _, row := range rows {
+		member, _ := row["member"].(string)
+		expiresAt, _ := row["expires_at"].(int64)
+		if member != "" {
+			page = append(page, &memberExpiry{member: member, expiresAt: expiresAt})
+		}
+	}
+	return page, nil
+}
+
+// sweepMemberExpiries removes the expired members from XDAS and deletes the expiries of the swept ones,
+// it returns the expiries of the members which could not be removed from XDAS
+func sweepMemberExpiries(hour int64, tagId string, bucketId int, expiries []*memberExpiry, sweep *tagExpirySweep) ([]*memberExpiry, error) {
+	if len(expiries) == 0 {
+		return nil, nil
+	}
+	members := make([]string, 0, len(expiries))
+	for _, expiry := range expiries {
+		members = append(members, expiry.member)
+	}
+	stored, err := getStoredBucketMembersFunc(tagId, bucketId, members)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired, swept []string
+	for _, member := range members {
+		if _, found := stored[member]; found {
+			swept = append(swept, member)
+		} else {
+			expired = append(expired, member)
+		}
+	}
+	removed := map[string]bool{}
+	if len(expired) > 0 {
+		removedFromXdas, err := removeMembersFromXdasFunc(tagId, expired)
+		if err == nil && len(removedFromXdas) > 0 {
+			// the expired rows are gone already, this drops the metadata of the drained bucket
+			err = unlistBucketIfEmptyFunc(tagId, bucketId)
+		}
+		if err != nil {
+			log.Errorf("Unable to sweep %d expired members of tag %s: %v", len(expired), tagId, err)
+		} else {
+			// an expired member is counted out once, when its expiry is swept
+			if err := addBucketCountFunc(tagId, bucketId, -len(removedFromXdas)); err != nil {
+				log.Warnf("Failed to update bucket %d count for tag %s: %v", bucketId, tagId, err)
+			}
+			for _, member := range removedFromXdas {
+				removed[member] = true
+			}
+			swept = append(swept, removedFromXdas...)
+		}
+	}
+	sweep.expired += len(expired)
+	sweep.removed += len(removed)
+
+	failed := []*memberExpiry{}
+	for _, expiry := range expiries {
+		if _, found := stored[expiry.member]; !found && !removed[expiry.member] {
+			failed = append(failed, expiry)
+		}
+	}
+	sweep.failed += len(failed)
+	if err := deleteMemberExpiriesFunc(hour, tagId, bucketId, swept); err != nil {
+		log.Errorf("Unable to delete the swept expiries of tag %s: %v", tagId, err)
+	}
+	return failed, nil
+}
+
+func isTagMemberStored(tagId string, member string) (bool, error) {
+	return getBucketMemberFunc(tagId, getBucketId(member), member)
+}
+
+// getBucketMemberFunc looks the member up in a bucket of the tag, the tests replace it
+var getBucketMemberFunc = getBucketMember
+
+func getBucketMember(tagId string, bucketId int, member string) (bool, error) {
+	rows, err := ds.GetSimpleDao().Query(QueryGetMemberBucketed, tagId, strconv.Itoa(bucketId), member)
+	if err != nil {
+		return false, err
+	}
+	return len(rows) > 0, nil
+}
+
+// getStoredBucketMembers returns the remaining TTL in seconds of the members still stored in the bucket, 0 for
+// the members without a TTL. The members are checked by chunks.
+func getStoredBucketMembers(tagId string, bucketId int, members []string) (map[string]int, error) {
+	stored := map[string]int{}
+	for start := 0; start < len(members); start += MemberExpiryCheckSize {
+		chunk := members[start:min(start+MemberExpiryCheckSize, len(members))]
+		args := append([]string{tagId, strconv.Itoa(bucketId)}, chunk...)
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(chunk)), ", ")
+		rows, err := ds.GetSimpleDao().Query(fmt.Sprintf(QueryGetStoredBucketMember, placeholders), args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			if member, ok := row["member"].(string); ok {
+				stored[member] = toCount(row["ttl"])
+			}
+		}
+	}
+	return stored, nil
+}
+
+// getMemberExpiryHours returns the hours the expiry of a member with the remaining TTL may have been indexed in,
+// the TTL is truncated to the second
+func getMemberExpiryHours(now time.Time, ttlSeconds int) []int64 {
+	expiresAt := now.Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+	hours := []int64{getMemberExpiryHour(expiresAt)}
+	if nextHour := getMemberExpiryHour(expiresAt + time.Second.Milliseconds()); nextHour != hours[0] {
+		hours = append(hours, nextHour)
+	}
+	return hours
+}
+
+// requeueMemberExpiries moves the expiries to a later hour so that they are swept again
+func requeueMemberExpiries(hour int64, requeueHour int64, tagId string, bucketId int, expiries []*memberExpiry) error {
+	batch := ds.GetSimpleDao().NewBatch(UnloggedBatch)
+	members := make([]string, 0, len(expiries))
+	for _, expiry := range expiries {
+		// the requeued expiry is kept for the lookback of the requeue hour
+		ttl := strconv.Itoa(MemberExpirySweepLookbackHours * 3600)
+		batch.Query(QueryAddMemberExpiry, strconv.FormatInt(requeueHour, 10), tagId, strconv.Itoa(bucketId), expiry.member,
+			strconv.FormatInt(expiry.expiresAt, 10), ttl)
+		members = append(members, expiry.member)
+	}
+	batch.Query(QueryAddMemberExpiryBucket, strconv.FormatInt(requeueHour, 10), tagId, strconv.Itoa(bucketId),
+		strconv.Itoa(MemberExpirySweepLookbackHours*3600))
+	if err := ds.GetSimpleDao().ExecuteBatch(batch); err != nil {
+		log.Errorf("Unable to requeue %d expired members of tag %s: %v", len(expiries), tagId, err)
+		return err
+	}
+	log.Warnf("Requeued %d expired members of tag %s which XDAS failed to remove since hour %d", len(expiries), tagId, hour)
+	return deleteMemberExpiries(hour, tagId, bucketId, members)
+}
+
+// reportTagExpirySweep audits the members removed from the tag and the ones which could not be removed
+func reportTagExpirySweep(tagId string, sweep *tagExpirySweep, now time.Time) {
+	if sweep.removed == 0 && sweep.failed == 0 {
+		return
+	}
+	auditEntry := &TagAuditEntry{Tag: tagId, Created: now.UnixMilli(), UserName: tagRetentionUser,
+		Operation: AuditRemoveMembers, Requested: sweep.expired, Affected: sweep.removed}
+	if sweep.failed > 0 {
+		auditEntry.Error = fmt.Sprintf("%d expired members could not be removed from XDAS", sweep.failed)
+		log.Errorf("Unable to sweep %d expired members of tag %s", sweep.failed, tagId)
+	}
+	if sweep.removed > 0 {
+		log.Infof("Swept %d expired members of tag %s", sweep.removed, tagId)
+		touchTagMetadata(tagId, tagRetentionUser, now)
+	}
+	RecordTagAudit(auditEntry)
+}
+
+func deleteMemberExpiries(hour int64, tagId string, bucketId int, members []string) error {
+	if len(members) == 0 {
+		return nil
+	}
+	batch := ds.GetSimpleDao().NewBatch(UnloggedBatch)
+	for _, member := range members {
+		batch.Query(QueryDeleteMemberExpiry, strconv.FormatInt(hour, 10), tagId, strconv.Itoa(bucketId), member)
+	}
+	return ds.GetSimpleDao().ExecuteBatch(batch)
+}
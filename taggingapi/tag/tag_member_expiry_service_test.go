@@ -0,0 +1,49 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMemberTtl(t *testing.T) {
+	assert.Nil(t, ValidateMemberTtl(1))
+	assert.Nil(t, ValidateMemberTtl(14*24*3600))
+	assert.Nil(t, ValidateMemberTtl(MaxMemberTtlInSeconds))
+
+	for _, ttl := range []int{-1, 0, MaxMemberTtlInSeconds + 1} {
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(ValidateMemberTtl(ttl)), ttl)
+	}
+}
+
+func TestGetMemberExpiryHour(t *testing.T) {
+	expiresAt := time.Date(2026, 3, 1, 10, 59, 59, 0, time.UTC).UnixMilli()
+	hour := getMemberExpiryHour(expiresAt)
+	assert.Equal(t, hour, getMemberExpiryHour(time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC).UnixMilli()))
+	assert.Equal(t, hour+1, getMemberExpiryHour(time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC).UnixMilli()))
+
+	err := AddMembersWithValue("test-tag", []string{}, "", 3600)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "member list is empty")
+}
@@ -6,7 +6,9 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
 	"github.com/rdkcentral/xconfadmin/common"
 	xhttp "github.com/rdkcentral/xconfadmin/http"
 
@@ -56,6 +58,10 @@ func GetTagMembersHandler(w http.ResponseWriter, r *http.Request) {
 		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
 		return
 	}
+	if err := auth.CanReadTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
 
 	query := r.URL.Query()
 	isPaginatedRequest := query.Has("limit") || query.Has("cursor")
@@ -111,6 +117,11 @@ func AddMembersToTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := auth.CanWriteTag(r, tagId); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
 	tagValue := getTagValueFromRequest(r)
 
 	xw, ok := w.(*xwhttp.XResponseWriter)
@@ -136,16 +147,35 @@ func AddMembersToTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	stored, err := AddMembersWithXdas(tagId, members, tagValue)
+	ttlSeconds := 0
+	if ttlStr := r.URL.Query().Get(common.TagTtl); ttlStr != "" {
+		parsedTtl, err := strconv.Atoi(ttlStr)
+		if err != nil {
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("%s must be a number of seconds", common.TagTtl)))
+			return
+		}
+		if err := ValidateMemberTtl(parsedTtl); err != nil {
+			xhttp.WriteXconfErrorResponse(w, err)
+			return
+		}
+		ttlSeconds = parsedTtl
+	}
+
+	stored, err := AddMembersWithXdasAndTtl(tagId, members, tagValue, ttlSeconds)
+	RecordTagAudit(NewTagAuditEntry(w, r, tagId, AuditAddMembers, members, stored, err))
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
 		return
 	}
+	TouchTagMetadata(r, tagId, time.Now())
 
 	response := map[string]int{
 		"requested": len(members),
 		"stored":    stored,
 	}
+	if ttlSeconds > 0 {
+		response[common.TagTtl] = ttlSeconds
+	}
 	respBytes, err := json.Marshal(response)
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
@@ -172,6 +202,10 @@ func RemoveMembersFromTagHandler(w http.ResponseWriter, r *http.Request) {
 		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
 		return
 	}
+	if err := auth.CanWriteTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
 
 	var members []string
 	body, err := io.ReadAll(r.Body)
@@ -197,10 +231,12 @@ func RemoveMembersFromTagHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	removed, err := RemoveMembersWithXdas(id, members)
+	RecordTagAudit(NewTagAuditEntry(w, r, id, AuditRemoveMembers, members, removed, err))
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
 		return
 	}
+	TouchTagMetadata(r, id, time.Now())
 
 	response := map[string]int{
 		"requested": len(members),
@@ -229,17 +265,35 @@ func RemoveMemberFromTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err := RemoveMemberWithXdas(id, member)
+	if err := auth.CanWriteTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
+	removed, err := RemoveMembersWithXdas(id, []string{member})
+	RecordTagAudit(NewTagAuditEntry(w, r, id, AuditRemoveMembers, []string{member}, removed, err))
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
 		return
 	}
+	TouchTagMetadata(r, id, time.Now())
 
 	xhttp.WriteXconfResponse(w, http.StatusNoContent, nil)
 }
 
 // GetAllTagsHandler returns all tag IDs from V2 storage
 func GetAllTagsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
+	queryParams := r.URL.Query()
+	if queryParams.Has(common.TagMetadata) || queryParams.Has(common.TagOwner) || queryParams.Has(common.TagPrefix) {
+		getAllTagMetadata(w, r)
+		return
+	}
+
 	tagIds, err := GetAllTagIds()
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
@@ -263,6 +317,11 @@ func GetTagByIdHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := auth.CanReadTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
 	members, wasTruncated, err := GetTagById(id)
 	if err != nil {
 		// Check if tag not found
@@ -312,6 +371,11 @@ func DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err := auth.CanDeleteTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+
 	populatedBuckets, err := getPopulatedBuckets(id)
 	if err != nil {
 		xhttp.WriteXconfErrorResponse(w, err)
@@ -324,8 +388,14 @@ func DeleteTagHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	auditId := xw.AuditId()
+	auditEntry := NewTagAuditEntry(w, r, id, AuditDeleteTag, nil, 0, nil)
 	go func(tagId string) {
-		if err := DeleteTag(tagId); err != nil {
+		err := DeleteTag(tagId)
+		if err != nil {
+			auditEntry.Error = err.Error()
+		}
+		RecordTagAudit(auditEntry)
+		if err != nil {
 			log.WithFields(log.Fields{
 				"audit_id": auditId,
 				"endpoint": "DeleteTag",
@@ -11,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
 	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
 	ds "github.com/rdkcentral/xconfwebconfig/db"
 	"github.com/rdkcentral/xconfwebconfig/util"
@@ -33,11 +35,19 @@ const (
 	MaxMembersInTagResponse = 100000 // Max members returned in GetTagById
 	MemberFetchChunkSize    = 1000   // Chunk size for memory-safe pagination
 
-	QueryAddMemberBucketed       = `INSERT INTO "TagMembersBucketed" (tag_id, bucket_id, member, created) VALUES (?, ?, ?, ?)`
-	QueryRemoveMemberBucketed    = `DELETE FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member = ?`
-	QueryGetMembersByBucket      = `SELECT member FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member > ? LIMIT ?`
-	QueryGetMembersCountByBucket = `SELECT count(*) FROM "TagMembersBucketed" WHERE tag_id = ? and bucket_id = ?`
-	QueryGetMembersByBucketFirst = `SELECT member FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? LIMIT ?`
+	QueryAddMemberBucketed    = `INSERT INTO "TagMembersBucketed" (tag_id, bucket_id, member, created) VALUES (?, ?, ?, ?)`
+	QueryAddMemberBucketedTtl = `INSERT INTO "TagMembersBucketed" (tag_id, bucket_id, member, created) VALUES (?, ?, ?, ?) USING TTL ?`
+	// the value of a member in XDAS is kept with it so that the member is copied and repaired with its value,
+	// the value column is added by db/migrations/0015_tag_members_value.cql and used once it is enabled
+	QueryAddMemberValueBucketed    = `INSERT INTO "TagMembersBucketed" (tag_id, bucket_id, member, created, value) VALUES (?, ?, ?, ?, ?)`
+	QueryAddMemberValueBucketedTtl = `INSERT INTO "TagMembersBucketed" (tag_id, bucket_id, member, created, value) VALUES (?, ?, ?, ?, ?) USING TTL ?`
+	QueryGetMemberBucketed         = `SELECT member FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member = ?`
+	QueryRemoveMemberBucketed      = `DELETE FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member = ?`
+	QueryGetMembersByBucket        = `SELECT member FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member > ? LIMIT ?`
+	QueryGetMembersCountByBucket   = `SELECT count(*) FROM "TagMembersBucketed" WHERE tag_id = ? and bucket_id = ?`
+	QueryGetMembersByBucketFirst   = `SELECT member FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? LIMIT ?`
+	QueryGetValuesByBucket         = `SELECT member, value FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member > ? LIMIT ?`
+	QueryGetValuesByBucketFirst    = `SELECT member, value FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? LIMIT ?`
 
 	QueryGetPopulatedBuckets  = `SELECT bucket_id FROM "TagBucketMetadata" WHERE tag_id = ?`
 	QueryAddBucketMetadata    = `INSERT INTO "TagBucketMetadata" (tag_id, bucket_id) VALUES (?, ?)`
@@ -79,6 +89,12 @@ func getBucketId(member string) int {
 }
 
 func AddMembers(tagId string, members []string) error {
+	return AddMembersWithValue(tagId, members, "", 0)
+}
+
+// AddMembersWithValue adds members with their value in XDAS which expire after ttlSeconds, 0 keeps them until
+// they are removed
+func AddMembersWithValue(tagId string, members []string, tagValue string, ttlSeconds int) error {
 	if len(members) > MaxBatchSizeV2 {
 		return fmt.Errorf("batch size %d exceeds maximum %d", len(members), MaxBatchSizeV2)
 	}
@@ -99,7 +115,7 @@ func AddMembers(tagId string, members []string) error {
 	successCount := 0
 
 	for bucketId, bucketMembers := range bucketGroups {
-		if err := addMembersToBucket(tagId, bucketId, bucketMembers, created); err != nil {
+		if err := addMembersToBucket(tagId, bucketId, bucketMembers, created, tagValue, ttlSeconds); err != nil {
 			allErrors = append(allErrors, fmt.Sprintf("bucket %d: %v", bucketId, err))
 			log.Errorf("Failed to add %d members to bucket %d for tag %s: %v",
 				len(bucketMembers), bucketId, tagId, err)
@@ -118,12 +134,70 @@ func AddMembers(tagId string, members []string) error {
 	return nil
 }
 
-func addMembersToBucket(tagId string, bucketId int, members []string, created string) error {
+// the reads and writes of the bucket rows which change the bucket counts, the tests replace them
+var (
+	getStoredBucketMembersFunc = getStoredBucketMembers
+	storeBucketMembersFunc     = storeBucketMembers
+	deleteBucketMemberRowsFunc = deleteBucketMemberRows
+	unlistBucketIfEmptyFunc    = unlistBucketIfEmpty
+	addBucketCountFunc         = addBucketCount
+	removeMembersFromXdasFunc  = removeMembersFromXDAS
+	deleteMemberExpiriesFunc   = deleteMemberExpiries
+)
+
+// addMembersToBucket stores the members and counts the ones which were not stored yet in the bucket
+func addMembersToBucket(tagId string, bucketId int, members []string, created string, tagValue string, ttlSeconds int) error {
+	stored, lookupErr := getStoredBucketMembersFunc(tagId, bucketId, members)
+	if lookupErr != nil {
+		log.Warnf("Unable to look up the stored members of bucket %d of tag %s, its count is repaired by the maintenance: %v", bucketId, tagId, lookupErr)
+	}
+	if err := storeBucketMembersFunc(tagId, bucketId, members, created, tagValue, ttlSeconds); err != nil {
+		return err
+	}
+	if lookupErr == nil {
+		if err := addBucketCountFunc(tagId, bucketId, countNewMembers(members, stored)); err != nil {
+			log.Warnf("Failed to update bucket %d count for tag %s: %v", bucketId, tagId, err)
+		}
+	}
+	return nil
+}
+
+// countNewMembers returns the number of distinct members which are not stored
+func countNewMembers(members []string, stored map[string]int) int {
+	added := map[string]bool{}
+	for _, member := range members {
+		if _, found := stored[member]; !found {
+			added[member] = true
+		}
+	}
+	return len(added)
+}
+
+func storeBucketMembers(tagId string, bucketId int, members []string, created string, tagValue string, ttlSeconds int) error {
 	batch := ds.GetSimpleDao().NewBatch(UnloggedBatch)
 
-	// Add member records
+	// Add member records, expiring members are also indexed by the hour of their expiry for the XDAS sweeper
+	expiresAt := time.Now().Add(time.Duration(ttlSeconds) * time.Second).UnixMilli()
+	expiryHour := strconv.FormatInt(getMemberExpiryHour(expiresAt), 10)
+	expiryTtl := strconv.Itoa(ttlSeconds + MemberExpirySweepLookbackHours*3600)
+	valueEnabled := isMemberValueEnabled()
 	for _, member := range members {
-		batch.Query(QueryAddMemberBucketed, tagId, strconv.Itoa(bucketId), member, created)
+		switch {
+		case ttlSeconds > 0 && valueEnabled:
+			batch.Query(QueryAddMemberValueBucketedTtl, tagId, strconv.Itoa(bucketId), member, created, tagValue, strconv.Itoa(ttlSeconds))
+		case ttlSeconds > 0:
+			batch.Query(QueryAddMemberBucketedTtl, tagId, strconv.Itoa(bucketId), member, created, strconv.Itoa(ttlSeconds))
+		case valueEnabled:
+			batch.Query(QueryAddMemberValueBucketed, tagId, strconv.Itoa(bucketId), member, created, tagValue)
+		default:
+			batch.Query(QueryAddMemberBucketed, tagId, strconv.Itoa(bucketId), member, created)
+		}
+		if ttlSeconds > 0 {
+			batch.Query(QueryAddMemberExpiry, expiryHour, tagId, strconv.Itoa(bucketId), member, strconv.FormatInt(expiresAt, 10), expiryTtl)
+		}
+	}
+	if ttlSeconds > 0 {
+		batch.Query(QueryAddMemberExpiryBucket, expiryHour, tagId, strconv.Itoa(bucketId), expiryTtl)
 	}
 
 	// Add metadata record for this bucket (will be ignored if already exists)
@@ -162,18 +236,8 @@ func RemoveMembers(tagId string, members []string) error {
 				len(bucketMembers), bucketId, tagId)
 		}
 		// Clean up bucket metadata if bucket is now empty
-		membersCount, err := getMembersCountOfBucket(tagId, bucketId)
-		if err != nil {
-			log.Warnf("Failed to check bucket %d count for tag %s: %v (skipping cleanup)", bucketId, tagId, err)
-			continue
-		}
-		if membersCount == 0 {
-			err = ds.GetSimpleDao().Modify(QueryDeleteBucketMetadata, tagId, strconv.Itoa(bucketId))
-			if err != nil {
-				log.Warnf("Failed to delete empty bucket %d metadata for tag %s: %v", bucketId, tagId, err)
-			} else {
-				log.Infof("Deleted empty bucket %d metadata for tag %s", bucketId, tagId)
-			}
+		if err := unlistBucketIfEmptyFunc(tagId, bucketId); err != nil {
+			log.Warnf("Failed to delete empty bucket %d metadata for tag %s: %v", bucketId, tagId, err)
 		}
 	}
 
@@ -206,16 +270,52 @@ func getMembersCountOfBucket(tagId string, bucketId int) (int, error) {
 	return int(count), nil
 }
 
+// removeMembersFromBucket deletes the members, the count of the bucket is decreased by the ones which were stored
 func removeMembersFromBucket(tagId string, bucketId int, members []string) error {
-	batch := ds.GetSimpleDao().NewBatch(UnloggedBatch)
+	stored, lookupErr := getStoredBucketMembersFunc(tagId, bucketId, members)
+	if lookupErr != nil {
+		log.Warnf("Unable to look up the stored members of bucket %d of tag %s, its count is repaired by the maintenance: %v", bucketId, tagId, lookupErr)
+	}
+	if err := deleteBucketMemberRowsFunc(tagId, bucketId, members, stored); err != nil {
+		return err
+	}
+	if lookupErr == nil {
+		if err := addBucketCountFunc(tagId, bucketId, -len(stored)); err != nil {
+			log.Warnf("Failed to update bucket %d count for tag %s: %v", bucketId, tagId, err)
+		}
+	}
+	return nil
+}
 
+// deleteBucketMemberRows deletes the members from the bucket. The expiry of a stored member with a TTL is deleted
+// with it, so that the sweep does not count the member out again.
+func deleteBucketMemberRows(tagId string, bucketId int, members []string, stored map[string]int) error {
+	batch := ds.GetSimpleDao().NewBatch(UnloggedBatch)
+	now := time.Now()
 	for _, member := range members {
 		batch.Query(QueryRemoveMemberBucketed, tagId, strconv.Itoa(bucketId), member)
+		if ttlSeconds := stored[member]; ttlSeconds > 0 {
+			for _, hour := range getMemberExpiryHours(now, ttlSeconds) {
+				batch.Query(QueryDeleteMemberExpiry, strconv.FormatInt(hour, 10), tagId, strconv.Itoa(bucketId), member)
+			}
+		}
 	}
-
 	return ds.GetSimpleDao().ExecuteBatch(batch)
 }
 
+// unlistBucketIfEmpty deletes the metadata of the bucket once its last member is gone
+func unlistBucketIfEmpty(tagId string, bucketId int) error {
+	rows, err := ds.GetSimpleDao().Query(QueryGetMembersByBucketFirst, tagId, strconv.Itoa(bucketId), "1")
+	if err != nil || len(rows) > 0 {
+		return err
+	}
+	if err := ds.GetSimpleDao().Modify(QueryDeleteBucketMetadata, tagId, strconv.Itoa(bucketId)); err != nil {
+		return err
+	}
+	log.Infof("Deleted empty bucket %d metadata for tag %s", bucketId, tagId)
+	return nil
+}
+
 func getPopulatedBuckets(tagId string) ([]int, error) {
 	rows, err := ds.GetSimpleDao().Query(QueryGetPopulatedBuckets, tagId)
 	if err != nil {
@@ -357,6 +457,40 @@ func getMembersFromBucket(tagId string, bucketId int, lastMember string, limit i
 	return members, nil
 }
 
+// isMemberValueEnabled returns true once the value column of TagMembersBucketed is known to exist
+func isMemberValueEnabled() bool {
+	config := GetTagApiConfig()
+	return config != nil && config.MemberValueEnabled
+}
+
+// getMemberValuesFromBucket returns a page of the members of the bucket with their values,
+// the values are empty until the values of the members are enabled
+func getMemberValuesFromBucket(tagId string, bucketId int, lastMember string, limit int) ([]string, map[string]string, error) {
+	firstQuery, nextQuery := QueryGetMembersByBucketFirst, QueryGetMembersByBucket
+	if isMemberValueEnabled() {
+		firstQuery, nextQuery = QueryGetValuesByBucketFirst, QueryGetValuesByBucket
+	}
+	var rows []map[string]interface{}
+	var err error
+	if lastMember == "" {
+		rows, err = ds.GetSimpleDao().Query(firstQuery, tagId, strconv.Itoa(bucketId), strconv.Itoa(limit))
+	} else {
+		rows, err = ds.GetSimpleDao().Query(nextQuery, tagId, strconv.Itoa(bucketId), lastMember, strconv.Itoa(limit))
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	members := make([]string, 0, len(rows))
+	values := make(map[string]string, len(rows))
+	for _, row := range rows {
+		if member, ok := row["member"].(string); ok {
+			members = append(members, member)
+			values[member], _ = row["value"].(string)
+		}
+	}
+	return members, values, nil
+}
+
 // Cursor management functions
 func generateBucketedCursor(bucketId int, lastMember string, totalCollected int) string {
 	cursor := BucketedCursor{
@@ -554,6 +688,12 @@ func fetchMembersFromBucketsConcurrent(tagId string, bucketIds []int, totalLimit
 // AddMembersWithXdas adds members to both XDAS and Cassandra (XDAS-first approach)
 // Returns the count of members actually stored to Cassandra.
 func AddMembersWithXdas(tagId string, members []string, tagValue string) (int, error) {
+	return AddMembersWithXdasAndTtl(tagId, members, tagValue, 0)
+}
+
+// AddMembersWithXdasAndTtl adds members which expire after ttlSeconds, 0 keeps them until they are removed.
+// Cassandra expires the members natively, they are removed from XDAS by the expiry sweeper.
+func AddMembersWithXdasAndTtl(tagId string, members []string, tagValue string, ttlSeconds int) (int, error) {
 	startTime := time.Now()
 
 	if len(members) == 0 {
@@ -573,7 +713,7 @@ func AddMembersWithXdas(tagId string, members []string, tagValue string) (int, e
 	cassandraStored := 0
 
 	if xdasAccepted > 0 {
-		if err := AddMembers(tagId, savedToXdasMembers); err != nil {
+		if err := AddMembersWithValue(tagId, savedToXdasMembers, tagValue, ttlSeconds); err != nil {
 			duration := time.Since(startTime)
 			log.Errorf("Critical: XDAS succeeded but Cassandra V2 failed for tag %s: %v", tagId, err)
 			log.Infof("AddMembers summary for tag '%s': requested=%d, xdasAccepted=%d, cassandraStored=%d, duration=%v", tagId, len(members), xdasAccepted, cassandraStored, duration)
@@ -804,6 +944,13 @@ func DeleteTag(tagId string) error {
 
 	log.Infof("Successfully deleted tag '%s': %d members removed from %d buckets",
 		tagId, totalMembersDeleted, len(deletedBuckets))
+	// the members have been counted out as they were removed, the counts which drifted are reset
+	if err := resetTagBucketCounts(tagId); err != nil {
+		log.Errorf("Failed to reset the bucket counts of tag '%s': %v", tagId, err)
+	}
+	if err := xtagging.DeleteOneTagMetadata(tagId); err != nil {
+		log.Errorf("Failed to delete the metadata of tag '%s': %v", tagId, err)
+	}
 	return nil
 }
 
@@ -0,0 +1,159 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+)
+
+const (
+	MaxMembershipCheckMembers = 50000
+	MaxMembershipCheckTags    = 20
+	// MaxMembershipChecks bounds the checks of one request, a check per member and tag queries each lookup form
+	// of the member until it is found
+	MaxMembershipChecks = 200000
+)
+
+// TagMembershipRequest asks which of the tags each member belongs to
+type TagMembershipRequest struct {
+	Members []string `json:"members"`
+	Tags    []string `json:"tags"`
+}
+
+func validateTagMembershipRequest(request *TagMembershipRequest) error {
+	if len(request.Members) == 0 || len(request.Members) > MaxMembershipCheckMembers {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("from 1 to %d members are required", MaxMembershipCheckMembers))
+	}
+	if len(request.Tags) == 0 || len(request.Tags) > MaxMembershipCheckTags {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("from 1 to %d tags are required", MaxMembershipCheckTags))
+	}
+	if len(request.Members)*len(request.Tags) > MaxMembershipChecks {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("members times tags must not exceed %d", MaxMembershipChecks))
+	}
+	for _, tagId := range request.Tags {
+		if util.IsBlank(tagId) {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "tag must not be blank")
+		}
+	}
+	for _, member := range request.Members {
+		if util.IsBlank(member) {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "member must not be blank")
+		}
+	}
+	return nil
+}
+
+type tagMembershipCheck struct {
+	member   string
+	tagIndex int
+}
+
+// getMemberLookupForms returns the forms a member may be stored in: as it was added through the API,
+// normalized as the imports and cohorts store it, and as the estb mac when it is a mac address
+func getMemberLookupForms(member string) []string {
+	forms := []string{member}
+	for _, form := range []string{ToNormalized(member), ToEstbIfMac(ToNormalized(member))} {
+		if !util.Contains(forms, form) {
+			forms = append(forms, form)
+		}
+	}
+	return forms
+}
+
+// isTagMemberStoredInAnyForm returns true when the member is stored in one of its lookup forms
+func isTagMemberStoredInAnyForm(tagId string, member string) (bool, error) {
+	for _, form := range getMemberLookupForms(member) {
+		stored, err := isTagMemberStored(tagId, form)
+		if err != nil || stored {
+			return stored, err
+		}
+	}
+	return false, nil
+}
+
+// CheckTagMembership returns the tags of each member in the order of the requested tags, keyed by the
+// member as requested. The members are looked up in their bucket of TagMembersBucketed by the read workers,
+// the first failed lookup stops the remaining ones.
+func CheckTagMembership(members []string, tagIds []string) (map[string][]string, error) {
+	done := make(chan struct{})
+	checkChan := make(chan tagMembershipCheck, getReadWorkerCount())
+	go func() {
+		defer close(checkChan)
+		for _, member := range members {
+			for i := range tagIds {
+				select {
+				case checkChan <- tagMembershipCheck{member: member, tagIndex: i}:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+
+	found := make(map[string][]bool, len(members))
+	for _, member := range members {
+		found[member] = make([]bool, len(tagIds))
+	}
+	var mutex sync.Mutex
+	var firstErr error
+	var wg sync.WaitGroup
+	for w := 0; w < getReadWorkerCount(); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for check := range checkChan {
+				select {
+				case <-done:
+					continue
+				default:
+				}
+				stored, err := isTagMemberStoredInAnyForm(tagIds[check.tagIndex], check.member)
+				mutex.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("failed to check member %s of tag %s: %w", check.member, tagIds[check.tagIndex], err)
+					close(done)
+				}
+				found[check.member][check.tagIndex] = stored
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	result := make(map[string][]string, len(found))
+	for member, flags := range found {
+		tags := []string{}
+		for i, stored := range flags {
+			if stored {
+				tags = append(tags, tagIds[i])
+			}
+		}
+		result[member] = tags
+	}
+	return result, nil
+}
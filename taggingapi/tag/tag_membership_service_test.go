@@ -0,0 +1,119 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTagMembershipRequest(t *testing.T) {
+	assert.Nil(t, validateTagMembershipRequest(&TagMembershipRequest{Members: []string{"AABBCCDDEEFF"}, Tags: []string{"t1", "t2"}}))
+
+	manyMembers := make([]string, MaxMembershipChecks/MaxMembershipCheckTags+1)
+	for i := range manyMembers {
+		manyMembers[i] = fmt.Sprintf("member-%d", i)
+	}
+	manyTags := make([]string, MaxMembershipCheckTags)
+	for i := range manyTags {
+		manyTags[i] = fmt.Sprintf("tag-%d", i)
+	}
+	invalid := []*TagMembershipRequest{
+		{Tags: []string{"t1"}},
+		{Members: []string{"AABBCCDDEEFF"}},
+		{Members: []string{"AABBCCDDEEFF"}, Tags: append(manyTags, "one-more")},
+		{Members: manyMembers, Tags: manyTags},
+		{Members: []string{"AABBCCDDEEFF"}, Tags: []string{" "}},
+		{Members: []string{""}, Tags: []string{"t1"}},
+	}
+	for i, request := range invalid {
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagMembershipRequest(request)), i)
+	}
+}
+
+// withBucketMembers replaces the bucket lookups by the members of the tags, the looked up buckets are recorded
+func withBucketMembers(t *testing.T, tagMembers map[string][]string, failingTag string) *[]string {
+	lookups := []string{}
+	var mutex sync.Mutex
+	original := getBucketMemberFunc
+	getBucketMemberFunc = func(tagId string, bucketId int, member string) (bool, error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		lookups = append(lookups, fmt.Sprintf("%s/%d/%s", tagId, bucketId, member))
+		if tagId == failingTag {
+			return false, errors.New("query failed")
+		}
+		for _, stored := range tagMembers[tagId] {
+			if stored == member && getBucketId(stored) == bucketId {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	t.Cleanup(func() { getBucketMemberFunc = original })
+	return &lookups
+}
+
+func TestCheckTagMembership(t *testing.T) {
+	// t1 holds members added through the API, t2 the estb macs of an import, t3 a mac added as it is
+	lookups := withBucketMembers(t, map[string][]string{
+		"t1": {"AABBCCDDEEFF", "raw-member"},
+		"t2": {"AABBCCDDEF01"},
+		"t3": {"AA:BB:CC:DD:EE:FF"},
+	}, "")
+
+	members := []string{" aa:bb:cc:dd:ee:ff ", "AABBCCDDEEFF", "aabbccddeeff", "raw-member", "unknown"}
+	membership, err := CheckTagMembership(members, []string{"t3", "t2", "t1"})
+	assert.Nil(t, err)
+	assert.Equal(t, map[string][]string{
+		" aa:bb:cc:dd:ee:ff ": {"t3"},
+		"AABBCCDDEEFF":        {"t2", "t1"},
+		"aabbccddeeff":        {"t2", "t1"},
+		"raw-member":          {"t1"},
+		"unknown":             {},
+	}, membership)
+	assert.Contains(t, *lookups, fmt.Sprintf("t1/%d/raw-member", getBucketId("raw-member")))
+	assert.Contains(t, *lookups, fmt.Sprintf("t2/%d/AABBCCDDEF01", getBucketId("AABBCCDDEF01")))
+}
+
+func TestCheckTagMembershipStopsOnError(t *testing.T) {
+	lookups := withBucketMembers(t, map[string][]string{"t1": {"member-1"}}, "t2")
+
+	members := make([]string, 1000)
+	for i := range members {
+		members[i] = fmt.Sprintf("member-%d", i)
+	}
+	membership, err := CheckTagMembership(members, []string{"t1", "t2"})
+	assert.Nil(t, membership)
+	assert.ErrorContains(t, err, "of tag t2: query failed")
+	assert.Less(t, len(*lookups), len(members))
+}
+
+func TestGetMemberLookupForms(t *testing.T) {
+	assert.Equal(t, []string{"raw", "RAW"}, getMemberLookupForms("raw"))
+	assert.Equal(t, []string{"UNKNOWN"}, getMemberLookupForms("UNKNOWN"))
+	assert.Equal(t, []string{"aabbccddeeff", "AABBCCDDEEFF", "AABBCCDDEF01"}, getMemberLookupForms("aabbccddeeff"))
+	assert.Equal(t, []string{" aa:bb:cc:dd:ee:ff", "AA:BB:CC:DD:EE:FF"}, getMemberLookupForms(" aa:bb:cc:dd:ee:ff"))
+}
@@ -0,0 +1,156 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+// getAllTagMetadata lists the tags filtered by owner and prefix, the metadata is returned when metadata=true
+func getAllTagMetadata(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+	withMetadata := false
+	if value := queryParams.Get(common.TagMetadata); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("%s must be true or false", common.TagMetadata)))
+			return
+		}
+		withMetadata = parsed
+	}
+	filter := &xtagging.TagMetadataFilter{
+		Owner:  queryParams.Get(common.TagOwner),
+		Prefix: queryParams.Get(common.TagPrefix),
+	}
+	metadataList, err := ListTagMetadata(filter)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	if withMetadata {
+		writeTagJsonResponse(w, metadataList, http.StatusOK)
+		return
+	}
+	tagIds := make([]string, 0, len(metadataList))
+	for _, metadata := range metadataList {
+		tagIds = append(tagIds, metadata.ID)
+	}
+	writeTagJsonResponse(w, tagIds, http.StatusOK)
+}
+
+func GetTagMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	if err := auth.CanReadTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	metadata, err := GetTagMetadata(id)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, metadata, http.StatusOK)
+}
+
+func SetTagMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	if err := auth.CanWriteTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	request := TagMetadataRequest{}
+	if err := json.Unmarshal([]byte(xw.Body()), &request); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	metadata, err := SetTagMetadata(r, id, &request, time.Now())
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, metadata, http.StatusOK)
+}
+
+// GetTagStatsHandler returns the member count and the bucket distribution of the tag
+func GetTagStatsHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	if err := auth.CanReadTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	stats, err := GetTagStats(id)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, stats, http.StatusOK)
+}
+
+// GetLargestTagsHandler returns the stats of the tags with the most members
+func GetLargestTagsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	limit := DefaultLargestTagsLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > MaxLargestTagsLimit {
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("limit must be from 1 to %d", MaxLargestTagsLimit)))
+			return
+		}
+		limit = parsedLimit
+	}
+	stats, err := GetLargestTags(limit)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, stats, http.StatusOK)
+}
@@ -0,0 +1,278 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const tagRetentionUser = "tag-retention"
+
+// each maintenance task holds its own lock, a slow task neither outlives its lock nor holds back the others
+var (
+	tagMaintenanceLock      = ds.NewDistributedLock(xcommon.TABLE_XCONF_TAG_METADATA, 600)
+	tagMemberExpiryLock     = ds.NewDistributedLock("TagMemberExpiry", 600)
+	tagMetadataBackfillLock = ds.NewDistributedLock(xcommon.TABLE_XCONF_TAG_METADATA+"Backfill", 600)
+	tagJobMaintenanceLock   = ds.NewDistributedLock(xcommon.TABLE_XCONF_TAG_JOB+"Maintenance", 600)
+)
+
+// TagMetadataRequest sets the editable fields of the metadata, expiresAt is in epoch milliseconds and 0 keeps the tag
+type TagMetadataRequest struct {
+	Description string `json:"description"`
+	Owner       string `json:"owner"`
+	ExpiresAt   int64  `json:"expiresAt"`
+}
+
+func GetTagMetadata(tagId string) (*xtagging.TagMetadata, error) {
+	metadata := xtagging.GetOneTagMetadata(tagId)
+	if metadata == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("%s tag metadata not found", tagId))
+	}
+	return metadata, nil
+}
+
+// SetTagMetadata creates or updates the metadata, a tag may be described before its members are added.
+// Setting or changing the expiry schedules the delete of the tag, it needs the delete permission.
+func SetTagMetadata(r *http.Request, tagId string, request *TagMetadataRequest, now time.Time) (*xtagging.TagMetadata, error) {
+	if request.ExpiresAt != 0 && request.ExpiresAt <= now.UnixMilli() {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "expiresAt must be in the future")
+	}
+	userName := auth.GetUserNameOrUnknown(r)
+	metadata := xtagging.GetOneTagMetadata(tagId)
+	if request.ExpiresAt != 0 && (metadata == nil || metadata.ExpiresAt != request.ExpiresAt) {
+		if err := auth.CanDeleteTag(r, tagId); err != nil {
+			return nil, err
+		}
+	}
+	if metadata == nil {
+		metadata = newTagMetadata(tagId, userName, xtagging.MetadataCreationSource, now)
+	}
+	metadata.Description = request.Description
+	metadata.Owner = request.Owner
+	metadata.ExpiresAt = request.ExpiresAt
+	metadata.UpdatedBy = userName
+	if err := xtagging.SetOneTagMetadata(metadata); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return metadata, nil
+}
+
+func newTagMetadata(tagId string, userName string, creationSource string, now time.Time) *xtagging.TagMetadata {
+	return &xtagging.TagMetadata{
+		ID:             tagId,
+		CreatedBy:      userName,
+		CreationSource: creationSource,
+		Created:        now.UnixMilli(),
+	}
+}
+
+// TouchTagMetadata marks the members of the tag as changed, the metadata of a new tag is created
+func TouchTagMetadata(r *http.Request, tagId string, now time.Time) {
+	touchTagMetadata(tagId, auth.GetUserNameOrUnknown(r), now)
+}
+
+func touchTagMetadata(tagId string, userName string, now time.Time) {
+	metadata := xtagging.GetOneTagMetadata(tagId)
+	if metadata == nil {
+		metadata = newTagMetadata(tagId, userName, xtagging.ApiCreationSource, now)
+	}
+	metadata.Updated = now.UnixMilli()
+	metadata.UpdatedBy = userName
+	if err := xtagging.SetOneTagMetadata(metadata); err != nil {
+		log.Errorf("Unable to update the metadata of tag %s: %v", tagId, err)
+	}
+}
+
+// ListTagMetadata returns the metadata of the stored tags and of the described ones which have no members yet.
+// A tag stored before its metadata existed is listed with the legacy creation source.
+func ListTagMetadata(filter *xtagging.TagMetadataFilter) ([]*xtagging.TagMetadata, error) {
+	tagIds, err := GetAllTagIds()
+	if err != nil {
+		return nil, err
+	}
+	return mergeTagMetadata(tagIds, xtagging.GetTagMetadataList(), filter), nil
+}
+
+func mergeTagMetadata(tagIds []string, metadataList []*xtagging.TagMetadata, filter *xtagging.TagMetadataFilter) []*xtagging.TagMetadata {
+	byId := map[string]*xtagging.TagMetadata{}
+	for _, metadata := range metadataList {
+		byId[metadata.ID] = metadata
+	}
+	for _, tagId := range tagIds {
+		if _, ok := byId[tagId]; !ok {
+			byId[tagId] = &xtagging.TagMetadata{ID: tagId, CreationSource: xtagging.LegacyCreationSource}
+		}
+	}
+	result := []*xtagging.TagMetadata{}
+	for _, metadata := range byId {
+		if filter.Matches(metadata) {
+			result = append(result, metadata)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// CountTagMembers sums the counts of the buckets of the tag
+func CountTagMembers(tagId string) (int, error) {
+	bucketCounts, err := getTagBucketCounts(tagId)
+	if err != nil {
+		return 0, err
+	}
+	return newTagStats(tagId, bucketCounts).MemberCount, nil
+}
+
+func StartTagMaintenance(interval time.Duration) {
+	if interval <= 0 {
+		log.Info("Tag maintenance is disabled")
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			MaintainTags(time.Now())
+		}
+	}()
+}
+
+// MaintainTags sweeps the expired members, describes the legacy tags, resumes the stalled jobs and prunes the old ones.
+// It then deletes the expired tags and recounts the members of the changed ones. Each task runs under its own lock.
+func MaintainTags(now time.Time) {
+	runTagMaintenanceTask("member-expiry", tagMemberExpiryLock, func() {
+		SweepExpiredTagMembers(now)
+	})
+	runTagMaintenanceTask("metadata-backfill", tagMetadataBackfillLock, func() {
+		backfillTagMetadata(now)
+	})
+	runTagMaintenanceTask("jobs", tagJobMaintenanceLock, func() {
+		resumeStalledTagImports(now)
+		resumeStalledTagCopies(now)
+		failStaleTagJobs(now)
+		pruneTagJobs(now)
+	})
+	runTagMaintenanceTask("metadata", tagMaintenanceLock, func() {
+		maintainTagMetadata(now)
+	})
+}
+
+// runTagMaintenanceTask runs the task unless another instance holds its lock
+func runTagMaintenanceTask(name string, lock *ds.DistributedLock, task func()) {
+	if xhttp.WebConfServer != nil && xhttp.WebConfServer.DistributedLockConfig.Enabled {
+		owner, _ := os.Hostname()
+		owner = "tag-maintenance-" + owner
+		if err := lock.Lock(owner); err != nil {
+			log.Debugf("Tag maintenance task %s is run by another instance: %v", name, err)
+			return
+		}
+		defer func() {
+			if err := lock.Unlock(owner); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+	task()
+}
+
+// maintainTagMetadata deletes the expired tags and refreshes the member count of the changed ones, the buckets
+// of a few tags whose last recount is older than TagBucketCountRepairInterval are recounted
+func maintainTagMetadata(now time.Time) {
+	repairs := 0
+	for _, metadata := range xtagging.GetTagMetadataList() {
+		if metadata.IsExpired(now.UnixMilli()) {
+			deleteExpiredTag(metadata)
+		} else if repairs < MaxTagCountRepairsPerPass && now.UnixMilli()-metadata.MemberCountRepaired >= TagBucketCountRepairInterval.Milliseconds() {
+			refreshTagMemberCount(metadata.ID, now, true)
+			repairs++
+		} else if metadata.IsMemberCountStale() {
+			refreshTagMemberCount(metadata.ID, now, false)
+		}
+	}
+}
+
+func deleteExpiredTag(metadata *xtagging.TagMetadata) {
+	log.Infof("Tag %s expired at %s, deleting it", metadata.ID, time.UnixMilli(metadata.ExpiresAt).UTC().Format(time.RFC3339))
+	populatedBuckets, err := getPopulatedBuckets(metadata.ID)
+	if err != nil {
+		log.Errorf("Unable to delete expired tag %s: %v", metadata.ID, err)
+		return
+	}
+	if len(populatedBuckets) == 0 {
+		// a described tag which never had members only has its metadata
+		if err := xtagging.DeleteOneTagMetadata(metadata.ID); err != nil {
+			log.Errorf("Unable to delete the metadata of expired tag %s: %v", metadata.ID, err)
+		}
+		return
+	}
+	err = DeleteTag(metadata.ID)
+	auditEntry := &TagAuditEntry{Tag: metadata.ID, Created: time.Now().UnixMilli(), UserName: tagRetentionUser, Operation: AuditDeleteTag}
+	if err != nil {
+		auditEntry.Error = err.Error()
+		log.Errorf("Unable to delete expired tag %s: %v", metadata.ID, err)
+	}
+	RecordTagAudit(auditEntry)
+}
+
+// refreshTagMemberCount sums the counts of the buckets of the tag, they are recounted first when repair is set
+func refreshTagMemberCount(tagId string, now time.Time, repair bool) {
+	count := 0
+	if repair {
+		bucketCounts, err := repairTagBucketCounts(tagId)
+		if err != nil {
+			log.Errorf("Unable to recount the members of tag %s: %v", tagId, err)
+			return
+		}
+		count = newTagStats(tagId, bucketCounts).MemberCount
+	} else {
+		var err error
+		if count, err = CountTagMembers(tagId); err != nil {
+			log.Errorf("Unable to count the members of tag %s: %v", tagId, err)
+			return
+		}
+	}
+	// the members may have changed while they were counted, only the count is updated
+	metadata := xtagging.GetOneTagMetadata(tagId)
+	if metadata == nil {
+		return
+	}
+	metadata.MemberCount = count
+	metadata.MemberCountUpdated = now.UnixMilli()
+	if repair {
+		metadata.MemberCountRepaired = now.UnixMilli()
+	}
+	if err := xtagging.SetOneTagMetadata(metadata); err != nil {
+		log.Errorf("Unable to update the member count of tag %s: %v", tagId, err)
+	}
+}
@@ -0,0 +1,56 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTagMetadataRejectsPastExpiry(t *testing.T) {
+	now := time.Now()
+	r := httptest.NewRequest(http.MethodPut, "/taggingService/tags/t1/metadata", nil)
+	_, err := SetTagMetadata(r, "t1", &TagMetadataRequest{ExpiresAt: now.Add(-time.Minute).UnixMilli()}, now)
+	assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(err))
+}
+
+func TestMergeTagMetadata(t *testing.T) {
+	metadataList := []*xtagging.TagMetadata{
+		{ID: "team-a.rollout", Owner: "team-a", CreationSource: xtagging.ApiCreationSource},
+		{ID: "team-a.planned", Owner: "team-a", CreationSource: xtagging.MetadataCreationSource},
+	}
+	merged := mergeTagMetadata([]string{"team-b.old", "team-a.rollout"}, metadataList, &xtagging.TagMetadataFilter{})
+	assert.Equal(t, 3, len(merged))
+	assert.Equal(t, "team-a.planned", merged[0].ID)
+	assert.Equal(t, "team-a.rollout", merged[1].ID)
+	assert.Equal(t, xtagging.ApiCreationSource, merged[1].CreationSource)
+	// a tag stored before the metadata existed is listed as legacy
+	assert.Equal(t, "team-b.old", merged[2].ID)
+	assert.Equal(t, xtagging.LegacyCreationSource, merged[2].CreationSource)
+
+	filtered := mergeTagMetadata([]string{"team-b.old", "team-a.rollout"}, metadataList, &xtagging.TagMetadataFilter{Owner: "team-a", Prefix: "team-a.r"})
+	assert.Equal(t, 1, len(filtered))
+	assert.Equal(t, "team-a.rollout", filtered[0].ID)
+}
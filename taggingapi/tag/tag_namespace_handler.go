@@ -0,0 +1,116 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+
+	"github.com/gorilla/mux"
+)
+
+func GetTagNamespacesHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanReadTags(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, xtagging.GetTagNamespaceList(), http.StatusOK)
+}
+
+// SetTagNamespaceHandler creates or replaces a namespace, namespaces are managed by the tag admins
+func SetTagNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanManageTagNamespaces(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	xw, ok := w.(*xwhttp.XResponseWriter)
+	if !ok {
+		xhttp.WriteXconfResponse(w, http.StatusInternalServerError, []byte(ResponseWriterCastErrorMsg))
+		return
+	}
+	namespace := xtagging.TagNamespace{}
+	if err := json.Unmarshal([]byte(xw.Body()), &namespace); err != nil {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(RequestBodyReadErrorMsg, err.Error())))
+		return
+	}
+	saved, err := SetTagNamespace(r, &namespace)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, saved, http.StatusOK)
+}
+
+func DeleteTagNamespaceHandler(w http.ResponseWriter, r *http.Request) {
+	if err := auth.CanManageTagNamespaces(r); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	if err := DeleteTagNamespace(mux.Vars(r)[common.TagPrefix]); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, http.StatusNoContent, nil)
+}
+
+// GetTagAuditHandler returns the latest membership changes of the tag
+func GetTagAuditHandler(w http.ResponseWriter, r *http.Request) {
+	id, found := mux.Vars(r)[common.Tag]
+	if !found {
+		xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf(NotSpecifiedErrorMsg, common.Tag)))
+		return
+	}
+	if err := auth.CanReadTag(r, id); err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	limit := DefaultTagAuditLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		parsedLimit, err := strconv.Atoi(limitStr)
+		if err != nil || parsedLimit < 1 || parsedLimit > MaxTagAuditLimit {
+			xhttp.WriteXconfResponse(w, http.StatusBadRequest, []byte(fmt.Sprintf("limit must be from 1 to %d", MaxTagAuditLimit)))
+			return
+		}
+		limit = parsedLimit
+	}
+	entries, err := GetTagAuditEntries(id, limit)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	writeTagJsonResponse(w, entries, http.StatusOK)
+}
+
+func writeTagJsonResponse(w http.ResponseWriter, obj interface{}, status int) {
+	respBytes, err := json.Marshal(obj)
+	if err != nil {
+		xhttp.WriteXconfErrorResponse(w, err)
+		return
+	}
+	xhttp.WriteXconfResponse(w, status, respBytes)
+}
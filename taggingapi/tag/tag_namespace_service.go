@@ -0,0 +1,68 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/rdkcentral/xconfwebconfig/util"
+)
+
+func validateTagNamespace(namespace *xtagging.TagNamespace) error {
+	if strings.TrimSpace(namespace.Prefix) == "" {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf(NotSpecifiedErrorMsg, "prefix"))
+	}
+	if strings.ContainsAny(namespace.Prefix, "/ ") {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "prefix may not contain '/' or spaces")
+	}
+	if len(namespace.OwnerUsers) == 0 && len(namespace.OwnerGroups) == 0 {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "ownerUsers or ownerGroups are required")
+	}
+	return nil
+}
+
+// SetTagNamespace creates or replaces the namespace of the prefix
+func SetTagNamespace(r *http.Request, namespace *xtagging.TagNamespace) (*xtagging.TagNamespace, error) {
+	if err := validateTagNamespace(namespace); err != nil {
+		return nil, err
+	}
+	namespace.UpdatedBy = auth.GetUserNameOrUnknown(r)
+	namespace.Updated = util.GetTimestamp(time.Now().UTC())
+	if err := xtagging.SetOneTagNamespace(namespace); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return namespace, nil
+}
+
+func DeleteTagNamespace(prefix string) error {
+	if xtagging.GetOneTagNamespace(prefix) == nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("Tag namespace %s is not found", prefix))
+	}
+	if err := xtagging.DeleteOneTagNamespace(prefix); err != nil {
+		return xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return nil
+}
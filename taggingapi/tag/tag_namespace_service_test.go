@@ -0,0 +1,88 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	"github.com/rdkcentral/xconfadmin/common"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	xwhttp "github.com/rdkcentral/xconfwebconfig/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func withTagPermissions(t *testing.T, permissions []string) {
+	satOn, getPermissions := common.SatOn, auth.GetPermissionsFunc
+	common.SatOn = true
+	auth.GetPermissionsFunc = func(r *http.Request) []string {
+		return permissions
+	}
+	t.Cleanup(func() {
+		common.SatOn, auth.GetPermissionsFunc = satOn, getPermissions
+	})
+}
+
+func TestValidateTagNamespace(t *testing.T) {
+	assert.Nil(t, validateTagNamespace(&xtagging.TagNamespace{Prefix: "team-a.", OwnerGroups: []string{"team-a"}}))
+
+	invalid := []*xtagging.TagNamespace{
+		{Prefix: " ", OwnerUsers: []string{"alice"}},
+		{Prefix: "team a", OwnerUsers: []string{"alice"}},
+		{Prefix: "team-a."},
+	}
+	for _, namespace := range invalid {
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagNamespace(namespace)), namespace.Prefix)
+	}
+}
+
+func TestTagHandlersCheckPermissions(t *testing.T) {
+	setupTestEnvironment()
+	withTagPermissions(t, []string{auth.WRITE_TAGS})
+
+	req := httptest.NewRequest("DELETE", "/taggingService/tags/test-tag", nil)
+	req = mux.SetURLVars(req, map[string]string{common.Tag: "test-tag"})
+	recorder := httptest.NewRecorder()
+	DeleteTagHandler(&xwhttp.XResponseWriter{ResponseWriter: recorder}, req)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), auth.DELETE_TAGS)
+
+	withTagPermissions(t, []string{})
+	req = httptest.NewRequest("PUT", "/taggingService/tags/test-tag/members", nil)
+	req = mux.SetURLVars(req, map[string]string{common.Tag: "test-tag"})
+	recorder = httptest.NewRecorder()
+	AddMembersToTagHandler(&xwhttp.XResponseWriter{ResponseWriter: recorder}, req)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	GetAllTagsHandler(recorder, httptest.NewRequest("GET", "/taggingService/tags", nil))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	withTagPermissions(t, []string{auth.WRITE_TAGS, auth.DELETE_TAGS, auth.WRITE_TOOLS})
+	req = httptest.NewRequest("DELETE", "/taggingService/namespaces/team-a.", nil)
+	req = mux.SetURLVars(req, map[string]string{common.TagPrefix: "team-a."})
+	recorder = httptest.NewRecorder()
+	DeleteTagNamespaceHandler(recorder, req)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), auth.MANAGE_TAG_NAMESPACES)
+}
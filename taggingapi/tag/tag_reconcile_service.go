@@ -0,0 +1,435 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xcommon "github.com/rdkcentral/xconfadmin/common"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// A reconcile compares the members of a tag in Cassandra with the groups of the members in XDAS. XDAS cannot
+// list the members of a group, so the members checked are the ones in Cassandra and the ones of the failed
+// membership changes in the audit log of the tag. The repair makes one side match the other, a member is
+// repaired with the value of the side it is copied from.
+//
+// The scheduled reconcile checks a sample of the buckets of each tag in one job, and only adds the members missing
+// in XDAS. Removals and the repair of Cassandra are only run on demand, and a bucket is not repaired once XDAS
+// failed to answer for one of its members.
+const (
+	TagJobReconcile = "RECONCILE"
+
+	// ReconcileRepairXdas adds and removes members in XDAS to match Cassandra
+	ReconcileRepairXdas = "XDAS"
+	// ReconcileRepairCassandra adds and removes members in Cassandra to match XDAS
+	ReconcileRepairCassandra = "CASSANDRA"
+
+	// TagReconcileSampleBuckets is the number of buckets of each tag checked by the scheduled reconcile
+	TagReconcileSampleBuckets = 10
+)
+
+var tagReconcileLock = ds.NewDistributedLock(xcommon.TABLE_XCONF_TAG_JOB, 3600)
+
+// tagReconcileOptions tells what a reconcile checks and repairs, the zero value checks and repairs everything
+type tagReconcileOptions struct {
+	// sampleBuckets limits the check to the first page of that many buckets
+	sampleBuckets int
+	// additive only adds the missing members
+	additive bool
+}
+
+func ValidateReconcileRepair(repair string) (string, error) {
+	repair = strings.ToUpper(strings.TrimSpace(repair))
+	switch repair {
+	case "", ReconcileRepairXdas, ReconcileRepairCassandra:
+		return repair, nil
+	}
+	return "", xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("repair must be %s or %s", ReconcileRepairXdas, ReconcileRepairCassandra))
+}
+
+// StartTagReconcile stores a job for the reconcile of the tag and runs it in the background
+func StartTagReconcile(r *http.Request, tagId string, repair string, now time.Time) (*xtagging.TagJob, error) {
+	job, err := newTagReconcileJob(tagId, repair, auth.GetUserNameOrUnknown(r), now)
+	if err != nil {
+		return nil, err
+	}
+	go runTagReconcile(job)
+	return job, nil
+}
+
+func newTagReconcileJob(tagId string, repair string, userName string, now time.Time) (*xtagging.TagJob, error) {
+	job := &xtagging.TagJob{
+		ID:        uuid.New().String(),
+		Type:      TagJobReconcile,
+		Target:    tagId,
+		DryRun:    repair == "",
+		Status:    xtagging.TagJobRunning,
+		Reconcile: &xtagging.TagReconcileReport{Repair: repair},
+		CreatedBy: userName,
+		Created:   now.UnixMilli(),
+		Updated:   now.UnixMilli(),
+	}
+	if err := xtagging.SetOneTagJob(job); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	return job, nil
+}
+
+func StartTagReconciler(interval time.Duration, repair string) {
+	if interval <= 0 {
+		log.Info("Scheduled tag reconcile is disabled")
+		return
+	}
+	repair, err := ValidateReconcileRepair(repair)
+	if err != nil {
+		log.Errorf("Scheduled tag reconcile is disabled: %v", err)
+		return
+	}
+	if repair == ReconcileRepairCassandra {
+		log.Warnf("The %s repair is only run on demand, the scheduled tag reconcile only reports", repair)
+		repair = ""
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			ReconcileAllTags(repair, time.Now())
+		}
+	}()
+}
+
+// ReconcileAllTags checks a sample of the buckets of every tag in one job, the repair only adds the missing members
+func ReconcileAllTags(repair string, now time.Time) {
+	if xhttp.WebConfServer != nil && xhttp.WebConfServer.DistributedLockConfig.Enabled {
+		owner, _ := os.Hostname()
+		owner = "tag-reconciler-" + owner
+		if err := tagReconcileLock.Lock(owner); err != nil {
+			log.Debugf("Tags are reconciled by another instance: %v", err)
+			return
+		}
+		defer func() {
+			if err := tagReconcileLock.Unlock(owner); err != nil {
+				log.Error(err)
+			}
+		}()
+	}
+
+	tagIds, err := GetAllTagIds()
+	if err != nil {
+		log.Errorf("Unable to get the tags to reconcile: %v", err)
+		return
+	}
+	sort.Strings(tagIds)
+	job, err := newTagReconcileJob("", repair, tagRetentionUser, now)
+	if err != nil {
+		log.Errorf("Unable to reconcile the tags: %v", err)
+		return
+	}
+	options := &tagReconcileOptions{sampleBuckets: TagReconcileSampleBuckets, additive: true}
+	var jobErr error
+	for _, tagId := range tagIds {
+		if err := reconcileTag(job, tagId, options, now); err != nil {
+			log.Errorf("Unable to reconcile tag %s: %v", tagId, err)
+			if jobErr == nil {
+				jobErr = err
+			}
+		}
+	}
+	finishTagJob(job, jobErr)
+}
+
+// tagReconcileDiff is the difference of one bucket, the values are the ones of the side the member is in
+type tagReconcileDiff struct {
+	checked            int
+	failed             int
+	missingInXdas      []string
+	missingInCassandra []string
+	values             map[string]string
+	repaired           int
+	err                error
+}
+
+func runTagReconcile(job *xtagging.TagJob) {
+	finishTagJob(job, reconcileTag(job, job.Target, &tagReconcileOptions{}, time.Now()))
+}
+
+// reconcileTag adds the differences of the tag to the report of the job, the progress is counted in buckets
+func reconcileTag(job *xtagging.TagJob, tagId string, options *tagReconcileOptions, now time.Time) error {
+	populatedBuckets, err := getPopulatedBuckets(tagId)
+	if err != nil {
+		return fmt.Errorf("failed to get populated buckets of tag %s: %w", tagId, err)
+	}
+	candidates, err := getTagReconcileCandidates(tagId)
+	if err != nil {
+		log.Warnf("Reconciling tag %s without the audited members: %v", tagId, err)
+	}
+	buckets := map[int]bool{}
+	for _, bucketId := range populatedBuckets {
+		buckets[bucketId] = true
+	}
+	for bucketId := range candidates {
+		buckets[bucketId] = true
+	}
+	bucketIds := make([]int, 0, len(buckets))
+	for bucketId := range buckets {
+		bucketIds = append(bucketIds, bucketId)
+	}
+	sort.Ints(bucketIds)
+	bucketIds = sampleTagReconcileBuckets(bucketIds, options.sampleBuckets, now)
+	job.Total += len(bucketIds)
+	saveTagJobProgress(job)
+
+	bucketChan := make(chan int, len(bucketIds))
+	for _, bucketId := range bucketIds {
+		bucketChan <- bucketId
+	}
+	close(bucketChan)
+	diffChan := make(chan *tagReconcileDiff, getReadWorkerCount())
+	var wg sync.WaitGroup
+	for w := 0; w < min(getReadWorkerCount(), max(len(bucketIds), 1)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bucketId := range bucketChan {
+				diffChan <- reconcileTagBucket(tagId, bucketId, candidates[bucketId], job.Reconcile.Repair, options)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(diffChan)
+	}()
+
+	var tagErr error
+	report := job.Reconcile
+	repaired, missing := 0, 0
+	for diff := range diffChan {
+		job.Processed++
+		if diff.err != nil && tagErr == nil {
+			tagErr = diff.err
+		}
+		report.Checked += diff.checked
+		report.Failed += diff.failed
+		report.Repaired += diff.repaired
+		report.AddMissingInXdas(diff.missingInXdas)
+		report.AddMissingInCassandra(diff.missingInCassandra)
+		repaired += diff.repaired
+		missing += len(diff.missingInXdas) + len(diff.missingInCassandra)
+		if job.Processed%TagJobProgressInterval == 0 || isTagJobHeartbeatDue(job) {
+			saveTagJobProgress(job)
+		}
+	}
+	job.ResultCount = report.MissingInXdasCount + report.MissingInCassandraCount
+	if report.Repair != "" {
+		job.Stored = report.Repaired
+		if repaired > 0 {
+			RecordTagAudit(&TagAuditEntry{Tag: tagId, Created: time.Now().UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+				Operation: AuditReconcile, Requested: missing, Affected: repaired})
+			touchTagMetadata(tagId, job.CreatedBy, time.Now())
+		}
+	}
+	if missing > 0 {
+		log.Warnf("Tag %s differs between Cassandra and XDAS: missing=%d, repaired=%d, repair=%s", tagId, missing, repaired, report.Repair)
+	}
+	return tagErr
+}
+
+// sampleTagReconcileBuckets returns count buckets from an offset which moves every hour, all buckets for 0
+func sampleTagReconcileBuckets(bucketIds []int, count int, now time.Time) []int {
+	if count <= 0 || len(bucketIds) <= count {
+		return bucketIds
+	}
+	offset := int(now.Unix()/3600) % len(bucketIds)
+	sample := make([]int, 0, count)
+	for i := 0; i < count; i++ {
+		sample = append(sample, bucketIds[(offset+i)%len(bucketIds)])
+	}
+	sort.Ints(sample)
+	return sample
+}
+
+// reconcileTagBucket checks the bucket page by page, a sampled bucket only has its first page checked
+func reconcileTagBucket(tagId string, bucketId int, candidates []string, repair string, options *tagReconcileOptions) *tagReconcileDiff {
+	total := &tagReconcileDiff{}
+	lastMember := ""
+	for {
+		storedMembers, values, err := getMemberValuesFromBucket(tagId, bucketId, lastMember, MemberFetchChunkSize)
+		if err != nil {
+			total.err = fmt.Errorf("failed to fetch bucket %d of tag %s: %w", bucketId, tagId, err)
+			return total
+		}
+		// the audited members are checked with the first page
+		diff := diffTagMembers(tagId, storedMembers, values, candidates)
+		candidates = nil
+		if repair != "" && diff.failed == 0 {
+			repairTagMembers(tagId, diff, repair, options.additive)
+		} else if repair != "" {
+			log.Warnf("Not repairing bucket %d of tag %s, XDAS failed for %d members", bucketId, tagId, diff.failed)
+		}
+		total.checked += diff.checked
+		total.failed += diff.failed
+		total.repaired += diff.repaired
+		total.missingInXdas = append(total.missingInXdas, diff.missingInXdas...)
+		total.missingInCassandra = append(total.missingInCassandra, diff.missingInCassandra...)
+		if diff.err != nil && total.err == nil {
+			total.err = diff.err
+		}
+		if len(storedMembers) < MemberFetchChunkSize || options.sampleBuckets > 0 {
+			return total
+		}
+		lastMember = storedMembers[len(storedMembers)-1]
+	}
+}
+
+// getTagReconcileCandidates returns the members of the failed membership changes by bucket
+func getTagReconcileCandidates(tagId string) (map[int][]string, error) {
+	entries, err := GetTagAuditEntries(tagId, MaxTagAuditLimit)
+	if err != nil {
+		return nil, err
+	}
+	candidates := map[int][]string{}
+	for _, entry := range entries {
+		if entry.Error == "" || (entry.Operation != AuditAddMembers && entry.Operation != AuditRemoveMembers) {
+			continue
+		}
+		for _, member := range entry.Members {
+			bucketId := getBucketId(member)
+			candidates[bucketId] = append(candidates[bucketId], member)
+		}
+	}
+	return candidates, nil
+}
+
+// diffTagMembers checks the members stored in Cassandra with their values and the candidates of the same bucket in XDAS
+func diffTagMembers(tagId string, storedMembers []string, storedValues map[string]string, candidates []string) *tagReconcileDiff {
+	stored := make(map[string]bool, len(storedMembers))
+	for _, member := range storedMembers {
+		stored[member] = true
+	}
+	members := append([]string{}, storedMembers...)
+	for _, member := range candidates {
+		if _, found := stored[member]; !found {
+			members = append(members, member)
+			stored[member] = false
+		}
+	}
+
+	diff := &tagReconcileDiff{values: map[string]string{}}
+	for _, member := range members {
+		xdasValue, inXdas, err := getTagMemberInXdas(tagId, member)
+		if err != nil {
+			diff.failed++
+			log.Errorf("Unable to check member %s of tag %s in XDAS: %v", member, tagId, err)
+			continue
+		}
+		diff.checked++
+		if stored[member] && !inXdas {
+			diff.missingInXdas = append(diff.missingInXdas, member)
+			diff.values[member] = storedValues[member]
+		} else if !stored[member] && inXdas {
+			diff.missingInCassandra = append(diff.missingInCassandra, member)
+			diff.values[member] = xdasValue
+		}
+	}
+	return diff
+}
+
+// getTagMemberInXdas returns the value of the member in the group of the tag and whether it is in the group
+func getTagMemberInXdas(tagId string, member string) (string, bool, error) {
+	groups, err := GetGroupServiceConnector().GetGroupsMemberBelongsTo(ToNormalizedEcm(member))
+	if err != nil {
+		return "", false, err
+	}
+	value, found := groups.GetFields()[SetTagPrefix(tagId)]
+	return value, found, nil
+}
+
+// repairTagMembers makes XDAS or Cassandra match the other side with the values of that side,
+// an additive repair does not remove the members missing in the other side
+func repairTagMembers(tagId string, diff *tagReconcileDiff, repair string, additive bool) {
+	var err error
+	switch repair {
+	case ReconcileRepairXdas:
+		for value, members := range groupMembersByValue(diff.missingInXdas, diff.values) {
+			var added []string
+			added, err = addMembersToXdas(tagId, members, value)
+			diff.repaired += len(added)
+			if err != nil {
+				break
+			}
+		}
+		if err == nil && !additive && len(diff.missingInCassandra) > 0 {
+			var removed []string
+			removed, err = removeMembersFromXDAS(tagId, diff.missingInCassandra)
+			diff.repaired += len(removed)
+		}
+	case ReconcileRepairCassandra:
+		for value, members := range groupMembersByValue(diff.missingInCassandra, diff.values) {
+			err = forEachMemberChunk(members, func(chunk []string) error {
+				return AddMembersWithValue(tagId, chunk, value, 0)
+			}, &diff.repaired)
+			if err != nil {
+				break
+			}
+		}
+		if err == nil && !additive {
+			err = forEachMemberChunk(diff.missingInXdas, func(chunk []string) error {
+				return RemoveMembers(tagId, chunk)
+			}, &diff.repaired)
+		}
+	}
+	if err != nil {
+		diff.err = fmt.Errorf("failed to repair tag %s in %s: %w", tagId, strings.ToLower(repair), err)
+	}
+}
+
+func groupMembersByValue(members []string, values map[string]string) map[string][]string {
+	byValue := map[string][]string{}
+	for _, member := range members {
+		byValue[values[member]] = append(byValue[values[member]], member)
+	}
+	return byValue
+}
+
+// forEachMemberChunk applies the change to the members in batches of MaxBatchSizeV2 and counts the changed ones
+func forEachMemberChunk(members []string, change func([]string) error, changed *int) error {
+	for start := 0; start < len(members); start += MaxBatchSizeV2 {
+		chunk := members[start:min(start+MaxBatchSizeV2, len(members))]
+		if err := change(chunk); err != nil {
+			return err
+		}
+		*changed += len(chunk)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-akka/configuration"
+	xhttp "github.com/rdkcentral/xconfadmin/http"
+	taggingapi_config "github.com/rdkcentral/xconfadmin/taggingapi/config"
+	proto2 "github.com/rdkcentral/xconfadmin/taggingapi/proto/generated"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+// stubGroupService keeps the groups of each member like XDAS does
+type stubGroupService struct {
+	mutex  sync.Mutex
+	groups map[string]map[string]string
+}
+
+func (s *stubGroupService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	elements := strings.Split(strings.TrimPrefix(r.URL.Path, "/members/"), "/")
+	member := elements[0]
+	switch {
+	case r.Method == http.MethodGet:
+		data, _ := proto.Marshal(&proto2.XdasHashes{Fields: s.groups[member]})
+		w.Write(data)
+	case r.Method == http.MethodPost:
+		body, _ := io.ReadAll(r.Body)
+		hashes := proto2.XdasHashes{}
+		if err := proto.Unmarshal(body, &hashes); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if s.groups[member] == nil {
+			s.groups[member] = map[string]string{}
+		}
+		for group, value := range hashes.Fields {
+			s.groups[member][group] = value
+		}
+	case r.Method == http.MethodDelete && len(elements) == 3:
+		delete(s.groups[member], elements[2])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func withStubGroupService(t *testing.T, groups map[string]map[string]string) *stubGroupService {
+	stub := &stubGroupService{groups: groups}
+	server := httptest.NewServer(stub)
+	t.Cleanup(server.Close)
+
+	conf := configuration.ParseString(fmt.Sprintf(`
+		xconfwebconfig {
+			xconf {
+				group_service_name = "xdas"
+				group_sync_service_name = "xdas_sync"
+			}
+			xdas {
+				host = "%[1]s"
+				getGroupsMembersTemplate = "%%s/members/%%s"
+				getAllGroupsTemplate = "%%s/members"
+				retries = 0
+			}
+			xdas_sync {
+				host = "%[1]s"
+				addGroupMemberTemplate = "%%s/members/%%s"
+				removeGroupMemberTemplate = "%%s/members/%%s/groups/%%s"
+				retries = 0
+			}
+		}
+	`, server.URL))
+
+	webConfServer := xhttp.WebConfServer
+	xhttp.WebConfServer = &xhttp.WebconfigServer{
+		GroupServiceConnector:     xhttp.NewGroupServiceConnector(conf, nil),
+		GroupServiceSyncConnector: xhttp.NewGroupServiceSyncConnector(conf, nil),
+		TaggingApiConfig:          &taggingapi_config.TaggingApiConfig{BatchLimit: 5000, WorkerCount: 2},
+	}
+	t.Cleanup(func() {
+		xhttp.WebConfServer = webConfServer
+	})
+	return stub
+}
+
+func TestReconcileTagMembersWithStubGroupService(t *testing.T) {
+	stub := withStubGroupService(t, map[string]map[string]string{
+		"DEVICE-1": {"t_tag1": "", "t_other": ""},
+		"DEVICE-3": {"t_tag1": ""},
+	})
+
+	// device-2 is only in Cassandra, device-3 is an audited member only in XDAS
+	diff := diffTagMembers("tag1", []string{"device-1", "device-2"}, map[string]string{"device-2": "v2"}, []string{"device-3", "device-1"})
+	assert.Nil(t, diff.err)
+	assert.Equal(t, 3, diff.checked)
+	assert.Equal(t, []string{"device-2"}, diff.missingInXdas)
+	assert.Equal(t, []string{"device-3"}, diff.missingInCassandra)
+
+	// an additive repair only adds the member missing in XDAS, with its value in Cassandra
+	repairTagMembers("tag1", diff, ReconcileRepairXdas, true)
+	assert.Nil(t, diff.err)
+	assert.Equal(t, 1, diff.repaired)
+	assert.Equal(t, map[string]string{"t_tag1": "v2"}, stub.groups["DEVICE-2"])
+	assert.Equal(t, map[string]string{"t_tag1": ""}, stub.groups["DEVICE-3"])
+
+	repairTagMembers("tag1", diff, ReconcileRepairXdas, false)
+	assert.Nil(t, diff.err)
+	assert.Equal(t, 3, diff.repaired)
+	assert.Equal(t, map[string]string{}, stub.groups["DEVICE-3"])
+	assert.Equal(t, map[string]string{"t_tag1": "", "t_other": ""}, stub.groups["DEVICE-1"])
+
+	diff = diffTagMembers("tag1", []string{"device-1", "device-2"}, nil, []string{"device-3"})
+	assert.Empty(t, diff.missingInXdas)
+	assert.Empty(t, diff.missingInCassandra)
+}
+
+func TestSampleTagReconcileBuckets(t *testing.T) {
+	bucketIds := []int{1, 2, 3, 4, 5}
+	assert.Equal(t, bucketIds, sampleTagReconcileBuckets(bucketIds, 0, time.Now()))
+	assert.Equal(t, bucketIds, sampleTagReconcileBuckets(bucketIds, 10, time.Now()))
+
+	now := time.Unix(3*3600, 0)
+	assert.Equal(t, []int{1, 4, 5}, sampleTagReconcileBuckets(bucketIds, 3, now))
+	assert.Equal(t, []int{1, 2, 5}, sampleTagReconcileBuckets(bucketIds, 3, now.Add(time.Hour)))
+}
+
+func TestValidateReconcileRepair(t *testing.T) {
+	for input, expected := range map[string]string{"": "", "xdas": ReconcileRepairXdas, " Cassandra ": ReconcileRepairCassandra} {
+		repair, err := ValidateReconcileRepair(input)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, repair)
+	}
+	_, err := ValidateReconcileRepair("both")
+	assert.NotNil(t, err)
+}
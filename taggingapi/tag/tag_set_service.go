@@ -0,0 +1,369 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rdkcentral/xconfadmin/adminapi/auth"
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	"github.com/rdkcentral/xconfadmin/util"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+)
+
+// set operations over the members of tags, the difference removes the members of the other tags from the first one
+const (
+	TagSetUnion        = "UNION"
+	TagSetIntersection = "INTERSECTION"
+	TagSetDifference   = "DIFFERENCE"
+
+	MaxTagSetSources = 10
+	// TagJobProgressInterval is the number of buckets processed between the progress updates of a job
+	TagJobProgressInterval = 50
+	TagJobRetention        = 7 * 24 * time.Hour
+	// a running job saves its progress at least every TagJobHeartbeatInterval, a job which has not been saved
+	// for TagJobStaleTimeout lost its instance and is failed by the maintenance
+	TagJobHeartbeatInterval = 30 * time.Second
+	TagJobStaleTimeout      = 10 * time.Minute
+)
+
+// TagSetRequest combines the members of the tags into the target tag, a dry run only counts the result
+type TagSetRequest struct {
+	Operation string   `json:"operation"`
+	Tags      []string `json:"tags"`
+	Target    string   `json:"target"`
+	Value     string   `json:"value"`
+	DryRun    bool     `json:"dryRun"`
+}
+
+func validateTagSetRequest(request *TagSetRequest) error {
+	request.Operation = strings.ToUpper(strings.TrimSpace(request.Operation))
+	switch request.Operation {
+	case TagSetUnion, TagSetIntersection, TagSetDifference:
+	default:
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("operation must be one of %s, %s or %s", TagSetUnion, TagSetIntersection, TagSetDifference))
+	}
+	if len(request.Tags) < 2 || len(request.Tags) > MaxTagSetSources {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("from 2 to %d tags are required", MaxTagSetSources))
+	}
+	tagIds := map[string]bool{}
+	for _, tagId := range request.Tags {
+		if util.IsBlank(tagId) {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "tag must not be blank")
+		}
+		if tagIds[tagId] {
+			return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, fmt.Sprintf("tag %s is duplicated", tagId))
+		}
+		tagIds[tagId] = true
+	}
+	if request.DryRun {
+		return nil
+	}
+	if util.IsBlank(request.Target) {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target is required")
+	}
+	if tagIds[request.Target] {
+		return xwcommon.NewRemoteErrorAS(http.StatusBadRequest, "target must not be one of the combined tags")
+	}
+	return nil
+}
+
+// StartTagSetOperation stores a job for the operation and runs it in the background
+func StartTagSetOperation(r *http.Request, request *TagSetRequest, now time.Time) (*xtagging.TagJob, error) {
+	if err := validateTagSetRequest(request); err != nil {
+		return nil, err
+	}
+	job := &xtagging.TagJob{
+		ID:        uuid.New().String(),
+		Type:      request.Operation,
+		Sources:   request.Tags,
+		DryRun:    request.DryRun,
+		Status:    xtagging.TagJobRunning,
+		CreatedBy: auth.GetUserNameOrUnknown(r),
+		Created:   now.UnixMilli(),
+		Updated:   now.UnixMilli(),
+	}
+	if !request.DryRun {
+		job.Target = request.Target
+	}
+	if err := xtagging.SetOneTagJob(job); err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	go runTagSetOperation(job, request.Value)
+	return job, nil
+}
+
+// getTagSetBuckets returns the buckets which may hold members of the result
+func getTagSetBuckets(operation string, bucketsPerTag [][]int) []int {
+	counts := map[int]int{}
+	for _, buckets := range bucketsPerTag {
+		for _, bucketId := range buckets {
+			counts[bucketId]++
+		}
+	}
+	result := []int{}
+	switch operation {
+	case TagSetUnion:
+		for bucketId := range counts {
+			result = append(result, bucketId)
+		}
+	case TagSetIntersection:
+		for bucketId, count := range counts {
+			if count == len(bucketsPerTag) {
+				result = append(result, bucketId)
+			}
+		}
+	case TagSetDifference:
+		result = append(result, bucketsPerTag[0]...)
+	}
+	sort.Ints(result)
+	return result
+}
+
+// combineTagSetMembers combines the members of one bucket of each tag in the order of the tags
+func combineTagSetMembers(operation string, membersPerTag [][]string) []string {
+	counts := map[string]int{}
+	for i, members := range membersPerTag {
+		for _, member := range members {
+			if operation == TagSetDifference && i > 0 {
+				counts[member] = -1
+			} else if counts[member] >= 0 {
+				counts[member]++
+			}
+		}
+	}
+	result := []string{}
+	for member, count := range counts {
+		switch operation {
+		case TagSetUnion:
+			result = append(result, member)
+		case TagSetIntersection:
+			if count == len(membersPerTag) {
+				result = append(result, member)
+			}
+		case TagSetDifference:
+			if count > 0 {
+				result = append(result, member)
+			}
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+type tagSetBucketResult struct {
+	members []string
+	err     error
+}
+
+func runTagSetOperation(job *xtagging.TagJob, tagValue string) {
+	bucketsPerTag := make([][]int, len(job.Sources))
+	for i, tagId := range job.Sources {
+		buckets, err := getPopulatedBuckets(tagId)
+		if err != nil {
+			finishTagJob(job, fmt.Errorf("failed to get populated buckets of tag %s: %w", tagId, err))
+			return
+		}
+		bucketsPerTag[i] = buckets
+	}
+	buckets := getTagSetBuckets(job.Type, bucketsPerTag)
+	job.Total = len(buckets)
+	saveTagJobProgress(job)
+
+	// bucket N of each tag holds the same members, so the buckets are combined independently
+	bucketChan := make(chan int, len(buckets))
+	for _, bucketId := range buckets {
+		bucketChan <- bucketId
+	}
+	close(bucketChan)
+	resultChan := make(chan tagSetBucketResult, getReadWorkerCount())
+	var wg sync.WaitGroup
+	for w := 0; w < min(getReadWorkerCount(), max(len(buckets), 1)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for bucketId := range bucketChan {
+				resultChan <- combineTagSetBucket(job.Type, job.Sources, bucketId)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var jobErr error
+	pending := []string{}
+	flush := func(all bool) {
+		for jobErr == nil && len(pending) > 0 && (all || len(pending) >= MaxBatchSizeV2) {
+			chunk := pending[:min(MaxBatchSizeV2, len(pending))]
+			stored, err := AddMembersWithXdas(job.Target, chunk, tagValue)
+			job.Stored += stored
+			if err != nil {
+				jobErr = fmt.Errorf("failed to store members in tag %s: %w", job.Target, err)
+			}
+			pending = pending[len(chunk):]
+		}
+	}
+	for result := range resultChan {
+		job.Processed++
+		if result.err != nil && jobErr == nil {
+			jobErr = result.err
+		}
+		if jobErr == nil {
+			job.ResultCount += len(result.members)
+			if !job.DryRun {
+				pending = append(pending, result.members...)
+				flush(false)
+			}
+		}
+		if job.Processed%TagJobProgressInterval == 0 || isTagJobHeartbeatDue(job) {
+			saveTagJobProgress(job)
+		}
+	}
+	flush(true)
+
+	if !job.DryRun {
+		auditEntry := &TagAuditEntry{Tag: job.Target, Created: time.Now().UnixMilli(), AuditId: job.ID, UserName: job.CreatedBy,
+			Operation: AuditAddMembers, Requested: job.ResultCount, Affected: job.Stored}
+		if jobErr != nil {
+			auditEntry.Error = jobErr.Error()
+		}
+		RecordTagAudit(auditEntry)
+		if job.Stored > 0 {
+			touchTagMetadata(job.Target, job.CreatedBy, time.Now())
+		}
+	}
+	finishTagJob(job, jobErr)
+}
+
+func combineTagSetBucket(operation string, tagIds []string, bucketId int) tagSetBucketResult {
+	membersPerTag := make([][]string, len(tagIds))
+	for i, tagId := range tagIds {
+		members, err := fetchBucketMembersWithLimit(tagId, bucketId, "", math.MaxInt32)
+		if err != nil {
+			return tagSetBucketResult{err: fmt.Errorf("failed to fetch bucket %d of tag %s: %w", bucketId, tagId, err)}
+		}
+		membersPerTag[i] = members
+	}
+	return tagSetBucketResult{members: combineTagSetMembers(operation, membersPerTag)}
+}
+
+// getTagJobFunc and setTagJobFunc read and store the jobs, the tests replace them
+var (
+	getTagJobFunc = xtagging.GetOneTagJob
+	setTagJobFunc = xtagging.SetOneTagJob
+)
+
+func saveTagJobProgress(job *xtagging.TagJob) {
+	job.Updated = time.Now().UnixMilli()
+	if err := setTagJobFunc(job); err != nil {
+		log.Errorf("Unable to save the progress of tag job %s: %v", job.ID, err)
+	}
+}
+
+// tagJobOwner identifies this instance as the owner of the jobs it runs
+var tagJobOwner = newTagJobOwner()
+
+func newTagJobOwner() string {
+	hostName, _ := os.Hostname()
+	return hostName + "-" + uuid.New().String()
+}
+
+// claimTagJob takes the job over, the maintenance claims the stalled jobs under its lock so a job has one owner
+func claimTagJob(job *xtagging.TagJob) error {
+	job.Owner = tagJobOwner
+	job.Updated = time.Now().UnixMilli()
+	return setTagJobFunc(job)
+}
+
+// ownsTagJob tells whether the job is still run by this instance, a stalled job may have been taken over
+func ownsTagJob(job *xtagging.TagJob) bool {
+	stored := getTagJobFunc(job.ID)
+	return stored != nil && stored.Owner == job.Owner && !stored.IsDone()
+}
+
+// isTagJobHeartbeatDue tells whether the progress of the running job must be saved to keep it alive
+func isTagJobHeartbeatDue(job *xtagging.TagJob) bool {
+	return time.Now().UnixMilli()-job.Updated >= TagJobHeartbeatInterval.Milliseconds()
+}
+
+func finishTagJob(job *xtagging.TagJob, err error) {
+	job.Status = xtagging.TagJobCompleted
+	if err != nil {
+		job.Status = xtagging.TagJobFailed
+		job.Error = err.Error()
+	}
+	log.WithFields(log.Fields{
+		"job_id":    job.ID,
+		"type":      job.Type,
+		"sources":   job.Sources,
+		"target":    job.Target,
+		"dry_run":   job.DryRun,
+		"result":    job.ResultCount,
+		"stored":    job.Stored,
+		"processed": job.Processed,
+	}).Infof("tag job %s", strings.ToLower(job.Status))
+	saveTagJobProgress(job)
+}
+
+func GetTagJob(id string) (*xtagging.TagJob, error) {
+	job := xtagging.GetOneTagJob(id)
+	if job == nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("tag job %s not found", id))
+	}
+	return job, nil
+}
+
+// failStaleTagJobs fails the running jobs whose instance stopped, the imports and the copies are resumed instead
+func failStaleTagJobs(now time.Time) {
+	staleSince := now.Add(-TagJobStaleTimeout).UnixMilli()
+	for _, job := range xtagging.GetTagJobList() {
+		if job.Status != xtagging.TagJobRunning || job.Updated > staleSince {
+			continue
+		}
+		switch job.Type {
+		case TagSetUnion, TagSetIntersection, TagSetDifference, TagJobReconcile, TagJobCohort:
+			log.Warnf("Tag job %s has made no progress since %s, failing it", job.ID, time.UnixMilli(job.Updated).UTC().Format(time.RFC3339))
+			finishTagJob(job, fmt.Errorf("job stopped without progress since %s", time.UnixMilli(job.Updated).UTC().Format(time.RFC3339)))
+		}
+	}
+}
+
+// pruneTagJobs deletes the jobs which are done for longer than the retention
+func pruneTagJobs(now time.Time) {
+	for _, job := range xtagging.GetTagJobList() {
+		if job.IsDone() && job.Updated < now.Add(-TagJobRetention).UnixMilli() {
+			if err := xtagging.DeleteOneTagJob(job.ID); err != nil {
+				log.Errorf("Unable to delete tag job %s: %v", job.ID, err)
+			}
+		}
+	}
+}
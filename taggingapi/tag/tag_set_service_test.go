@@ -0,0 +1,70 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTagSetRequest(t *testing.T) {
+	request := &TagSetRequest{Operation: "difference", Tags: []string{"a", "b"}, Target: "c"}
+	assert.Nil(t, validateTagSetRequest(request))
+	assert.Equal(t, TagSetDifference, request.Operation)
+	assert.Nil(t, validateTagSetRequest(&TagSetRequest{Operation: TagSetUnion, Tags: []string{"a", "b"}, DryRun: true}))
+
+	invalid := []*TagSetRequest{
+		{Operation: "xor", Tags: []string{"a", "b"}, Target: "c"},
+		{Operation: TagSetUnion, Tags: []string{"a"}, Target: "c"},
+		{Operation: TagSetUnion, Tags: []string{"a", "a"}, Target: "c"},
+		{Operation: TagSetUnion, Tags: []string{"a", " "}, Target: "c"},
+		{Operation: TagSetUnion, Tags: []string{"a", "b"}},
+		{Operation: TagSetUnion, Tags: []string{"a", "b"}, Target: "b"},
+	}
+	for _, request := range invalid {
+		assert.Equal(t, http.StatusBadRequest, xwcommon.GetXconfErrorStatusCode(validateTagSetRequest(request)), request)
+	}
+}
+
+func TestGetTagSetBuckets(t *testing.T) {
+	bucketsPerTag := [][]int{{1, 2, 3}, {2, 3, 4}, {3, 5}}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, getTagSetBuckets(TagSetUnion, bucketsPerTag))
+	assert.Equal(t, []int{3}, getTagSetBuckets(TagSetIntersection, bucketsPerTag))
+	assert.Equal(t, []int{1, 2, 3}, getTagSetBuckets(TagSetDifference, bucketsPerTag))
+}
+
+func TestCombineTagSetMembers(t *testing.T) {
+	membersPerTag := [][]string{{"m1", "m2", "m3"}, {"m2", "m3", "m4"}, {"m3"}}
+	assert.Equal(t, []string{"m1", "m2", "m3", "m4"}, combineTagSetMembers(TagSetUnion, membersPerTag))
+	assert.Equal(t, []string{"m3"}, combineTagSetMembers(TagSetIntersection, membersPerTag))
+	assert.Equal(t, []string{"m1"}, combineTagSetMembers(TagSetDifference, membersPerTag))
+	assert.Equal(t, []string{}, combineTagSetMembers(TagSetDifference, [][]string{{}, {"m1"}}))
+}
+
+func TestIsTagJobHeartbeatDue(t *testing.T) {
+	job := &xtagging.TagJob{Updated: time.Now().UnixMilli()}
+	assert.False(t, isTagJobHeartbeatDue(job))
+	job.Updated = time.Now().Add(-TagJobHeartbeatInterval).UnixMilli()
+	assert.True(t, isTagJobHeartbeatDue(job))
+}
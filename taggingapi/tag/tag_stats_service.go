@@ -0,0 +1,239 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	xtagging "github.com/rdkcentral/xconfadmin/shared/tagging"
+
+	xwcommon "github.com/rdkcentral/xconfwebconfig/common"
+	ds "github.com/rdkcentral/xconfwebconfig/db"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// tagMetadataBackfilled is only read and written by the maintenance
+var tagMetadataBackfilled bool
+
+// The members of each bucket are counted as they change, so that the size of a tag is read without counting its
+// buckets. A counter is changed by the rows actually inserted or deleted: the members are looked up in their bucket
+// before they are written, and an expired member is counted out when its expiry is swept. A count drifts when the
+// same member is added concurrently or added again with a shorter TTL, so the maintenance recounts the buckets of
+// each tag every TagBucketCountRepairInterval. The table is created by db/migrations/0016_tag_bucket_counts.cql.
+const (
+	DefaultLargestTagsLimit = 20
+	MaxLargestTagsLimit     = 100
+
+	// TagBucketCountRepairInterval is the time after which the maintenance recounts the buckets of a tag
+	TagBucketCountRepairInterval = 24 * time.Hour
+	// MaxTagCountRepairsPerPass bounds the tags recounted by a pass of the maintenance
+	MaxTagCountRepairsPerPass = 10
+
+	QueryAddBucketCount  = `UPDATE "TagBucketCounts" SET members = members + ? WHERE tag_id = ? AND bucket_id = ?`
+	QueryGetBucketCounts = `SELECT bucket_id, members FROM "TagBucketCounts" WHERE tag_id = ?`
+	// QueryGetStoredBucketMember is completed with the placeholders of the members
+	QueryGetStoredBucketMember = `SELECT member, TTL(created) AS ttl FROM "TagMembersBucketed" WHERE tag_id = ? AND bucket_id = ? AND member IN (%s)`
+)
+
+// TagStats is the size of a tag and of its populated buckets
+type TagStats struct {
+	ID            string  `json:"id"`
+	MemberCount   int     `json:"memberCount"`
+	BucketCount   int     `json:"bucketCount"`
+	MinBucketSize int     `json:"minBucketSize"`
+	MaxBucketSize int     `json:"maxBucketSize"`
+	AvgBucketSize float64 `json:"avgBucketSize"`
+	LastModified  int64   `json:"lastModified,omitempty"`
+}
+
+// newTagStats summarizes the counts of the buckets, the empty buckets are not populated
+func newTagStats(tagId string, bucketCounts map[int]int) *TagStats {
+	stats := &TagStats{ID: tagId}
+	for _, count := range bucketCounts {
+		if count <= 0 {
+			continue
+		}
+		if stats.BucketCount == 0 || count < stats.MinBucketSize {
+			stats.MinBucketSize = count
+		}
+		stats.MaxBucketSize = max(stats.MaxBucketSize, count)
+		stats.MemberCount += count
+		stats.BucketCount++
+	}
+	if stats.BucketCount > 0 {
+		stats.AvgBucketSize = float64(stats.MemberCount) / float64(stats.BucketCount)
+	}
+	return stats
+}
+
+func GetTagStats(tagId string) (*TagStats, error) {
+	bucketCounts, err := getTagBucketCounts(tagId)
+	if err != nil {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+	}
+	stats := newTagStats(tagId, bucketCounts)
+	if stats.BucketCount == 0 {
+		return nil, xwcommon.NewRemoteErrorAS(http.StatusNotFound, fmt.Sprintf("tag %s not found", tagId))
+	}
+	if metadata := xtagging.GetOneTagMetadata(tagId); metadata != nil {
+		stats.LastModified = metadata.Updated
+	}
+	return stats, nil
+}
+
+// GetLargestTags returns the tags with the most members first, the tags are ranked by the counts of their buckets
+func GetLargestTags(limit int) ([]*TagStats, error) {
+	result := []*TagStats{}
+	for _, metadata := range xtagging.GetTagMetadataList() {
+		bucketCounts, err := getTagBucketCounts(metadata.ID)
+		if err != nil {
+			return nil, xwcommon.NewRemoteErrorAS(http.StatusInternalServerError, err.Error())
+		}
+		stats := newTagStats(metadata.ID, bucketCounts)
+		if stats.BucketCount == 0 {
+			continue
+		}
+		stats.LastModified = metadata.Updated
+		result = append(result, stats)
+	}
+	sortLargestTags(result)
+	return result[:min(limit, len(result))], nil
+}
+
+func sortLargestTags(stats []*TagStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].MemberCount != stats[j].MemberCount {
+			return stats[i].MemberCount > stats[j].MemberCount
+		}
+		return stats[i].ID < stats[j].ID
+	})
+}
+
+// getTagBucketCounts returns the count of each bucket of the tag
+func getTagBucketCounts(tagId string) (map[int]int, error) {
+	rows, err := ds.GetSimpleDao().Query(QueryGetBucketCounts, tagId)
+	if err != nil {
+		return nil, err
+	}
+	bucketCounts := map[int]int{}
+	for _, row := range rows {
+		bucketCounts[toCount(row["bucket_id"])] = toCount(row["members"])
+	}
+	return bucketCounts, nil
+}
+
+func addBucketCount(tagId string, bucketId int, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	return ds.GetSimpleDao().Modify(QueryAddBucketCount, strconv.Itoa(delta), tagId, strconv.Itoa(bucketId))
+}
+
+// repairTagBucketCounts counts the rows of the populated buckets and corrects the counters which drifted,
+// it returns the repaired counts
+func repairTagBucketCounts(tagId string) (map[int]int, error) {
+	bucketCounts, err := getTagBucketCounts(tagId)
+	if err != nil {
+		return nil, err
+	}
+	populatedBuckets, err := getPopulatedBuckets(tagId)
+	if err != nil {
+		return nil, err
+	}
+	repaired := map[int]int{}
+	for bucketId := range bucketCounts {
+		repaired[bucketId] = 0
+	}
+	for _, bucketId := range populatedBuckets {
+		count, err := getMembersCountOfBucket(tagId, bucketId)
+		if err != nil {
+			return nil, err
+		}
+		repaired[bucketId] = count
+	}
+	for bucketId, count := range repaired {
+		if count == bucketCounts[bucketId] {
+			continue
+		}
+		log.Infof("Repairing the count of bucket %d of tag %s from %d to %d", bucketId, tagId, bucketCounts[bucketId], count)
+		if err := addBucketCountFunc(tagId, bucketId, count-bucketCounts[bucketId]); err != nil {
+			return nil, err
+		}
+	}
+	return repaired, nil
+}
+
+// resetTagBucketCounts sets the counts of a deleted tag back to 0. A counter is not deleted, as a deleted counter
+// cannot be counted again if the tag is created again.
+func resetTagBucketCounts(tagId string) error {
+	bucketCounts, err := getTagBucketCounts(tagId)
+	if err != nil {
+		return err
+	}
+	for bucketId, count := range bucketCounts {
+		if err := addBucketCountFunc(tagId, bucketId, -count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillTagMetadata creates the metadata of the tags stored before the metadata existed, their member count is
+// stale so that the maintenance counts them. The tags are only listed until a pass succeeded on this instance.
+func backfillTagMetadata(now time.Time) {
+	if tagMetadataBackfilled {
+		return
+	}
+	tagIds, err := GetAllTagIds()
+	if err != nil {
+		log.Errorf("Unable to get the tags to backfill their metadata: %v", err)
+		return
+	}
+	backfilled := 0
+	for _, tagId := range tagIds {
+		if xtagging.GetOneTagMetadata(tagId) != nil {
+			continue
+		}
+		metadata := &xtagging.TagMetadata{ID: tagId, CreationSource: xtagging.LegacyCreationSource, Updated: now.UnixMilli()}
+		if err := xtagging.SetOneTagMetadata(metadata); err != nil {
+			log.Errorf("Unable to backfill the metadata of tag %s: %v", tagId, err)
+			return
+		}
+		backfilled++
+	}
+	if backfilled > 0 {
+		log.Infof("Backfilled the metadata of %d tags", backfilled)
+	}
+	tagMetadataBackfilled = true
+}
+
+func toCount(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,205 @@
+/**
+ * Copyright 2025 Comcast Cable Communications Management, LLC
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package tag
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTagStats(t *testing.T) {
+	stats := newTagStats("tag1", map[int]int{1: 4, 2: 0, 7: 1, 9: 7})
+	assert.Equal(t, &TagStats{ID: "tag1", MemberCount: 12, BucketCount: 3, MinBucketSize: 1, MaxBucketSize: 7, AvgBucketSize: 4}, stats)
+
+	empty := newTagStats("tag2", map[int]int{3: 0})
+	assert.Equal(t, 0, empty.BucketCount)
+	assert.Equal(t, 0.0, empty.AvgBucketSize)
+}
+
+func TestSortLargestTags(t *testing.T) {
+	stats := []*TagStats{{ID: "b", MemberCount: 5}, {ID: "c", MemberCount: 9}, {ID: "a", MemberCount: 5}}
+	sortLargestTags(stats)
+	assert.Equal(t, []string{"c", "a", "b"}, []string{stats[0].ID, stats[1].ID, stats[2].ID})
+}
+
+func TestToCount(t *testing.T) {
+	assert.Equal(t, 3, toCount(3))
+	assert.Equal(t, 5, toCount(int64(5)))
+	assert.Equal(t, 0, toCount(nil))
+}
+
+// fakeBucketStore keeps the rows of the buckets of a tag with the TTL of each member, the expiries and the counts
+type fakeBucketStore struct {
+	rows      map[int]map[string]int
+	expiries  map[string]bool
+	counts    map[int]int
+	populated map[int]bool
+}
+
+func withFakeBucketStore(t *testing.T) *fakeBucketStore {
+	store := &fakeBucketStore{rows: map[int]map[string]int{}, expiries: map[string]bool{}, counts: map[int]int{}, populated: map[int]bool{}}
+	originalGet, originalStore, originalDelete := getStoredBucketMembersFunc, storeBucketMembersFunc, deleteBucketMemberRowsFunc
+	originalUnlist, originalCount := unlistBucketIfEmptyFunc, addBucketCountFunc
+	originalXdas, originalExpiries := removeMembersFromXdasFunc, deleteMemberExpiriesFunc
+	getStoredBucketMembersFunc = func(tagId string, bucketId int, members []string) (map[string]int, error) {
+		stored := map[string]int{}
+		for _, member := range members {
+			if ttl, found := store.rows[bucketId][member]; found {
+				stored[member] = ttl
+			}
+		}
+		return stored, nil
+	}
+	storeBucketMembersFunc = func(tagId string, bucketId int, members []string, created string, tagValue string, ttlSeconds int) error {
+		if store.rows[bucketId] == nil {
+			store.rows[bucketId] = map[string]int{}
+		}
+		for _, member := range members {
+			store.rows[bucketId][member] = ttlSeconds
+			if ttlSeconds > 0 {
+				store.expiries[member] = true
+			}
+		}
+		store.populated[bucketId] = true
+		return nil
+	}
+	deleteBucketMemberRowsFunc = func(tagId string, bucketId int, members []string, stored map[string]int) error {
+		for _, member := range members {
+			delete(store.rows[bucketId], member)
+			if stored[member] > 0 {
+				delete(store.expiries, member)
+			}
+		}
+		return nil
+	}
+	unlistBucketIfEmptyFunc = func(tagId string, bucketId int) error {
+		if len(store.rows[bucketId]) == 0 {
+			delete(store.populated, bucketId)
+		}
+		return nil
+	}
+	addBucketCountFunc = func(tagId string, bucketId int, delta int) error {
+		store.counts[bucketId] += delta
+		return nil
+	}
+	removeMembersFromXdasFunc = func(tagId string, members []string) ([]string, error) {
+		return members, nil
+	}
+	deleteMemberExpiriesFunc = func(hour int64, tagId string, bucketId int, members []string) error {
+		for _, member := range members {
+			delete(store.expiries, member)
+		}
+		return nil
+	}
+	t.Cleanup(func() {
+		getStoredBucketMembersFunc, storeBucketMembersFunc, deleteBucketMemberRowsFunc = originalGet, originalStore, originalDelete
+		unlistBucketIfEmptyFunc, addBucketCountFunc = originalUnlist, originalCount
+		removeMembersFromXdasFunc, deleteMemberExpiriesFunc = originalXdas, originalExpiries
+	})
+	return store
+}
+
+// expire drops the row of the member as Cassandra does once its TTL has passed
+func (s *fakeBucketStore) expire(member string) {
+	delete(s.rows[getBucketId(member)], member)
+}
+
+// sweep sweeps the indexed expiry of the member from the bucket of the member
+func (s *fakeBucketStore) sweep(t *testing.T, member string) *tagExpirySweep {
+	sweep := &tagExpirySweep{}
+	expiries := []*memberExpiry{}
+	if s.expiries[member] {
+		expiries = append(expiries, &memberExpiry{member: member, expiresAt: time.Now().UnixMilli()})
+	}
+	failed, err := sweepMemberExpiries(0, "t1", getBucketId(member), expiries, sweep)
+	assert.Nil(t, err)
+	assert.Empty(t, failed)
+	return sweep
+}
+
+func (s *fakeBucketStore) assertCounted(t *testing.T, members int) {
+	total := 0
+	for bucketId, count := range s.counts {
+		assert.Equal(t, len(s.rows[bucketId]), count, "bucket %d", bucketId)
+		total += count
+	}
+	assert.Equal(t, members, total)
+	for bucketId, rows := range s.rows {
+		assert.Equal(t, len(rows) > 0, s.populated[bucketId], "bucket %d", bucketId)
+	}
+}
+
+func TestBucketCountsAcrossAddRemoveAndSweep(t *testing.T) {
+	store := withFakeBucketStore(t)
+
+	// a member added twice, or already stored, is counted once
+	assert.Nil(t, AddMembers("t1", []string{"m1", "m2", "m3", "m3"}))
+	store.assertCounted(t, 3)
+	assert.Nil(t, AddMembers("t1", []string{"m2", "m4"}))
+	store.assertCounted(t, 4)
+
+	// only the stored members are counted out
+	assert.Nil(t, RemoveMembers("t1", []string{"m1", "unknown"}))
+	store.assertCounted(t, 3)
+
+	// an expired member is counted until its expiry is swept, and it is counted out once
+	assert.Nil(t, AddMembersWithValue("t1", []string{"e1"}, "", 60))
+	store.assertCounted(t, 4)
+	store.expire("e1")
+	sweep := store.sweep(t, "e1")
+	assert.Equal(t, 1, sweep.removed)
+	store.assertCounted(t, 3)
+	assert.Equal(t, 0, store.sweep(t, "e1").removed)
+	store.assertCounted(t, 3)
+
+	// a member added again with a TTL is kept by the sweep
+	assert.Nil(t, AddMembersWithValue("t1", []string{"m2"}, "", 60))
+	assert.Equal(t, 0, store.sweep(t, "m2").removed)
+	store.assertCounted(t, 3)
+
+	// a member removed before it expired has its expiry deleted, the sweep does not count it out again
+	assert.Nil(t, AddMembersWithValue("t1", []string{"e2"}, "", 60))
+	store.assertCounted(t, 4)
+	assert.Nil(t, RemoveMembers("t1", []string{"e2"}))
+	store.assertCounted(t, 3)
+	assert.False(t, store.expiries["e2"])
+	assert.Equal(t, 0, store.sweep(t, "e2").removed)
+	store.assertCounted(t, 3)
+
+	// the bucket of the last member is unlisted
+	assert.Nil(t, RemoveMembers("t1", []string{"m2", "m3", "m4"}))
+	store.assertCounted(t, 0)
+}
+
+func TestCountNewMembers(t *testing.T) {
+	assert.Equal(t, 2, countNewMembers([]string{"a", "b", "b", "c"}, map[string]int{"a": 0}))
+	assert.Equal(t, 0, countNewMembers([]string{"a"}, map[string]int{"a": 60}))
+}
+
+func TestGetMemberExpiryHours(t *testing.T) {
+	now := time.Date(2026, 1, 1, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, []int64{getMemberExpiryHour(now.Add(time.Hour).UnixMilli())}, getMemberExpiryHours(now, 3600))
+
+	// the expiry may have been indexed in the next hour when the TTL has been truncated at the end of the hour
+	beforeEndOfHour := time.Date(2026, 1, 1, 9, 58, 59, 500000000, time.UTC)
+	hour := getMemberExpiryHour(beforeEndOfHour.Add(time.Minute).UnixMilli())
+	assert.Equal(t, []int64{hour, hour + 1}, getMemberExpiryHours(beforeEndOfHour, 60))
+}